@@ -0,0 +1,13 @@
+// Fills the lower cased search keys of users created before user search.
+//   mongosh <uri>/db_user 003_users_search_keys.js
+db.users.updateMany(
+  { email_key: { $exists: false } },
+  [
+    {
+      $set: {
+        email_key: { $toLower: "$email" },
+        name_key: { $toLower: { $ifNull: ["$display_name", ""] } },
+      },
+    },
+  ],
+)
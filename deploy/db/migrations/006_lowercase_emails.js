@@ -0,0 +1,10 @@
+// Lower cases the emails of users and credentials created before emails were
+// normalized, so their owners can still log in. Accounts differing only in the
+// case of their email fail the unique index and have to be merged by hand.
+//   mongosh <uri>/db_user 006_lowercase_emails.js
+//   mongosh <uri>/db_auth 006_lowercase_emails.js
+const collection = db.getName() === "db_auth" ? db.creds : db.users;
+collection.updateMany(
+  { email: { $regex: "[A-Z]" } },
+  [{ $set: { email: { $toLower: "$email" } } }],
+)
@@ -16,7 +16,12 @@ func main() {
 
 	log.Info("starting", slog.Any("cfg", cfg))
 
-	application := auth.New(log, &cfg.Services.Auth)
+	application, err := auth.New(log, &cfg.Services.Auth)
+	if err != nil {
+		log.Error("unable to setup application", logger.Err(err))
+		return
+	}
+
 	go application.MustRun()
 
 	stop := make(chan os.Signal, 1)
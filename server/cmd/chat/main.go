@@ -20,6 +20,7 @@ func main() {
 		log,
 		&cfg.Services.Chat,
 		&cfg.Clients.Frontend,
+		&cfg.Notifier,
 	)
 	if err != nil {
 		panic(err)
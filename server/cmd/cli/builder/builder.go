@@ -16,6 +16,7 @@ type UpstreamBuilder struct {
 
 type Builder struct {
 	upstreamBuilders map[string]*UpstreamBuilder
+	lastRequestId    uint64
 }
 
 func NewBuilder() *Builder {
@@ -53,11 +54,13 @@ func (b *Builder) Build(input string) []byte {
 		return nil
 	}
 
-	res, err := proto.Marshal(&frontendv1.Upstream{Type: builder.ut, Payload: mPayload})
+	b.lastRequestId++
+	res, err := proto.Marshal(&frontendv1.Upstream{Type: builder.ut, Payload: mPayload, RequestId: b.lastRequestId})
 	if err != nil {
 		fmt.Println("failed to marshal upstream " + err.Error())
 		return nil
 	}
+	fmt.Printf("sent #%d: %s\n", b.lastRequestId, args[0])
 
 	return res
 }
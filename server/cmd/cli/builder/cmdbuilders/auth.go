@@ -1,6 +1,7 @@
 package cmdbuilders
 
 import (
+	"fmt"
 	"github.com/dvid-messanger/cmd/cli/builder"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
@@ -9,6 +10,8 @@ import (
 func AddAuthBuilders(b *builder.Builder) {
 	b.AddBuilder("login", frontendv1.UpstreamType_U_LOGIN, BuildLogin)
 	b.AddBuilder("logout", frontendv1.UpstreamType_U_LOGOUT, BuildLogout)
+	b.AddBuilder("refresh", frontendv1.UpstreamType_U_REFRESH, BuildRefresh)
+	b.AddBuilder("auth", frontendv1.UpstreamType_U_AUTH_TOKEN, BuildAuthToken)
 }
 
 func BuildLogin(args []string) proto.Message {
@@ -18,6 +21,33 @@ func BuildLogin(args []string) proto.Message {
 	}
 }
 
-func BuildLogout(_ []string) proto.Message {
-	return &frontendv1.UpstreamLogout{}
+func BuildLogout(args []string) proto.Message {
+	upstream := &frontendv1.UpstreamLogout{}
+	if len(args) > 0 {
+		upstream.RefreshToken = args[0]
+	}
+
+	return upstream
+}
+
+func BuildRefresh(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: refresh [refresh_token]")
+		return nil
+	}
+
+	return &frontendv1.UpstreamRefresh{
+		RefreshToken: args[0],
+	}
+}
+
+func BuildAuthToken(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: auth [token]")
+		return nil
+	}
+
+	return &frontendv1.UpstreamAuthToken{
+		Token: args[0],
+	}
 }
@@ -6,13 +6,28 @@ import (
 	"github.com/dvid-messanger/cmd/cli/builder"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
+	"strconv"
+	"strings"
 )
 
 func AddChatBuilders(b *builder.Builder) {
 	b.AddBuilder("chats", frontendv1.UpstreamType_U_GET_USER_CHATS, BuildGetUserChats)
 	b.AddBuilder("cchat", frontendv1.UpstreamType_U_CREATE_CHAT, BuildCreateChat)
+	b.AddBuilder("gchat", frontendv1.UpstreamType_U_CREATE_GROUP_CHAT, BuildCreateGroupChat)
+	b.AddBuilder("addm", frontendv1.UpstreamType_U_ADD_CHAT_MEMBER, BuildAddChatMember)
+	b.AddBuilder("rmm", frontendv1.UpstreamType_U_REMOVE_CHAT_MEMBER, BuildRemoveChatMember)
+	b.AddBuilder("leave", frontendv1.UpstreamType_U_LEAVE_CHAT, BuildLeaveChat)
 	b.AddBuilder("msgs", frontendv1.UpstreamType_U_CHAT_MESSAGES, BuildChatMessages)
 	b.AddBuilder("msg", frontendv1.UpstreamType_U_SEND_MESSAGE, BuildSendMessage)
+	b.AddBuilder("reply", frontendv1.UpstreamType_U_SEND_MESSAGE, BuildReplyMessage)
+	b.AddBuilder("fwd", frontendv1.UpstreamType_U_SEND_MESSAGE, BuildForwardMessage)
+	b.AddBuilder("edit", frontendv1.UpstreamType_U_EDIT_MESSAGE, BuildEditMessage)
+	b.AddBuilder("del", frontendv1.UpstreamType_U_DELETE_MESSAGE, BuildDeleteMessage)
+	b.AddBuilder("read", frontendv1.UpstreamType_U_MARK_READ, BuildMarkRead)
+	b.AddBuilder("find", frontendv1.UpstreamType_U_SEARCH_MESSAGES, BuildSearchMessages)
+	b.AddBuilder("react", frontendv1.UpstreamType_U_ADD_REACTION, BuildAddReaction)
+	b.AddBuilder("unreact", frontendv1.UpstreamType_U_REMOVE_REACTION, BuildRemoveReaction)
+	b.AddBuilder("typing", frontendv1.UpstreamType_U_TYPING, BuildTyping)
 }
 
 func BuildGetUserChats(_ []string) proto.Message {
@@ -20,8 +35,8 @@ func BuildGetUserChats(_ []string) proto.Message {
 }
 
 func BuildChatMessages(args []string) proto.Message {
-	if len(args) < 1 {
-		fmt.Println("usage: msgs [cid]")
+	if len(args) < 1 || len(args) == 3 {
+		fmt.Println("usage: msgs [cid] [limit] [before|after] [mid]")
 		return nil
 	}
 	cid, err := base64.StdEncoding.DecodeString(args[0])
@@ -30,9 +45,65 @@ func BuildChatMessages(args []string) proto.Message {
 		return nil
 	}
 
-	return &frontendv1.UpstreamChatMessages{
+	upstream := &frontendv1.UpstreamChatMessages{
 		Cid: cid,
 	}
+	if len(args) > 1 {
+		limit, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Println("bad limit")
+			return nil
+		}
+		upstream.Limit = int32(limit)
+	}
+	if len(args) > 3 {
+		mid, err := base64.StdEncoding.DecodeString(args[3])
+		if err != nil {
+			fmt.Println("bad mid")
+			return nil
+		}
+
+		switch args[2] {
+		case "before":
+			upstream.Before = mid
+		case "after":
+			upstream.After = mid
+		default:
+			fmt.Println("bad cursor direction, expected before or after")
+			return nil
+		}
+	}
+
+	return upstream
+}
+
+func BuildSearchMessages(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: find [query, words joined with +] [cid|*] [after]")
+		return nil
+	}
+
+	upstream := &frontendv1.UpstreamSearchMessages{
+		Query: strings.ReplaceAll(args[0], "+", " "),
+	}
+	if len(args) > 1 && args[1] != "*" {
+		cid, err := base64.StdEncoding.DecodeString(args[1])
+		if err != nil {
+			fmt.Println("bad cid")
+			return nil
+		}
+		upstream.Cid = cid
+	}
+	if len(args) > 2 {
+		after, err := base64.StdEncoding.DecodeString(args[2])
+		if err != nil {
+			fmt.Println("bad after")
+			return nil
+		}
+		upstream.After = after
+	}
+
+	return upstream
 }
 
 func BuildCreateChat(args []string) proto.Message {
@@ -51,6 +122,87 @@ func BuildCreateChat(args []string) proto.Message {
 	}
 }
 
+func BuildCreateGroupChat(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: gchat [title] [uid...]")
+		return nil
+	}
+	uids := make([][]byte, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		uid, err := base64.StdEncoding.DecodeString(arg)
+		if err != nil {
+			fmt.Println("bad uid")
+			return nil
+		}
+		uids = append(uids, uid)
+	}
+
+	return &frontendv1.UpstreamCreateGroupChat{
+		Title: args[0],
+		Uids:  uids,
+	}
+}
+
+func BuildAddChatMember(args []string) proto.Message {
+	if len(args) < 2 {
+		fmt.Println("usage: addm [cid] [uid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	uid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad uid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamAddChatMember{
+		Cid: cid,
+		Uid: uid,
+	}
+}
+
+func BuildRemoveChatMember(args []string) proto.Message {
+	if len(args) < 2 {
+		fmt.Println("usage: rmm [cid] [uid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	uid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad uid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamRemoveChatMember{
+		Cid: cid,
+		Uid: uid,
+	}
+}
+
+func BuildLeaveChat(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: leave [cid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamLeaveChat{
+		Cid: cid,
+	}
+}
+
 func BuildSendMessage(args []string) proto.Message {
 	if len(args) < 2 {
 		fmt.Println("usage: msg [cid] [text]")
@@ -67,3 +219,186 @@ func BuildSendMessage(args []string) proto.Message {
 		Text: args[1],
 	}
 }
+
+func BuildReplyMessage(args []string) proto.Message {
+	if len(args) < 3 {
+		fmt.Println("usage: reply [cid] [mid] [text]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	mid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad mid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamSendMessage{
+		Cid:        cid,
+		Text:       args[2],
+		ReplyToMid: mid,
+	}
+}
+
+func BuildForwardMessage(args []string) proto.Message {
+	if len(args) < 3 {
+		fmt.Println("usage: fwd [cid] [from cid] [mid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	fromCid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad from cid")
+		return nil
+	}
+	mid, err := base64.StdEncoding.DecodeString(args[2])
+	if err != nil {
+		fmt.Println("bad mid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamSendMessage{
+		Cid:        cid,
+		ForwardCid: fromCid,
+		ForwardMid: mid,
+	}
+}
+
+func BuildEditMessage(args []string) proto.Message {
+	if len(args) < 3 {
+		fmt.Println("usage: edit [cid] [mid] [text]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	mid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad mid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamEditMessage{
+		Cid:  cid,
+		Mid:  mid,
+		Text: args[2],
+	}
+}
+
+func BuildDeleteMessage(args []string) proto.Message {
+	if len(args) < 2 {
+		fmt.Println("usage: del [cid] [mid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	mid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad mid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamDeleteMessage{
+		Cid: cid,
+		Mid: mid,
+	}
+}
+
+func BuildMarkRead(args []string) proto.Message {
+	if len(args) < 2 {
+		fmt.Println("usage: read [cid] [mid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+	mid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad mid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamMarkRead{
+		Cid: cid,
+		Mid: mid,
+	}
+}
+
+func BuildAddReaction(args []string) proto.Message {
+	if len(args) < 3 {
+		fmt.Println("usage: react [cid] [mid] [emoji]")
+		return nil
+	}
+	cid, mid, ok := decodeMessageRef(args)
+	if !ok {
+		return nil
+	}
+
+	return &frontendv1.UpstreamAddReaction{
+		Cid:   cid,
+		Mid:   mid,
+		Emoji: args[2],
+	}
+}
+
+func BuildRemoveReaction(args []string) proto.Message {
+	if len(args) < 3 {
+		fmt.Println("usage: unreact [cid] [mid] [emoji]")
+		return nil
+	}
+	cid, mid, ok := decodeMessageRef(args)
+	if !ok {
+		return nil
+	}
+
+	return &frontendv1.UpstreamRemoveReaction{
+		Cid:   cid,
+		Mid:   mid,
+		Emoji: args[2],
+	}
+}
+
+func decodeMessageRef(args []string) ([]byte, []byte, bool) {
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil, nil, false
+	}
+	mid, err := base64.StdEncoding.DecodeString(args[1])
+	if err != nil {
+		fmt.Println("bad mid")
+		return nil, nil, false
+	}
+
+	return cid, mid, true
+}
+
+func BuildTyping(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: typing [cid]")
+		return nil
+	}
+	cid, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		fmt.Println("bad cid")
+		return nil
+	}
+
+	return &frontendv1.UpstreamTyping{
+		Cid: cid,
+	}
+}
@@ -1,15 +1,45 @@
 package cmdbuilders
 
 import (
+	"encoding/base64"
+	"fmt"
 	"github.com/dvid-messanger/cmd/cli/builder"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
+	"strings"
 )
 
 func AddInfoInitBuilders(b *builder.Builder) {
 	b.AddBuilder("init", frontendv1.UpstreamType_U_INFO_INIT, BuildInfoInit)
+	b.AddBuilder("sync", frontendv1.UpstreamType_U_SYNC, BuildSync)
 }
 
 func BuildInfoInit(_ []string) proto.Message {
 	return &frontendv1.UpstreamInfoInit{}
 }
+
+func BuildSync(args []string) proto.Message {
+	upstream := &frontendv1.UpstreamSync{}
+	for _, arg := range args {
+		cidStr, midStr, ok := strings.Cut(arg, ":")
+		if !ok {
+			fmt.Println("usage: sync [cid:mid...]")
+			return nil
+		}
+
+		cid, err := base64.StdEncoding.DecodeString(cidStr)
+		if err != nil {
+			fmt.Println("bad cid")
+			return nil
+		}
+		mid, err := base64.StdEncoding.DecodeString(midStr)
+		if err != nil {
+			fmt.Println("bad mid")
+			return nil
+		}
+
+		upstream.Cursors = append(upstream.Cursors, &frontendv1.SyncCursor{Cid: cid, Mid: mid})
+	}
+
+	return upstream
+}
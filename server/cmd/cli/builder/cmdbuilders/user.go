@@ -6,13 +6,16 @@ import (
 	"github.com/dvid-messanger/cmd/cli/builder"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
+	"strings"
 )
 
 func AddUserBuilders(b *builder.Builder) {
 	b.AddBuilder("cur", frontendv1.UpstreamType_U_CUR_USER, BuildCurUser)
 	b.AddBuilder("user", frontendv1.UpstreamType_U_GET_USER, BuildGetUser)
 	b.AddBuilder("users", frontendv1.UpstreamType_U_GET_USERS, BuildGetUsers)
+	b.AddBuilder("search", frontendv1.UpstreamType_U_SEARCH_USERS, BuildSearchUsers)
 	b.AddBuilder("reg", frontendv1.UpstreamType_U_REG_USER, BuildRegUser)
+	b.AddBuilder("profile", frontendv1.UpstreamType_U_UPDATE_PROFILE, BuildUpdateProfile)
 }
 
 func BuildCurUser(_ []string) proto.Message {
@@ -39,6 +42,23 @@ func BuildGetUsers(_ []string) proto.Message {
 	return &frontendv1.UpstreamGetUsers{}
 }
 
+func BuildSearchUsers(args []string) proto.Message {
+	upstream := &frontendv1.UpstreamSearchUsers{}
+	if len(args) > 0 {
+		upstream.Query, upstream.Prefix = strings.CutPrefix(args[0], "^")
+	}
+	if len(args) > 1 {
+		after, err := base64.StdEncoding.DecodeString(args[1])
+		if err != nil {
+			fmt.Println("usage: search [query, ^ prefix to match the start] [after]")
+			return nil
+		}
+		upstream.After = after
+	}
+
+	return upstream
+}
+
 func BuildRegUser(args []string) proto.Message {
 	if len(args) < 2 {
 		fmt.Println("usage: reg [email] [password]")
@@ -48,3 +68,26 @@ func BuildRegUser(args []string) proto.Message {
 		Password: args[1],
 	}
 }
+
+func BuildUpdateProfile(args []string) proto.Message {
+	if len(args) < 1 {
+		fmt.Println("usage: profile [name|avatar|bio] [value], no value clears the field")
+		return nil
+	}
+	value := strings.Join(args[1:], " ")
+
+	upstream := &frontendv1.UpstreamUpdateProfile{}
+	switch args[0] {
+	case "name":
+		upstream.DisplayName = &value
+	case "avatar":
+		upstream.Avatar = &value
+	case "bio":
+		upstream.Bio = &value
+	default:
+		fmt.Println("unknown profile field " + args[0])
+		return nil
+	}
+
+	return upstream
+}
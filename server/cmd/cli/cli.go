@@ -2,25 +2,32 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"github.com/dvid-messanger/cmd/cli/builder"
 	"github.com/dvid-messanger/cmd/cli/builder/cmdbuilders"
 	"github.com/dvid-messanger/cmd/cli/printer"
 	"github.com/dvid-messanger/cmd/cli/printer/formatter"
 	"github.com/gorilla/websocket"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"time"
 )
 
-var addr = "localhost:20203"
+var (
+	addr  = flag.String("addr", "localhost:20203", "frontend websocket address")
+	token = flag.String("token", "", "access token to resume a session with")
+)
 
 func main() {
+	flag.Parse()
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
 
-	u := url.URL{Scheme: "ws", Host: addr, Path: "/ws"}
+	u := url.URL{Scheme: "ws", Host: *addr, Path: "/ws"}
 	log.Printf("connecting to %s", u.String())
 
 	upstreamBuilder := builder.NewBuilder()
@@ -37,7 +44,12 @@ func main() {
 	formatter.AddInfoFormatters(downstreamPrinter)
 	formatter.AddSystemFormatters(downstreamPrinter)
 
-	c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	header := http.Header{}
+	if *token != "" {
+		header.Set("Authorization", "Bearer "+*token)
+	}
+
+	c, _, err := websocket.DefaultDialer.Dial(u.String(), header)
 	if err != nil {
 		log.Fatal("dial_err:", err)
 	}
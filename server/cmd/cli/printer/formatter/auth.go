@@ -9,14 +9,28 @@ import (
 func AddAuthFormatters(printer *printer.Printer) {
 	printer.AddFormatter(frontendv1.DownstreamType_D_LOGIN, &frontendv1.DownstreamLogin{}, FormatLogin)
 	printer.AddFormatter(frontendv1.DownstreamType_D_LOGOUT, &frontendv1.DownstreamLogout{}, FormatLogout)
+	printer.AddFormatter(frontendv1.DownstreamType_D_REFRESH, &frontendv1.DownstreamRefresh{}, FormatRefresh)
+	printer.AddFormatter(frontendv1.DownstreamType_D_AUTH_TOKEN, &frontendv1.DownstreamAuthToken{}, FormatAuthToken)
 }
 
 func FormatLogin(payload proto.Message) string {
 	downstream := payload.(*frontendv1.DownstreamLogin)
 
-	return "\t{ token=" + downstream.GetToken() + " }"
+	return "\t{ token=" + downstream.GetToken() + ", refresh=" + downstream.GetRefreshToken() + " }"
+}
+
+func FormatRefresh(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamRefresh)
+
+	return "\t{ token=" + downstream.GetToken() + ", refresh=" + downstream.GetRefreshToken() + " }"
 }
 
 func FormatLogout(_ proto.Message) string {
 	return "\tlogged out"
 }
+
+func FormatAuthToken(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamAuthToken)
+
+	return "\tauthorized as " + FormatUid(downstream.GetUid())
+}
@@ -7,18 +7,38 @@ import (
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	protocolv1 "github.com/dvid-messanger/protos/gen/protocol"
 	"github.com/golang/protobuf/proto"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 func AddChatFormatters(printer *printer.Printer) {
 	printer.AddFormatter(frontendv1.DownstreamType_D_GET_CHAT, &frontendv1.DownstreamGetChat{}, FormatGetChat)
 	printer.AddFormatter(frontendv1.DownstreamType_D_GET_USER_CHATS, &frontendv1.DownstreamGetUserChats{}, FormatGetUserChats)
 	printer.AddFormatter(frontendv1.DownstreamType_D_CREATE_CHAT, &frontendv1.DownstreamCreateChat{}, FormatCreateChat)
+	printer.AddFormatter(frontendv1.DownstreamType_D_CREATE_GROUP_CHAT, &frontendv1.DownstreamCreateGroupChat{}, FormatCreateGroupChat)
+	printer.AddFormatter(frontendv1.DownstreamType_D_ADD_CHAT_MEMBER, &frontendv1.DownstreamAddChatMember{}, FormatAddChatMember)
+	printer.AddFormatter(frontendv1.DownstreamType_D_REMOVE_CHAT_MEMBER, &frontendv1.DownstreamRemoveChatMember{}, FormatRemoveChatMember)
+	printer.AddFormatter(frontendv1.DownstreamType_D_LEAVE_CHAT, &frontendv1.DownstreamLeaveChat{}, FormatLeaveChat)
+	printer.AddFormatter(frontendv1.DownstreamType_D_CHAT_UPDATED, &frontendv1.DownstreamChatUpdated{}, FormatChatUpdated)
 
 	printer.AddFormatter(frontendv1.DownstreamType_D_CHAT_MESSAGES, &frontendv1.DownstreamChatMessages{}, FormatChatMessages)
+	printer.AddFormatter(frontendv1.DownstreamType_D_SEARCH_MESSAGES, &frontendv1.DownstreamSearchMessages{}, FormatSearchMessages)
 
 	printer.AddFormatter(frontendv1.DownstreamType_D_SEND_MESSAGE, &frontendv1.DownstreamSendMessage{}, FormatSendMessage)
 	printer.AddFormatter(frontendv1.DownstreamType_D_NEW_MESSAGE, &frontendv1.DownstreamNewMessage{}, FormatNewMessage)
+	printer.AddFormatter(frontendv1.DownstreamType_D_EDIT_MESSAGE, &frontendv1.DownstreamEditMessage{}, FormatEditMessage)
+	printer.AddFormatter(frontendv1.DownstreamType_D_DELETE_MESSAGE, &frontendv1.DownstreamDeleteMessage{}, FormatDeleteMessage)
+	printer.AddFormatter(frontendv1.DownstreamType_D_MESSAGE_EDITED, &frontendv1.DownstreamMessageEdited{}, FormatMessageEdited)
+	printer.AddFormatter(frontendv1.DownstreamType_D_MESSAGE_DELETED, &frontendv1.DownstreamMessageDeleted{}, FormatMessageDeleted)
+	printer.AddFormatter(frontendv1.DownstreamType_D_MARK_READ, &frontendv1.DownstreamMarkRead{}, FormatMarkRead)
+	printer.AddFormatter(frontendv1.DownstreamType_D_MESSAGES_READ, &frontendv1.DownstreamMessagesRead{}, FormatMessagesRead)
+	printer.AddFormatter(frontendv1.DownstreamType_D_ADD_REACTION, &frontendv1.DownstreamAddReaction{}, FormatAddReaction)
+	printer.AddFormatter(frontendv1.DownstreamType_D_REMOVE_REACTION, &frontendv1.DownstreamRemoveReaction{}, FormatRemoveReaction)
+	printer.AddFormatter(frontendv1.DownstreamType_D_REACTION_UPDATED, &frontendv1.DownstreamReactionUpdated{}, FormatReactionUpdated)
+
+	printer.AddFormatter(frontendv1.DownstreamType_D_TYPING, &frontendv1.DownstreamTyping{}, FormatTyping)
+	printer.AddFormatter(frontendv1.DownstreamType_D_PRESENCE, &frontendv1.DownstreamPresence{}, FormatPresence)
 
 }
 
@@ -31,7 +51,7 @@ func FormatGetChat(payload proto.Message) string {
 func FormatGetUserChats(payload proto.Message) string {
 	downstream := payload.(*frontendv1.DownstreamGetUserChats)
 
-	return FormatChats(downstream.GetChats())
+	return FormatChats(downstream.GetChats()) + "\n\tstates:[\n" + FormatChatStates(downstream.GetStates()) + "\n\t]"
 }
 
 func FormatCreateChat(payload proto.Message) string {
@@ -40,10 +60,98 @@ func FormatCreateChat(payload proto.Message) string {
 	return FormatChat(downstream.GetChat())
 }
 
+func FormatCreateGroupChat(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamCreateGroupChat)
+
+	return FormatChat(downstream.GetChat())
+}
+
+func FormatAddChatMember(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamAddChatMember)
+
+	return FormatChat(downstream.GetChat())
+}
+
+func FormatRemoveChatMember(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamRemoveChatMember)
+
+	return FormatChat(downstream.GetChat())
+}
+
+func FormatLeaveChat(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamLeaveChat)
+
+	return FormatChat(downstream.GetChat())
+}
+
+func FormatChatUpdated(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamChatUpdated)
+
+	return FormatChat(downstream.GetChat())
+}
+
 func FormatChatMessages(payload proto.Message) string {
 	downstream := payload.(*frontendv1.DownstreamChatMessages)
 
-	return FormatMessages(downstream.GetMessages())
+	next := "\tno more messages"
+	if len(downstream.GetNext()) != 0 {
+		next = "\tnext=" + base64.StdEncoding.EncodeToString(downstream.GetNext())
+	}
+
+	reactions := make(map[string]string, len(downstream.GetReactions()))
+	for _, mr := range downstream.GetReactions() {
+		reactions[string(mr.GetMid())] = FormatReactionCounts(mr.GetCounts())
+	}
+	messages := strings.Join(cutil.Map(downstream.GetMessages(), func(msg *protocolv1.ChatMessage) string {
+		if counts, ok := reactions[string(msg.GetId())]; ok {
+			return FormatMessage(msg) + "\n\t  " + counts
+		}
+		return FormatMessage(msg)
+	}), ",\n")
+
+	return messages + "\n" + next
+}
+
+func FormatSearchMessages(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamSearchMessages)
+
+	if len(downstream.GetHits()) == 0 {
+		return "\tno messages found"
+	}
+
+	next := "\tno more messages"
+	if len(downstream.GetNext()) != 0 {
+		next = "\tnext=" + base64.StdEncoding.EncodeToString(downstream.GetNext())
+	}
+
+	hits := cutil.Map(downstream.GetHits(), func(hit *protocolv1.MessageHit) string {
+		return "\t{ chat=" + base64.StdEncoding.EncodeToString(hit.GetMessage().GetCid()) +
+			", id=" + base64.StdEncoding.EncodeToString(hit.GetMessage().GetId()) +
+			", from=" + FormatUid(hit.GetMessage().GetUid()) +
+			", text=\"" + highlight(hit) + "\" }"
+	})
+
+	return strings.Join(hits, ",\n") + "\n" + next
+}
+
+// highlight wraps the matched words of the hit snippet in brackets.
+func highlight(hit *protocolv1.MessageHit) string {
+	snippet := hit.GetSnippet()
+
+	var b strings.Builder
+	pos := 0
+	for _, hl := range hit.GetHighlights() {
+		start, end := int(hl.GetStart()), int(hl.GetEnd())
+		if start < pos || end > len(snippet) || start > end {
+			continue
+		}
+		b.WriteString(snippet[pos:start])
+		b.WriteString("[" + snippet[start:end] + "]")
+		pos = end
+	}
+	b.WriteString(snippet[pos:])
+
+	return b.String()
 }
 
 func FormatSendMessage(payload proto.Message) string {
@@ -58,11 +166,88 @@ func FormatNewMessage(payload proto.Message) string {
 	return FormatMessage(downstream.GetMessage())
 }
 
+func FormatEditMessage(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamEditMessage)
+
+	return FormatMessage(downstream.GetMessage())
+}
+
+func FormatDeleteMessage(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamDeleteMessage)
+
+	return FormatMessage(downstream.GetMessage())
+}
+
+func FormatMessageEdited(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamMessageEdited)
+
+	return FormatMessage(downstream.GetMessage())
+}
+
+func FormatMessageDeleted(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamMessageDeleted)
+
+	return FormatMessage(downstream.GetMessage())
+}
+
+func FormatMarkRead(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamMarkRead)
+
+	return FormatReadReceipt(downstream.GetReceipt())
+}
+
+func FormatMessagesRead(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamMessagesRead)
+
+	return FormatReadReceipt(downstream.GetReceipt())
+}
+
+func FormatAddReaction(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamAddReaction)
+
+	return FormatReactionUpdate(downstream.GetUpdate())
+}
+
+func FormatRemoveReaction(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamRemoveReaction)
+
+	return FormatReactionUpdate(downstream.GetUpdate())
+}
+
+func FormatReactionUpdated(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamReactionUpdated)
+
+	return FormatReactionUpdate(downstream.GetUpdate())
+}
+
+func FormatTyping(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamTyping)
+
+	return "\t{ chat=" + base64.StdEncoding.EncodeToString(downstream.GetCid()) +
+		", user=" + FormatUid(downstream.GetUid()) + " is typing }"
+}
+
+func FormatPresence(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamPresence)
+
+	status := "offline"
+	if downstream.GetOnline() {
+		status = "online"
+	}
+
+	return "\t{ chat=" + base64.StdEncoding.EncodeToString(downstream.GetCid()) +
+		", user=" + FormatUid(downstream.GetUid()) + " is " + status + " }"
+}
+
 func FormatChat(chat *protocolv1.Chat) string {
 	members := strings.Join(cutil.Map(chat.GetChatMembers(), func(member *protocolv1.ChatMember) string {
-		return "\t\t\t{ id=" + base64.StdEncoding.EncodeToString(member.GetUid()) + " }"
+		return "\t\t\t{ user=" + FormatUid(member.GetUid()) + " }"
 	}), ",\n")
-	return "\t{ id=" + base64.StdEncoding.EncodeToString(chat.GetId()) + ", type=" + chat.GetType().String() +
+	group := ""
+	if chat.GetType() == protocolv1.ChatType_GROUP {
+		group = ", title=\"" + chat.GetTitle() + "\", owner=" + FormatUid(chat.GetOwnerUid())
+	}
+	return "\t{ id=" + base64.StdEncoding.EncodeToString(chat.GetId()) + ", type=" + chat.GetType().String() + group +
 		", members=[\n" + members + "\n\t]}"
 }
 
@@ -72,10 +257,66 @@ func FormatChats(chats []*protocolv1.Chat) string {
 	}), ",\n")
 }
 
+// quoteLen is how many runes of a replied message are quoted.
+const quoteLen = 40
+
+// quotes holds texts of messages seen so far, so replies quote them.
+var quotes = struct {
+	mu sync.Mutex
+	m  map[string]string
+}{m: make(map[string]string)}
+
 func FormatMessage(msg *protocolv1.ChatMessage) string {
+	rememberQuote(msg)
+
+	text := ", text=\"" + msg.GetText() + "\""
+	switch {
+	case msg.GetDeleted():
+		text = ", deleted"
+	case msg.GetEditedAt() != 0:
+		text += ", edited"
+	}
+	if fwd := msg.GetForwardedFrom(); fwd != nil {
+		text += ", forwarded from=" + FormatUid(fwd.GetUid())
+	}
+
 	return "\t{ id=" + base64.StdEncoding.EncodeToString(msg.GetId()) +
-		", from=" + base64.StdEncoding.EncodeToString(msg.GetId()) +
-		", text=\"" + msg.Text + "\" }"
+		", from=" + FormatUid(msg.GetUid()) +
+		text + " }" + formatQuote(msg.GetReplyToMid())
+}
+
+// formatQuote prints the replied message under the reply, its id when the
+// message was not seen.
+func formatQuote(mid []byte) string {
+	if len(mid) == 0 {
+		return ""
+	}
+
+	quotes.mu.Lock()
+	quote, ok := quotes.m[string(mid)]
+	quotes.mu.Unlock()
+
+	if !ok {
+		return "\n\t  > reply to id=" + base64.StdEncoding.EncodeToString(mid)
+	}
+	return "\n\t  > " + quote
+}
+
+func rememberQuote(msg *protocolv1.ChatMessage) {
+	quotes.mu.Lock()
+	defer quotes.mu.Unlock()
+
+	if msg.GetDeleted() {
+		quotes.m[string(msg.GetId())] = "deleted message"
+		return
+	}
+
+	text := []rune(msg.GetText())
+	quote := string(text[:min(len(text), quoteLen)])
+	if len(text) > quoteLen {
+		quote += "…"
+	}
+	quotes.m[string(msg.GetId())] = FormatUid(msg.GetUid()) + ": " + quote
 }
 
 func FormatMessages(messages []*protocolv1.ChatMessage) string {
@@ -83,3 +324,39 @@ func FormatMessages(messages []*protocolv1.ChatMessage) string {
 		return FormatMessage(msg)
 	}), ",\n")
 }
+
+func FormatChatStates(states []*protocolv1.ChatState) string {
+	return strings.Join(cutil.Map(states, func(state *protocolv1.ChatState) string {
+		last := ""
+		if state.GetLastMessage() != nil {
+			last = ", last=\n\t" + FormatMessage(state.GetLastMessage())
+		}
+
+		return "\t{ chat=" + base64.StdEncoding.EncodeToString(state.GetCid()) +
+			", unread=" + strconv.FormatInt(state.GetUnread(), 10) + last + " }"
+	}), ",\n")
+}
+
+func FormatReadReceipt(receipt *protocolv1.ReadReceipt) string {
+	return "\t{ chat=" + base64.StdEncoding.EncodeToString(receipt.GetCid()) +
+		", user=" + FormatUid(receipt.GetUid()) +
+		", read=" + base64.StdEncoding.EncodeToString(receipt.GetMid()) + " }"
+}
+
+func FormatReactionUpdate(update *protocolv1.ReactionUpdate) string {
+	action := " removed "
+	if update.GetAdded() {
+		action = " added "
+	}
+
+	return "\t{ chat=" + base64.StdEncoding.EncodeToString(update.GetCid()) +
+		", id=" + base64.StdEncoding.EncodeToString(update.GetMid()) +
+		", user=" + FormatUid(update.GetUid()) + action + update.GetEmoji() +
+		", count=" + strconv.FormatInt(update.GetCount(), 10) + " }"
+}
+
+func FormatReactionCounts(counts []*protocolv1.ReactionCount) string {
+	return strings.Join(cutil.Map(counts, func(rc *protocolv1.ReactionCount) string {
+		return rc.GetEmoji() + " " + strconv.FormatInt(rc.GetCount(), 10)
+	}), "  ")
+}
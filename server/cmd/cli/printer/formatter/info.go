@@ -1,20 +1,48 @@
 package formatter
 
 import (
+	"encoding/base64"
 	"github.com/dvid-messanger/cmd/cli/printer"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
+	"strings"
 )
 
 func AddInfoFormatters(printer *printer.Printer) {
 	printer.AddFormatter(frontendv1.DownstreamType_D_INFO_INIT, &frontendv1.DownstreamInfoInit{}, FormatInfoInit)
+	printer.AddFormatter(frontendv1.DownstreamType_D_SYNC, &frontendv1.DownstreamSync{}, FormatSync)
 }
 
 func FormatInfoInit(payload proto.Message) string {
 	downstream := payload.(*frontendv1.DownstreamInfoInit)
 
+	// Members are remembered first so chats print their names.
+	members := FormatUsers(downstream.GetMembers())
 	chats := FormatChats(downstream.GetChats())
 	user := FormatUser(downstream.GetUser())
+	states := FormatChatStates(downstream.GetStates())
 
-	return "\tuser:\n" + user + "\n\tchats:[\n" + chats + "\n\t]"
+	return "\tuser:\n" + user + "\n\tchats:[\n" + chats + "\n\t]\n\tstates:[\n" + states + "\n\t]\n\tmembers:[\n" + members + "\n\t]"
+}
+
+func FormatSync(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamSync)
+
+	if len(downstream.GetChats()) == 0 {
+		return "\tup to date"
+	}
+
+	res := make([]string, 0, len(downstream.GetChats()))
+	for _, chat := range downstream.GetChats() {
+		str := "\tchat=" + base64.StdEncoding.EncodeToString(chat.GetCid()) + ":\n" + FormatMessages(chat.GetMessages())
+		if len(chat.GetAfter()) != 0 {
+			str += "\n\tmore after=" + base64.StdEncoding.EncodeToString(chat.GetAfter())
+		}
+		if len(chat.GetBefore()) != 0 {
+			str += "\n\tolder before=" + base64.StdEncoding.EncodeToString(chat.GetBefore())
+		}
+		res = append(res, str)
+	}
+
+	return strings.Join(res, "\n")
 }
@@ -9,16 +9,27 @@ import (
 	protocolv1 "github.com/dvid-messanger/protos/gen/protocol"
 	"github.com/golang/protobuf/proto"
 	"strings"
+	"sync"
 )
 
 func AddUserFormatters(printer *printer.Printer) {
 	printer.AddFormatter(frontendv1.DownstreamType_D_GET_USER, &frontendv1.DownstreamGetUser{}, FormatGetUser)
 	printer.AddFormatter(frontendv1.DownstreamType_D_GET_USERS, &frontendv1.DownstreamGetUsers{}, FormatGetUsers)
 	printer.AddFormatter(frontendv1.DownstreamType_D_CUR_USER, &frontendv1.DownstreamCurUser{}, FormatCurUser)
+	printer.AddFormatter(frontendv1.DownstreamType_D_SEARCH_USERS, &frontendv1.DownstreamSearchUsers{}, FormatSearchUsers)
 
 	printer.AddFormatter(frontendv1.DownstreamType_D_REG_USER, &frontendv1.DownstreamRegUser{}, FormatRegUser)
+	printer.AddFormatter(frontendv1.DownstreamType_D_UPDATE_PROFILE, &frontendv1.DownstreamUpdateProfile{}, FormatUpdateProfile)
+	printer.AddFormatter(frontendv1.DownstreamType_D_PROFILE_UPDATED, &frontendv1.DownstreamProfileUpdated{}, FormatProfileUpdated)
 }
 
+// names holds display names of users seen so far, so ids of known users are
+// printed as names.
+var names = struct {
+	mu sync.Mutex
+	m  map[string]string
+}{m: make(map[string]string)}
+
 func FormatGetUser(payload proto.Message) string {
 	downstream := payload.(*frontendv1.DownstreamGetUser)
 
@@ -31,6 +42,20 @@ func FormatGetUsers(payload proto.Message) string {
 	return FormatUsers(downstream.GetUsers())
 }
 
+func FormatSearchUsers(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamSearchUsers)
+
+	if len(downstream.GetUsers()) == 0 {
+		return "\tno users found"
+	}
+
+	next := ""
+	if len(downstream.GetNext()) != 0 {
+		next = "\n\tnext=" + base64.StdEncoding.EncodeToString(downstream.GetNext())
+	}
+	return FormatUsers(downstream.GetUsers()) + next
+}
+
 func FormatCurUser(payload proto.Message) string {
 	downstream := payload.(*frontendv1.DownstreamCurUser)
 
@@ -43,8 +68,59 @@ func FormatRegUser(payload proto.Message) string {
 	return FormatUser(downstream.GetUser())
 }
 
+func FormatUpdateProfile(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamUpdateProfile)
+
+	return FormatUser(downstream.GetUser())
+}
+
+func FormatProfileUpdated(payload proto.Message) string {
+	downstream := payload.(*frontendv1.DownstreamProfileUpdated)
+
+	return FormatUser(downstream.GetUser())
+}
+
 func FormatUser(user *protocolv1.User) string {
-	return fmt.Sprintf("\t{ id=%s, email=%s, bio=%s}", base64.StdEncoding.EncodeToString(user.Id), user.Email, user.Bio)
+	rememberName(user)
+
+	avatar := ""
+	if user.GetAvatar() != "" {
+		avatar = ", avatar=" + user.GetAvatar()
+	}
+	return fmt.Sprintf(
+		"\t{ name=\"%s\", id=%s, email=%s, bio=%s%s }",
+		displayName(user),
+		base64.StdEncoding.EncodeToString(user.GetId()),
+		user.GetEmail(),
+		user.GetBio(),
+		avatar,
+	)
+}
+
+// FormatUid prints the display name of a known user, the id otherwise.
+func FormatUid(uid []byte) string {
+	names.mu.Lock()
+	name, ok := names.m[string(uid)]
+	names.mu.Unlock()
+
+	if !ok {
+		return base64.StdEncoding.EncodeToString(uid)
+	}
+	return "\"" + name + "\""
+}
+
+func rememberName(user *protocolv1.User) {
+	names.mu.Lock()
+	defer names.mu.Unlock()
+
+	names.m[string(user.GetId())] = displayName(user)
+}
+
+func displayName(user *protocolv1.User) string {
+	if user.GetDisplayName() != "" {
+		return user.GetDisplayName()
+	}
+	return user.GetEmail()
 }
 
 func FormatUsers(users []*protocolv1.User) string {
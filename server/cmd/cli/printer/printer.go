@@ -7,8 +7,6 @@ import (
 	"reflect"
 )
 
-const prefix = "recv: "
-
 type FormatterFunc = func(downstream proto.Message) string
 
 type Formatter struct {
@@ -33,9 +31,12 @@ func (p *Printer) AddFormatter(dt frontendv1.DownstreamType, msg proto.Message,
 func (p *Printer) Print(data []byte) {
 	downstream := &frontendv1.Downstream{}
 	if err := proto.Unmarshal(data, downstream); err != nil {
-		fmt.Println(prefix + "error during unmarshalling " + err.Error())
+		fmt.Println("recv: error during unmarshalling " + err.Error())
+		return
 	}
 
+	prefix := formatPrefix(downstream)
+
 	if downstream.GetError() != nil && downstream.Error.GetCode() != frontendv1.ErrorCode_NO_ERROR {
 		fmt.Println(prefix + formatError(downstream))
 		return
@@ -55,6 +56,21 @@ func (p *Printer) Print(data []byte) {
 	fmt.Println(prefix + downstream.GetType().String() + " {\n" + formatter.fun(payload) + "\n}")
 }
 
+func formatPrefix(downstream *frontendv1.Downstream) string {
+	if downstream.GetPush() {
+		return "push: "
+	}
+	return fmt.Sprintf("recv #%d: ", downstream.GetRequestId())
+}
+
 func formatError(downstream *frontendv1.Downstream) string {
-	return fmt.Sprintf("error: code=%d desc=\"%s\"", downstream.GetError().Code, downstream.GetError().Desc)
+	dErr := downstream.GetError()
+	res := fmt.Sprintf("error: code=%d desc=\"%s\"", dErr.GetCode(), dErr.GetDesc())
+	if dErr.GetReason() != "" {
+		res += " reason=" + dErr.GetReason()
+	}
+	if dErr.GetRetryAfterMs() != 0 {
+		res += fmt.Sprintf(" retry_after=%dms", dErr.GetRetryAfterMs())
+	}
+	return res
 }
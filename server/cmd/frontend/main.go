@@ -19,25 +19,37 @@ func main() {
 	application, err := frontend.New(
 		log,
 		cfg.Services.Frontend.Port,
-		[]byte(cfg.Services.Auth.Secret),
+		cfg.Services.Frontend.KeysTTL,
 		cfg.Services.Frontend.WsPort,
 		cfg.Services.Frontend.WsBasePath,
 		cfg.Services.Frontend.SendBuffSize,
+		cfg.Services.Frontend.SendPolicy,
+		cfg.Services.Frontend.SendStatsEvery,
 		cfg.Services.Frontend.RBuffSize,
 		cfg.Services.Frontend.WBuffSize,
 		cfg.Services.Frontend.HsTimeout,
 		cfg.Services.Frontend.MsgLimit,
 		cfg.Services.Frontend.WriteWait,
 		cfg.Services.Frontend.PongWait,
+		cfg.Services.Frontend.DrainTimeout,
+		cfg.Services.Frontend.TypingThrottle,
+		cfg.Services.Frontend.RevocationTTL,
+		cfg.Services.Frontend.PresenceTTL,
+		cfg.Services.Frontend.Concurrency,
+		cfg.Services.Frontend.RateLimit,
 		cfg.Clients.User.Address,
+		cfg.Clients.User.InternalAddress,
 		cfg.Clients.User.Timeout,
 		cfg.Clients.User.RetriesCount,
 		cfg.Clients.Auth.Address,
+		cfg.Clients.Auth.InternalAddress,
 		cfg.Clients.Auth.Timeout,
 		cfg.Clients.Auth.RetriesCount,
 		cfg.Clients.Chat.Address,
 		cfg.Clients.Chat.Timeout,
 		cfg.Clients.Chat.RetriesCount,
+		cfg.Notifier.Backend,
+		cfg.Notifier.BrokerAddress,
 	)
 	if err != nil {
 		log.Error("unable to setup application", logger.Err(err))
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"github.com/dvid-messanger/internal/config"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/database/scylladb"
+	"github.com/gocql/gocql"
+	"log/slog"
+	"time"
+)
+
+const (
+	stepSnapshot = "snapshot"
+	stepRestore  = "restore"
+)
+
+const (
+	statementSelectMessages = "SELECT cid, mid, uid, text, writetime(uid) FROM messages"
+	statementInsertLegacy   = "INSERT INTO messages_legacy(cid, mid, uid, text, sent_at) VALUES(?,?,?,?,?)"
+	statementSelectLegacy   = "SELECT cid, mid, uid, text, sent_at FROM messages_legacy"
+	statementInsert         = "INSERT INTO messages(cid, mid, uid, text) VALUES(?,?,?,?)"
+)
+
+// timeBase is the start of timeuuid time in seconds since the Unix epoch.
+var timeBase = time.Date(1582, time.October, 15, 0, 0, 0, 0, time.UTC).Unix()
+
+// Moves messages to the timeuuid keyed messages table in two steps, see
+// deploy/db/migrations/001_messages_timeuuid.cql.
+func main() {
+	step := flag.String("step", "", "migration step, snapshot or restore")
+	cfg := config.MustLoad()
+	log := logger.MustSetupLogger(cfg.Env, cfg.LogLevel)
+
+	if *step != stepSnapshot && *step != stepRestore {
+		log.Error("unknown step " + *step)
+		return
+	}
+
+	session, err := scylladb.NewSession(scylladb.CreateCluster(
+		gocql.Quorum,
+		cfg.Services.Chat.MessageStorage.Keyspace,
+		cfg.Services.Chat.MessageStorage.Hosts...,
+	))
+	if err != nil {
+		log.Error("failed to connect to message storage", logger.Err(err))
+		return
+	}
+	defer session.Close()
+
+	var migrated int
+	if *step == stepSnapshot {
+		migrated, err = snapshot(session)
+	} else {
+		migrated, err = restore(session)
+	}
+	if err != nil {
+		log.Error("failed to migrate messages", slog.String("step", *step), logger.Err(err))
+		return
+	}
+
+	log.Info("messages migrated", slog.String("step", *step), slog.Int("count", migrated))
+}
+
+// snapshot copies legacy messages to messages_legacy along with their write
+// time in microseconds, the only trace of when they were sent.
+func snapshot(session *gocql.Session) (int, error) {
+	iter := session.Query(statementSelectMessages).Iter()
+	scanner := iter.Scanner()
+	defer iter.Close()
+
+	migrated := 0
+	for scanner.Next() {
+		var cid, mid, uid []byte
+		var text string
+		var sentAt int64
+
+		if err := scanner.Scan(&cid, &mid, &uid, &text, &sentAt); err != nil {
+			return migrated, err
+		}
+		if err := session.Query(statementInsertLegacy, cid, mid, uid, text, sentAt).Exec(); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, scanner.Err()
+}
+
+// restore inserts messages_legacy rows into the recreated messages table with
+// timeuuids of their send time, the Unix epoch for a message without one. The
+// ids depend on the rows only, so a rerun after a failure overwrites the
+// messages already copied.
+func restore(session *gocql.Session) (int, error) {
+	iter := session.Query(statementSelectLegacy).Iter()
+	scanner := iter.Scanner()
+	defer iter.Close()
+
+	migrated := 0
+	for scanner.Next() {
+		var cid, mid, uid []byte
+		var text string
+		var sentAt int64
+
+		if err := scanner.Scan(&cid, &mid, &uid, &text, &sentAt); err != nil {
+			return migrated, err
+		}
+		if err := session.Query(statementInsert, cid, legacyId(time.UnixMicro(sentAt), mid), uid, text).Exec(); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, scanner.Err()
+}
+
+// legacyId makes a version 1 timeuuid of sent, taking its clock sequence and
+// node from the legacy id instead of random ones. Messages of the same
+// microsecond keep distinct ids.
+func legacyId(sent time.Time, legacy []byte) gocql.UUID {
+	ts := uint64(sent.Unix()-timeBase)*10_000_000 + uint64(sent.Nanosecond()/100)
+
+	var u gocql.UUID
+	binary.BigEndian.PutUint32(u[0:], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:], uint16(ts>>48)&0x0fff|0x1000)
+	copy(u[8:], legacy[8:])
+	u[8] = u[8]&0x3f | 0x80
+
+	return u
+}
@@ -2,14 +2,14 @@ package auth
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/core/service/auth"
-	gvalidate "github.com/dvid-messanger/internal/pkg/grpc"
+	grpcutil "github.com/dvid-messanger/internal/pkg/grpc"
 	authv1 "github.com/dvid-messanger/protos/gen/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type serverApi struct {
@@ -21,24 +21,89 @@ func Register(gRpc *grpc.Server, auth primary.Auth) {
 	authv1.RegisterAuthServiceServer(gRpc, &serverApi{auth: auth})
 }
 
+type internalApi struct {
+	authv1.UnimplementedAuthInternalServiceServer
+	auth primary.Auth
+}
+
+// RegisterInternal registers the calls meant for other services only, gRpc
+// must not listen where clients can reach it.
+func RegisterInternal(gRpc *grpc.Server, auth primary.Auth) {
+	authv1.RegisterAuthInternalServiceServer(gRpc, &internalApi{auth: auth})
+}
+
+func (s *internalApi) Delete(ctx context.Context, req *authv1.DeleteRequest) (*authv1.DeleteResponse, error) {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return nil, err
+	}
+
+	if err := s.auth.Delete(ctx, req.GetUid()); err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &authv1.DeleteResponse{}, nil
+}
+
 func (s *serverApi) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.LoginResponse, error) {
 	if err := validateLogin(req); err != nil {
 		return nil, err
 	}
 
-	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword())
+	pair, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword())
 	if err != nil {
 		if errors.Is(err, auth.ErrInvalidCredentials) {
-			return nil, status.Error(codes.InvalidArgument, "invalid email or password")
+			return nil, grpcutil.Error(codes.Unauthenticated, grpcutil.ReasonInvalidCredentials, "invalid email or password")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 
-	return &authv1.LoginResponse{Token: token}, nil
+	return &authv1.LoginResponse{Token: pair.Access, RefreshToken: pair.Refresh}, nil
 }
 
-func (s *serverApi) Register(ctx context.Context, req *authv1.CreateRequest) (*authv1.CreateResponse, error) {
+func (s *serverApi) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.RefreshResponse, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, grpcutil.FieldError("refreshToken", "is required")
+	}
+
+	pair, err := s.auth.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, grpcutil.Error(codes.Unauthenticated, grpcutil.ReasonInvalidToken, "invalid refresh token")
+		}
+
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &authv1.RefreshResponse{Token: pair.Access, RefreshToken: pair.Refresh}, nil
+}
+
+func (s *serverApi) Logout(ctx context.Context, req *authv1.LogoutRequest) (*authv1.LogoutResponse, error) {
+	if req.GetToken() == "" && req.GetRefreshToken() == "" {
+		return nil, grpcutil.FieldError("token", "or refresh token is required")
+	}
+
+	if err := s.auth.Logout(ctx, req.GetToken(), req.GetRefreshToken()); err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &authv1.LogoutResponse{}, nil
+}
+
+func (s *serverApi) Revoked(ctx context.Context, req *authv1.RevokedRequest) (*authv1.RevokedResponse, error) {
+	if req.GetJti() == "" {
+		return nil, grpcutil.FieldError("jti", "is required")
+	}
+
+	revoked, err := s.auth.Revoked(ctx, req.GetJti())
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &authv1.RevokedResponse{Revoked: revoked}, nil
+}
+
+func (s *serverApi) Create(ctx context.Context, req *authv1.CreateRequest) (*authv1.CreateResponse, error) {
 	if err := validateRegister(req); err != nil {
 		return nil, err
 	}
@@ -46,34 +111,53 @@ func (s *serverApi) Register(ctx context.Context, req *authv1.CreateRequest) (*a
 	uid, err := s.auth.Create(ctx, req.GetUid(), req.GetEmail(), req.GetPassword())
 	if err != nil {
 		if errors.Is(err, auth.ErrUserExists) {
-			return nil, status.Error(codes.AlreadyExists, "user already exists")
+			return nil, grpcutil.Error(codes.AlreadyExists, grpcutil.ReasonUserExists, "user already exists")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 	return &authv1.CreateResponse{Uid: uid}, nil
 }
 
+func (s *serverApi) PublicKeys(ctx context.Context, _ *authv1.PublicKeysRequest) (*authv1.PublicKeysResponse, error) {
+	keys, err := s.auth.PublicKeys(ctx)
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	resp := &authv1.PublicKeysResponse{Keys: make([]*authv1.PublicKey, 0, len(keys))}
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(key.Key)
+		if err != nil {
+			return nil, grpcutil.ErrInternal
+		}
+
+		resp.Keys = append(resp.Keys, &authv1.PublicKey{Kid: key.Id, Alg: key.Alg, Der: der})
+	}
+
+	return resp, nil
+}
+
 func validateLogin(req *authv1.LoginRequest) error {
 	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
+		return grpcutil.FieldError("email", "is required")
 	}
 	if req.GetPassword() == "" {
-		return status.Error(codes.InvalidArgument, "password is required")
+		return grpcutil.FieldError("password", "is required")
 	}
 
 	return nil
 }
 
 func validateRegister(req *authv1.CreateRequest) error {
-	if err := gvalidate.ValidateId(req.GetUid(), "uid"); err != nil {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
 		return err
 	}
 	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
+		return grpcutil.FieldError("email", "is required")
 	}
 	if req.GetPassword() == "" {
-		return status.Error(codes.InvalidArgument, "password is required")
+		return grpcutil.FieldError("password", "is required")
 	}
 
 	return nil
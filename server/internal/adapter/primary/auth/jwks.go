@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"encoding/json"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/pkg/jwt"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"log/slog"
+	"net/http"
+)
+
+const JWKSPath = "/.well-known/jwks.json"
+
+type JWKSHandler struct {
+	log  *slog.Logger
+	auth primary.Auth
+}
+
+func NewJWKSHandler(log *slog.Logger, auth primary.Auth) *JWKSHandler {
+	return &JWKSHandler{log: log, auth: auth}
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "auth.JWKS"
+	log := h.log.With(slog.String("op", op))
+
+	keys, err := h.auth.PublicKeys(r.Context())
+	if err != nil {
+		log.Error("failed to get public keys", logger.Err(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	set, err := jwt.NewJWKSet(keys)
+	if err != nil {
+		log.Error("failed to build jwks", logger.Err(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if err = json.NewEncoder(w).Encode(set); err != nil {
+		log.Error("failed to write jwks", logger.Err(err))
+	}
+}
@@ -5,14 +5,18 @@ import (
 	"errors"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/core/domain/converter"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/internal/core/service/chat"
 	grpcutil "github.com/dvid-messanger/internal/pkg/grpc"
 	chatv1 "github.com/dvid-messanger/protos/gen/chat"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"unicode/utf8"
 )
 
+const maxSearchQueryLen = 128
+
 type serverApi struct {
 	chatv1.UnimplementedChatServiceServer
 	chat primary.Chat
@@ -30,27 +34,78 @@ func (s *serverApi) Create(ctx context.Context, req *chatv1.CreateChatRequest) (
 	c, err := s.chat.Create(ctx, req.GetFromUid(), req.GetToUid())
 	if err != nil {
 		if errors.Is(err, chat.ErrChatExists) {
-			return nil, status.Error(codes.AlreadyExists, "chat already exists")
+			return nil, grpcutil.Error(codes.AlreadyExists, grpcutil.ReasonChatExists, "chat already exists")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 
 	return &chatv1.CreateChatResponse{Chat: converter.ChatToDTO(c)}, nil
 }
 
+func (s *serverApi) CreateGroup(
+	ctx context.Context,
+	req *chatv1.CreateGroupChatRequest,
+) (*chatv1.CreateGroupChatResponse, error) {
+	if err := validateCreateGroup(req); err != nil {
+		return nil, err
+	}
+
+	c, err := s.chat.CreateGroup(ctx, req.GetOwnerUid(), req.GetTitle(), req.GetMemberUids())
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &chatv1.CreateGroupChatResponse{Chat: converter.ChatToDTO(c)}, nil
+}
+
+func (s *serverApi) AddMember(ctx context.Context, req *chatv1.AddMemberRequest) (*chatv1.AddMemberResponse, error) {
+	if err := validateAddMember(req); err != nil {
+		return nil, err
+	}
+
+	c, err := s.chat.AddMember(ctx, req.GetCid(), req.GetUid(), req.GetMemberUid())
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return &chatv1.AddMemberResponse{Chat: converter.ChatToDTO(c)}, nil
+}
+
+func (s *serverApi) RemoveMember(ctx context.Context, req *chatv1.RemoveMemberRequest) (*chatv1.RemoveMemberResponse, error) {
+	if err := validateRemoveMember(req); err != nil {
+		return nil, err
+	}
+
+	c, err := s.chat.RemoveMember(ctx, req.GetCid(), req.GetUid(), req.GetMemberUid())
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return &chatv1.RemoveMemberResponse{Chat: converter.ChatToDTO(c)}, nil
+}
+
+func (s *serverApi) Leave(ctx context.Context, req *chatv1.LeaveRequest) (*chatv1.LeaveResponse, error) {
+	if err := validateLeave(req); err != nil {
+		return nil, err
+	}
+
+	c, err := s.chat.Leave(ctx, req.GetCid(), req.GetUid())
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return &chatv1.LeaveResponse{Chat: converter.ChatToDTO(c)}, nil
+}
+
 func (s *serverApi) Chat(ctx context.Context, req *chatv1.ChatRequest) (*chatv1.ChatResponse, error) {
 	if err := validateChat(req); err != nil {
 		return nil, err
 	}
 
-	c, err := s.chat.Chat(ctx, req.GetCid())
+	c, err := s.chat.Chat(ctx, req.GetCid(), req.GetUid())
 	if err != nil {
-		if errors.Is(err, chat.ErrChatNotFound) {
-			return nil, status.Error(codes.NotFound, "chat not found")
-		}
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, accessError(err)
 	}
 
 	return &chatv1.ChatResponse{Chat: converter.ChatToDTO(c)}, nil
@@ -64,46 +119,183 @@ func (s *serverApi) UserChats(ctx context.Context, req *chatv1.UserChatsRequest)
 	chats, err := s.chat.UserChats(ctx, req.GetUid())
 	if err != nil {
 		if errors.Is(err, chat.ErrUserChatsNotFound) {
-			return nil, status.Error(codes.NotFound, "user chats not found")
+			return nil, grpcutil.Error(codes.NotFound, grpcutil.ReasonUserNotFound, "user chats not found")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 
 	return &chatv1.UserChatsResponse{Chats: converter.ChatsToDTO(chats)}, nil
 }
 
-func (s *serverApi) SendMessage(ctx context.Context, req *chatv1.SendMessageRequest) (*chatv1.SendMessageResponse, error) {
-	if err := validateSendMessage(req); err != nil {
+func (s *serverApi) ChatStates(ctx context.Context, req *chatv1.ChatStatesRequest) (*chatv1.ChatStatesResponse, error) {
+	if err := validateChatStates(req); err != nil {
+		return nil, err
+	}
+
+	states, err := s.chat.ChatStates(ctx, req.GetUid())
+	if err != nil {
+		if errors.Is(err, chat.ErrUserChatsNotFound) {
+			return nil, grpcutil.Error(codes.NotFound, grpcutil.ReasonUserNotFound, "user chats not found")
+		}
+
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &chatv1.ChatStatesResponse{States: converter.ChatStatesToDTO(states)}, nil
+}
+
+func (s *serverApi) LastMessages(
+	ctx context.Context,
+	req *chatv1.LastMessagesRequest,
+) (*chatv1.LastMessagesResponse, error) {
+	if err := validateLastMessages(req); err != nil {
 		return nil, err
 	}
 
-	msg, err := s.chat.SendMessage(ctx, req.GetCid(), req.GetUid(), req.GetText())
+	messages, err := s.chat.LastMessages(ctx, req.GetUid())
 	if err != nil {
-		if errors.Is(err, chat.ErrChatNotFound) {
-			return nil, status.Error(codes.NotFound, "chat not found")
+		if errors.Is(err, chat.ErrUserChatsNotFound) {
+			return nil, grpcutil.Error(codes.NotFound, grpcutil.ReasonUserNotFound, "user chats not found")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &chatv1.LastMessagesResponse{Messages: converter.ChatMessagesToDTO(messages)}, nil
+}
+
+func (s *serverApi) SendMessage(ctx context.Context, req *chatv1.SendMessageRequest) (*chatv1.SendMessageResponse, error) {
+	if err := validateSendMessage(req); err != nil {
+		return nil, err
+	}
+
+	refs := model.MessageRefs{ReplyTo: req.GetReplyToMid()}
+	if len(req.GetForwardMid()) != 0 {
+		refs.Forward = &model.ForwardedFrom{Cid: req.GetForwardCid(), Mid: req.GetForwardMid()}
+	}
+
+	msg, err := s.chat.SendMessage(ctx, req.GetCid(), req.GetUid(), req.GetText(), refs)
+	if err != nil {
+		return nil, accessError(err)
 	}
 
 	return &chatv1.SendMessageResponse{Message: converter.ChatMessageToDTO(msg)}, nil
 }
+
+func (s *serverApi) EditMessage(ctx context.Context, req *chatv1.EditMessageRequest) (*chatv1.EditMessageResponse, error) {
+	if err := validateEditMessage(req); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.chat.EditMessage(ctx, req.GetCid(), req.GetMid(), req.GetUid(), req.GetText())
+	if err != nil {
+		return nil, accessError(err)
+	}
+
+	return &chatv1.EditMessageResponse{Message: converter.ChatMessageToDTO(msg)}, nil
+}
+
+func (s *serverApi) DeleteMessage(ctx context.Context, req *chatv1.DeleteMessageRequest) (*chatv1.DeleteMessageResponse, error) {
+	if err := validateDeleteMessage(req); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.chat.DeleteMessage(ctx, req.GetCid(), req.GetMid(), req.GetUid())
+	if err != nil {
+		return nil, accessError(err)
+	}
+
+	return &chatv1.DeleteMessageResponse{Message: converter.ChatMessageToDTO(msg)}, nil
+}
+
 func (s *serverApi) Messages(ctx context.Context, req *chatv1.MessagesRequest) (*chatv1.MessagesResponse, error) {
 	if err := validateMessages(req); err != nil {
 		return nil, err
 	}
 
-	c, err := s.chat.Messages(ctx, req.GetCid())
+	page, err := s.chat.Messages(
+		ctx,
+		req.GetCid(),
+		req.GetUid(),
+		req.GetBefore(),
+		req.GetAfter(),
+		int(req.GetLimit()),
+	)
 	if err != nil {
-		if errors.Is(err, chat.ErrChatNotFound) {
-			return nil, status.Error(codes.NotFound, "chat not found")
+		return nil, accessError(err)
+	}
+
+	return &chatv1.MessagesResponse{
+		Messages:  converter.ChatMessagesToDTO(page.Messages),
+		Next:      page.Next,
+		Reactions: converter.MessageReactionsToDTO(page.Reactions),
+	}, nil
+}
+
+func (s *serverApi) SearchMessages(
+	ctx context.Context,
+	req *chatv1.SearchMessagesRequest,
+) (*chatv1.SearchMessagesResponse, error) {
+	if err := validateSearchMessages(req); err != nil {
+		return nil, err
+	}
+
+	page, err := s.chat.SearchMessages(
+		ctx,
+		req.GetUid(),
+		req.GetCid(),
+		req.GetQuery(),
+		req.GetAfter(),
+		int(req.GetLimit()),
+	)
+	if err != nil {
+		if errors.Is(err, chat.ErrInvalidCursor) {
+			return nil, grpcutil.FieldError("after", "is bad")
 		}
+		return nil, accessError(err)
+	}
+
+	return &chatv1.SearchMessagesResponse{Hits: converter.MessageHitsToDTO(page.Hits), Next: page.Next}, nil
+}
+
+func (s *serverApi) MarkRead(ctx context.Context, req *chatv1.MarkReadRequest) (*chatv1.MarkReadResponse, error) {
+	if err := validateMarkRead(req); err != nil {
+		return nil, err
+	}
 
-		return nil, status.Error(codes.Internal, "internal error")
+	receipt, err := s.chat.MarkRead(ctx, req.GetCid(), req.GetMid(), req.GetUid())
+	if err != nil {
+		return nil, accessError(err)
 	}
 
-	return &chatv1.MessagesResponse{Messages: converter.ChatMessagesToDTO(c)}, nil
+	return &chatv1.MarkReadResponse{Receipt: converter.ReadReceiptToDTO(receipt)}, nil
+}
+
+func (s *serverApi) AddReaction(ctx context.Context, req *chatv1.ReactionRequest) (*chatv1.ReactionResponse, error) {
+	if err := validateReaction(req); err != nil {
+		return nil, err
+	}
+
+	update, err := s.chat.AddReaction(ctx, req.GetCid(), req.GetMid(), req.GetUid(), req.GetEmoji())
+	if err != nil {
+		return nil, reactionError(err)
+	}
+
+	return &chatv1.ReactionResponse{Update: converter.ReactionUpdateToDTO(update)}, nil
+}
+
+func (s *serverApi) RemoveReaction(ctx context.Context, req *chatv1.ReactionRequest) (*chatv1.ReactionResponse, error) {
+	if err := validateReaction(req); err != nil {
+		return nil, err
+	}
+
+	update, err := s.chat.RemoveReaction(ctx, req.GetCid(), req.GetMid(), req.GetUid(), req.GetEmoji())
+	if err != nil {
+		return nil, reactionError(err)
+	}
+
+	return &chatv1.ReactionResponse{Update: converter.ReactionUpdateToDTO(update)}, nil
 }
 
 func validateCreate(req *chatv1.CreateChatRequest) error {
@@ -117,10 +309,68 @@ func validateCreate(req *chatv1.CreateChatRequest) error {
 	return nil
 }
 
+func validateCreateGroup(req *chatv1.CreateGroupChatRequest) error {
+	if err := grpcutil.ValidateId(req.GetOwnerUid(), "ownerUid"); err != nil {
+		return err
+	}
+	if len(req.GetTitle()) == 0 {
+		return grpcutil.FieldError("title", "is required")
+	}
+	for _, uid := range req.GetMemberUids() {
+		if err := grpcutil.ValidateId(uid, "memberUid"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateAddMember(req *chatv1.AddMemberRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetMemberUid(), "memberUid"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateRemoveMember(req *chatv1.RemoveMemberRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetMemberUid(), "memberUid"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateLeave(req *chatv1.LeaveRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func validateChat(req *chatv1.ChatRequest) error {
 	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
 		return err
 	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -133,6 +383,22 @@ func validateUserChats(req *chatv1.UserChatsRequest) error {
 	return nil
 }
 
+func validateChatStates(req *chatv1.ChatStatesRequest) error {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateLastMessages(req *chatv1.LastMessagesRequest) error {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func validateSendMessage(req *chatv1.SendMessageRequest) error {
 	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
 		return err
@@ -140,8 +406,60 @@ func validateSendMessage(req *chatv1.SendMessageRequest) error {
 	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
 		return err
 	}
+	if len(req.GetReplyToMid()) != 0 {
+		if err := grpcutil.ValidateId(req.GetReplyToMid(), "replyToMid"); err != nil {
+			return err
+		}
+	}
+	if len(req.GetForwardCid()) == 0 && len(req.GetForwardMid()) == 0 {
+		if len(req.GetText()) == 0 {
+			return grpcutil.FieldError("text", "is required")
+		}
+		return nil
+	}
+
+	if err := grpcutil.ValidateId(req.GetForwardCid(), "forwardCid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetForwardMid(), "forwardMid"); err != nil {
+		return err
+	}
+	if len(req.GetReplyToMid()) != 0 {
+		return grpcutil.FieldError("forwardMid", "and replyToMid are mutually exclusive")
+	}
+	if len(req.GetText()) != 0 {
+		return grpcutil.FieldError("text", "must be empty when forwarding")
+	}
+
+	return nil
+}
+
+func validateEditMessage(req *chatv1.EditMessageRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetMid(), "mid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
 	if len(req.GetText()) == 0 {
-		return status.Error(codes.InvalidArgument, "text is required")
+		return grpcutil.FieldError("text", "is required")
+	}
+
+	return nil
+}
+
+func validateDeleteMessage(req *chatv1.DeleteMessageRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetMid(), "mid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
 	}
 
 	return nil
@@ -151,6 +469,131 @@ func validateMessages(req *chatv1.MessagesRequest) error {
 	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
 		return err
 	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+	if len(req.GetBefore()) != 0 && len(req.GetAfter()) != 0 {
+		return grpcutil.FieldError("before", "and after are mutually exclusive")
+	}
+	if len(req.GetBefore()) != 0 {
+		if err := grpcutil.ValidateId(req.GetBefore(), "before"); err != nil {
+			return err
+		}
+	}
+	if len(req.GetAfter()) != 0 {
+		if err := grpcutil.ValidateId(req.GetAfter(), "after"); err != nil {
+			return err
+		}
+	}
+	if req.GetLimit() < 0 {
+		return grpcutil.FieldError("limit", "is bad")
+	}
+
+	return nil
+}
+
+func validateSearchMessages(req *chatv1.SearchMessagesRequest) error {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+	if len(req.GetCid()) != 0 {
+		if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+			return err
+		}
+	}
+	if len(req.GetQuery()) == 0 {
+		return grpcutil.FieldError("query", "is required")
+	}
+	if utf8.RuneCountInString(req.GetQuery()) > maxSearchQueryLen {
+		return grpcutil.FieldError("query", "is too long")
+	}
+	if req.GetLimit() < 0 {
+		return grpcutil.FieldError("limit", "is bad")
+	}
+
+	return nil
+}
+
+func validateMarkRead(req *chatv1.MarkReadRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetMid(), "mid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateReaction(req *chatv1.ReactionRequest) error {
+	if err := grpcutil.ValidateId(req.GetCid(), "cid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetMid(), "mid"); err != nil {
+		return err
+	}
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return err
+	}
+	if len(req.GetEmoji()) == 0 {
+		return grpcutil.FieldError("emoji", "is required")
+	}
 
 	return nil
 }
+
+func membershipError(err error) error {
+	switch {
+	case errors.Is(err, chat.ErrChatNotFound):
+		return grpcutil.Error(codes.NotFound, grpcutil.ReasonChatNotFound, "chat not found")
+	case errors.Is(err, chat.ErrMemberNotFound):
+		return grpcutil.Error(codes.NotFound, grpcutil.ReasonMemberNotFound, "chat member not found")
+	case errors.Is(err, chat.ErrMemberExists):
+		return grpcutil.Error(codes.AlreadyExists, grpcutil.ReasonMemberExists, "chat member already exists")
+	case errors.Is(err, chat.ErrNotGroupChat):
+		return grpcutil.Error(codes.FailedPrecondition, grpcutil.ReasonNotGroupChat, "not a group chat")
+	case errors.Is(err, chat.ErrOwnerCannotLeave):
+		return grpcutil.Error(codes.FailedPrecondition, grpcutil.ReasonOwnerCannotLeave, "chat owner can not leave chat")
+	case errors.Is(err, chat.ErrNotChatOwner):
+		return grpcutil.Error(codes.PermissionDenied, grpcutil.ReasonNotChatOwner, "not a chat owner")
+	case errors.Is(err, chat.ErrNotChatMember):
+		return grpcutil.Error(codes.PermissionDenied, grpcutil.ReasonNotChatMember, "not a chat member")
+	default:
+		return grpcutil.ErrInternal
+	}
+}
+
+func accessError(err error) error {
+	switch {
+	case errors.Is(err, chat.ErrChatNotFound):
+		return grpcutil.Error(codes.NotFound, grpcutil.ReasonChatNotFound, "chat not found")
+	case errors.Is(err, chat.ErrMessagesNotFound):
+		return grpcutil.Error(codes.NotFound, grpcutil.ReasonMessageNotFound, "chat messages not found")
+	case errors.Is(err, chat.ErrMessageNotFound):
+		return grpcutil.Error(codes.NotFound, grpcutil.ReasonMessageNotFound, "chat message not found")
+	case errors.Is(err, chat.ErrMessageDeleted):
+		return grpcutil.Error(codes.FailedPrecondition, grpcutil.ReasonMessageDeleted, "chat message deleted")
+	case errors.Is(err, chat.ErrNotChatMember):
+		return grpcutil.Error(codes.PermissionDenied, grpcutil.ReasonNotChatMember, "not a chat member")
+	case errors.Is(err, chat.ErrNotMessageAuthor):
+		return grpcutil.Error(codes.PermissionDenied, grpcutil.ReasonNotMessageAuthor, "not a message author")
+	default:
+		return grpcutil.ErrInternal
+	}
+}
+
+func reactionError(err error) error {
+	switch {
+	case errors.Is(err, validate.ErrEmoji):
+		return grpcutil.Error(codes.InvalidArgument, grpcutil.ReasonInvalidEmoji, "invalid emoji")
+	case errors.Is(err, chat.ErrReactionExists):
+		return grpcutil.Error(codes.AlreadyExists, grpcutil.ReasonReactionExists, "reaction already exists")
+	case errors.Is(err, chat.ErrReactionNotFound):
+		return grpcutil.Error(codes.NotFound, grpcutil.ReasonReactionNotFound, "reaction not found")
+	default:
+		return accessError(err)
+	}
+}
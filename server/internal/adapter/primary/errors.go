@@ -0,0 +1,38 @@
+package primary
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrThrottled          = errors.New("throttled")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+)
+
+// Error is a domain error returned by another service. Kind is one of the
+// errors above, Reason tells apart errors of the same kind. RetryAfter is
+// set when a throttled call tells how long to wait.
+type Error struct {
+	Kind       error
+	Reason     string
+	Desc       string
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Reason == "" {
+		return e.Desc
+	}
+
+	return e.Desc + " (" + e.Reason + ")"
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
@@ -0,0 +1,190 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/core/domain/converter"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/internal/pkg/proto"
+	"github.com/dvid-messanger/internal/pkg/topic"
+	"github.com/dvid-messanger/pkg/id"
+	"github.com/dvid-messanger/pkg/pubsub"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"log/slog"
+	"sync"
+)
+
+type Subscriber struct {
+	log      *slog.Logger
+	broker   pubsub.Broker
+	notifier primary.Notifier
+
+	chats    pubsub.Subscription
+	chatSubs map[[16]byte]pubsub.Subscription
+
+	mu *sync.Mutex
+}
+
+func New(log *slog.Logger, broker pubsub.Broker) *Subscriber {
+	return &Subscriber{
+		log:      log,
+		broker:   broker,
+		chatSubs: make(map[[16]byte]pubsub.Subscription),
+		mu:       &sync.Mutex{},
+	}
+}
+
+func (s *Subscriber) Start(notifier primary.Notifier) error {
+	const op = "broker.Start"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notifier = notifier
+
+	sub, err := s.broker.Subscribe(topic.Chats, s.handle)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.chats = sub
+
+	return nil
+}
+
+func (s *Subscriber) Stop() {
+	const op = "broker.Stop"
+	log := s.log.With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.chats != nil {
+		if err := s.chats.Unsubscribe(); err != nil {
+			log.Error("failed to unsubscribe from chats", logger.Err(err))
+		}
+		s.chats = nil
+	}
+	for cid, sub := range s.chatSubs {
+		if err := sub.Unsubscribe(); err != nil {
+			log.Error("failed to unsubscribe from chat "+id.String(cid[:]), logger.Err(err))
+		}
+	}
+	clear(s.chatSubs)
+}
+
+func (s *Subscriber) WatchChat(cid []byte) error {
+	const op = "broker.WatchChat"
+	log := s.log.With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.chatSubs[id.Id(cid)]; ok {
+		return nil
+	}
+
+	sub, err := s.broker.Subscribe(topic.Chat(cid), s.handle)
+	if err != nil {
+		log.Error("failed to subscribe to chat "+id.String(cid), logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.chatSubs[id.Id(cid)] = sub
+
+	log.Debug("subscribed to chat " + id.String(cid))
+	return nil
+}
+
+func (s *Subscriber) UnwatchChat(cid []byte) {
+	const op = "broker.UnwatchChat"
+	log := s.log.With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.chatSubs[id.Id(cid)]
+	if !ok {
+		return
+	}
+
+	delete(s.chatSubs, id.Id(cid))
+	if err := sub.Unsubscribe(); err != nil {
+		log.Error("failed to unsubscribe from chat "+id.String(cid), logger.Err(err))
+		return
+	}
+
+	log.Debug("unsubscribed from chat " + id.String(cid))
+}
+
+func (s *Subscriber) handle(payload []byte) {
+	const op = "broker.handle"
+	log := s.log.With(slog.String("op", op))
+
+	event := &frontendv1.ChatEvent{}
+	if err := proto.Unmarshal(payload, event); err != nil {
+		log.Error("failed to unmarshal event", logger.Err(err))
+		return
+	}
+
+	var err error
+	ctx := context.Background()
+	switch event.GetType() {
+	case frontendv1.ChatEventType_E_NEW_MESSAGE:
+		req := &frontendv1.NewMessageRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.NewMessage(ctx, converter.ChatMessageFromDTO(req.GetMessage()))
+		}
+	case frontendv1.ChatEventType_E_NEW_CHAT:
+		req := &frontendv1.NewChatRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.NewChat(ctx, converter.ChatFromDTO(req.GetChat()))
+		}
+	case frontendv1.ChatEventType_E_CHAT_UPDATED:
+		req := &frontendv1.ChatUpdatedRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.ChatUpdated(ctx, converter.ChatFromDTO(req.GetChat()))
+		}
+	case frontendv1.ChatEventType_E_MESSAGE_EDITED:
+		req := &frontendv1.MessageEditedRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.MessageEdited(ctx, converter.ChatMessageFromDTO(req.GetMessage()))
+		}
+	case frontendv1.ChatEventType_E_MESSAGE_DELETED:
+		req := &frontendv1.MessageDeletedRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.MessageDeleted(ctx, converter.ChatMessageFromDTO(req.GetMessage()))
+		}
+	case frontendv1.ChatEventType_E_MESSAGES_READ:
+		req := &frontendv1.MessagesReadRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.MessagesRead(ctx, converter.ReadReceiptFromDTO(req.GetReceipt()))
+		}
+	case frontendv1.ChatEventType_E_REACTION_UPDATED:
+		req := &frontendv1.ReactionUpdatedRequest{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.ReactionUpdated(ctx, converter.ReactionUpdateFromDTO(req.GetUpdate()))
+		}
+	case frontendv1.ChatEventType_E_TYPING:
+		req := &frontendv1.TypingEvent{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.Typing(ctx, req.GetCid(), req.GetUid())
+		}
+	case frontendv1.ChatEventType_E_PRESENCE:
+		req := &frontendv1.PresenceEvent{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.Presence(ctx, req.GetCid(), req.GetUid(), req.GetOnline())
+		}
+	case frontendv1.ChatEventType_E_PROFILE_UPDATED:
+		req := &frontendv1.ProfileUpdatedEvent{}
+		if err = proto.Unmarshal(event.GetPayload(), req); err == nil {
+			err = s.notifier.ProfileUpdated(ctx, req.GetCids(), converter.UserFromDTO(req.GetUser()))
+		}
+	default:
+		log.Warn("unknown event type " + event.GetType().String())
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to handle "+event.GetType().String(), logger.Err(err))
+	}
+}
@@ -0,0 +1,151 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	brokerfe "github.com/dvid-messanger/internal/adapter/secondary/broker/frontend"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/frontend"
+	"github.com/dvid-messanger/internal/pkg/proto"
+	"github.com/dvid-messanger/pkg/pubsub/inproc"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+var log = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+type testClient struct {
+	id   []byte
+	msgs []*frontendv1.Downstream
+	mu   sync.Mutex
+}
+
+func (c *testClient) GetId() []byte {
+	return c.id
+}
+
+func (c *testClient) Send(msg []byte) error {
+	downstream := &frontendv1.Downstream{}
+	if err := proto.Unmarshal(msg, downstream); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.msgs = append(c.msgs, downstream)
+
+	return nil
+}
+
+func (c *testClient) received() []frontendv1.DownstreamType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	types := make([]frontendv1.DownstreamType, 0, len(c.msgs))
+	for _, msg := range c.msgs {
+		types = append(types, msg.GetType())
+	}
+
+	return types
+}
+
+func newId() []byte {
+	uid := uuid.New()
+	return uid[:]
+}
+
+type instance struct {
+	registry   *frontend.ClientRegistry
+	subscriber *Subscriber
+}
+
+func newInstance(t *testing.T, broker *inproc.Broker) *instance {
+	subscriber := New(log, broker)
+	registry := frontend.NewClientRegistry(log, subscriber, nil)
+	require.NoError(t, subscriber.Start(frontend.NewNotifier(log, registry, registry)))
+
+	return &instance{registry: registry, subscriber: subscriber}
+}
+
+func (i *instance) connect(t *testing.T, cid []byte, uid []byte, chats ...model.Chat) *testClient {
+	client := &testClient{id: cid}
+	require.NoError(t, i.registry.Register(client))
+	require.NoError(t, i.registry.SetInfo(cid, &model.User{Id: uid}, chats))
+
+	return client
+}
+
+func TestSubscriber(t *testing.T) {
+	t.Parallel()
+
+	uid1, uid2, uid3 := newId(), newId(), newId()
+	chat := model.Chat{
+		Id:      newId(),
+		Type:    model.CTGroup,
+		Members: []model.ChatMember{{Uid: uid1}, {Uid: uid2}},
+	}
+
+	t.Run("NewMessageDeliveredAcrossInstances", func(t *testing.T) {
+		t.Parallel()
+
+		broker := inproc.New()
+		first, second, third := newInstance(t, broker), newInstance(t, broker), newInstance(t, broker)
+		client1 := first.connect(t, newId(), uid1, chat)
+		client2 := second.connect(t, newId(), uid2, chat)
+		client3 := third.connect(t, newId(), uid3)
+
+		publisher := brokerfe.New(log, broker)
+		require.NoError(t, publisher.NewMessage(
+			context.Background(),
+			&model.ChatMessage{Id: newId(), Cid: chat.Id, Uid: uid1, Text: "hello"},
+		))
+
+		assert.Equal(t, []frontendv1.DownstreamType{frontendv1.DownstreamType_D_NEW_MESSAGE}, client1.received())
+		assert.Equal(t, []frontendv1.DownstreamType{frontendv1.DownstreamType_D_NEW_MESSAGE}, client2.received())
+		assert.Empty(t, client3.received())
+		assert.Len(t, first.subscriber.chatSubs, 1)
+		assert.Empty(t, third.subscriber.chatSubs)
+	})
+	t.Run("ChatUpdatedSubscribesNewMemberInstance", func(t *testing.T) {
+		t.Parallel()
+
+		broker := inproc.New()
+		first, second := newInstance(t, broker), newInstance(t, broker)
+		client1 := first.connect(t, newId(), uid1, chat)
+		client3 := second.connect(t, newId(), uid3)
+
+		publisher := brokerfe.New(log, broker)
+		updated := chat
+		updated.Members = append([]model.ChatMember{{Uid: uid3}}, chat.Members...)
+		require.NoError(t, publisher.ChatUpdated(context.Background(), &updated))
+		require.NoError(t, publisher.NewMessage(
+			context.Background(),
+			&model.ChatMessage{Id: newId(), Cid: chat.Id, Uid: uid1, Text: "hello"},
+		))
+
+		expected := []frontendv1.DownstreamType{
+			frontendv1.DownstreamType_D_CHAT_UPDATED,
+			frontendv1.DownstreamType_D_NEW_MESSAGE,
+		}
+		assert.Equal(t, expected, client1.received())
+		assert.Equal(t, expected, client3.received())
+	})
+	t.Run("UnsubscribedWhenLastClientGone", func(t *testing.T) {
+		t.Parallel()
+
+		broker := inproc.New()
+		client1Id := newId()
+		first := newInstance(t, broker)
+		first.connect(t, client1Id, uid1, chat)
+		require.Len(t, first.subscriber.chatSubs, 1)
+
+		require.NoError(t, first.registry.Unregister(client1Id))
+
+		assert.Empty(t, first.subscriber.chatSubs)
+	})
+}
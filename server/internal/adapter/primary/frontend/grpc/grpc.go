@@ -4,10 +4,9 @@ import (
 	"context"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/core/domain/converter"
+	grpcutil "github.com/dvid-messanger/internal/pkg/grpc"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type serverApi struct {
@@ -26,7 +25,7 @@ func (s *serverApi) NewMessage(ctx context.Context, req *frontendv1.NewMessageRe
 
 	err := s.notifier.NewMessage(ctx, converter.ChatMessageFromDTO(req.GetMessage()))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 
 	return &frontendv1.NewMessageResponse{}, nil
@@ -39,12 +38,89 @@ func (s *serverApi) NewChat(ctx context.Context, req *frontendv1.NewChatRequest)
 
 	err := s.notifier.NewChat(ctx, converter.ChatFromDTO(req.GetChat()))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 
 	return &frontendv1.NewChatResponse{}, nil
 }
 
+func (s *serverApi) ChatUpdated(ctx context.Context, req *frontendv1.ChatUpdatedRequest) (*frontendv1.ChatUpdatedResponse, error) {
+	if err := validateChatUpdated(req); err != nil {
+		return nil, err
+	}
+
+	err := s.notifier.ChatUpdated(ctx, converter.ChatFromDTO(req.GetChat()))
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &frontendv1.ChatUpdatedResponse{}, nil
+}
+
+func (s *serverApi) MessageEdited(
+	ctx context.Context,
+	req *frontendv1.MessageEditedRequest,
+) (*frontendv1.MessageEditedResponse, error) {
+	if err := validateMessageEdited(req); err != nil {
+		return nil, err
+	}
+
+	err := s.notifier.MessageEdited(ctx, converter.ChatMessageFromDTO(req.GetMessage()))
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &frontendv1.MessageEditedResponse{}, nil
+}
+
+func (s *serverApi) MessageDeleted(
+	ctx context.Context,
+	req *frontendv1.MessageDeletedRequest,
+) (*frontendv1.MessageDeletedResponse, error) {
+	if err := validateMessageDeleted(req); err != nil {
+		return nil, err
+	}
+
+	err := s.notifier.MessageDeleted(ctx, converter.ChatMessageFromDTO(req.GetMessage()))
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &frontendv1.MessageDeletedResponse{}, nil
+}
+
+func (s *serverApi) MessagesRead(
+	ctx context.Context,
+	req *frontendv1.MessagesReadRequest,
+) (*frontendv1.MessagesReadResponse, error) {
+	if err := validateMessagesRead(req); err != nil {
+		return nil, err
+	}
+
+	err := s.notifier.MessagesRead(ctx, converter.ReadReceiptFromDTO(req.GetReceipt()))
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &frontendv1.MessagesReadResponse{}, nil
+}
+
+func (s *serverApi) ReactionUpdated(
+	ctx context.Context,
+	req *frontendv1.ReactionUpdatedRequest,
+) (*frontendv1.ReactionUpdatedResponse, error) {
+	if err := validateReactionUpdated(req); err != nil {
+		return nil, err
+	}
+
+	err := s.notifier.ReactionUpdated(ctx, converter.ReactionUpdateFromDTO(req.GetUpdate()))
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &frontendv1.ReactionUpdatedResponse{}, nil
+}
+
 func validateNewMessage(req *frontendv1.NewMessageRequest) error {
 	_ = req
 	return nil
@@ -54,3 +130,28 @@ func validateNewChat(req *frontendv1.NewChatRequest) error {
 	_ = req
 	return nil
 }
+
+func validateChatUpdated(req *frontendv1.ChatUpdatedRequest) error {
+	_ = req
+	return nil
+}
+
+func validateMessageEdited(req *frontendv1.MessageEditedRequest) error {
+	_ = req
+	return nil
+}
+
+func validateMessageDeleted(req *frontendv1.MessageDeletedRequest) error {
+	_ = req
+	return nil
+}
+
+func validateMessagesRead(req *frontendv1.MessagesReadRequest) error {
+	_ = req
+	return nil
+}
+
+func validateReactionUpdated(req *frontendv1.ReactionUpdatedRequest) error {
+	_ = req
+	return nil
+}
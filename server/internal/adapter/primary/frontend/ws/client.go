@@ -1,15 +1,73 @@
 package ws
 
 import (
+	"errors"
+	"fmt"
 	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/id"
 	"github.com/gorilla/websocket"
 	"log/slog"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+type SendPolicy string
+
+const (
+	SendPolicyDropOldest SendPolicy = "drop_oldest"
+	SendPolicyDropNewest SendPolicy = "drop_newest"
+	SendPolicyDisconnect SendPolicy = "disconnect"
+)
+
+var (
+	ErrClientClosed     = errors.New("client closed")
+	ErrSendBufferFull   = errors.New("send buffer full, message dropped")
+	ErrSlowConsumer     = errors.New("slow consumer disconnected")
+	ErrUnknownPolicy    = errors.New("unknown send policy")
+	slowConsumerMessage = websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "slow consumer")
+)
+
+func ParseSendPolicy(policy string) (SendPolicy, error) {
+	switch p := SendPolicy(policy); p {
+	case SendPolicyDropOldest, SendPolicyDropNewest, SendPolicyDisconnect:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownPolicy, policy)
+	}
+}
+
+type SendStats struct {
+	Sent          uint64
+	DroppedOldest uint64
+	DroppedNewest uint64
+	Disconnected  uint64
+}
+
+type sendCounters struct {
+	sent          atomic.Uint64
+	droppedOldest atomic.Uint64
+	droppedNewest atomic.Uint64
+	disconnected  atomic.Uint64
+}
+
+// Lost counts the messages which did not reach their clients.
+func (s SendStats) Lost() uint64 {
+	return s.DroppedOldest + s.DroppedNewest + s.Disconnected
+}
+
+func (sc *sendCounters) stats() SendStats {
+	return SendStats{
+		Sent:          sc.sent.Load(),
+		DroppedOldest: sc.droppedOldest.Load(),
+		DroppedNewest: sc.droppedNewest.Load(),
+		Disconnected:  sc.disconnected.Load(),
+	}
+}
+
 type Client struct {
 	id       []byte
 	log      *slog.Logger
@@ -18,18 +76,31 @@ type Client struct {
 	handler  MsgHandler
 	cfg      *ClientConfig
 	send     chan []byte
+
+	sendMu   sync.Mutex
+	closeMsg []byte
+	discard  bool
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
 type ClientConfig struct {
 	sendMsgBuff  int
+	sendPolicy   SendPolicy
+	counters     *sendCounters
+	handlers     *tracker
 	readMsgLimit int64
 	writeWait    time.Duration
 	pongWait     time.Duration
 	pingPeriod   time.Duration
 }
 
+// MsgHandler handles a client message, possibly asynchronously. done must be
+// called once the message is fully processed. Refuse answers a message
+// received while the server drains, without processing it.
 type MsgHandler interface {
-	Handle(c *Client, msg []byte)
+	Handle(c *Client, msg []byte, done func())
+	Refuse(c *Client, msg []byte)
 }
 
 func NewClient(
@@ -48,10 +119,13 @@ func NewClient(
 		conn:     conn,
 		cfg:      config,
 		send:     make(chan []byte, config.sendMsgBuff),
+		done:     make(chan struct{}),
 	}
 }
 
-func (c *Client) Serve() {
+// Serve registers the client and pumps its messages until it disconnects. A
+// handshake auth is set along with the info of its user.
+func (c *Client) Serve(auth string, user *model.User, chats []model.Chat) {
 	const op = "client.Serve"
 	log := c.log.With(slog.String("op", op))
 
@@ -59,6 +133,13 @@ func (c *Client) Serve() {
 		log.Error("failed to register client", logger.Err(err))
 		return
 	}
+	if auth != "" {
+		if err := c.registry.SetAuth(c.id, auth); err != nil {
+			log.Error("failed to set handshake auth", logger.Err(err))
+		} else if err = c.registry.SetInfo(c.id, user, chats); err != nil {
+			log.Error("failed to set handshake info", logger.Err(err))
+		}
+	}
 
 	wg := sync.WaitGroup{}
 	wg.Add(2)
@@ -83,9 +164,69 @@ func (c *Client) GetId() []byte {
 	return c.id
 }
 
+// Send queues msg without blocking. When the buffer is full the configured
+// policy decides whether a message is dropped or the client disconnected,
+// dropping the oldest message still queues msg and is no error.
 func (c *Client) Send(msg []byte) error {
-	c.send <- msg
-	return nil
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	select {
+	case <-c.done:
+		return ErrClientClosed
+	default:
+	}
+
+	select {
+	case c.send <- msg:
+		c.cfg.counters.sent.Add(1)
+		return nil
+	default:
+	}
+
+	switch c.cfg.sendPolicy {
+	case SendPolicyDropNewest:
+		c.cfg.counters.droppedNewest.Add(1)
+		return ErrSendBufferFull
+	case SendPolicyDropOldest:
+		// sendMu keeps other senders out, so the freed slot is ours
+		select {
+		case <-c.send:
+		default:
+		}
+		c.send <- msg
+		c.cfg.counters.droppedOldest.Add(1)
+		c.cfg.counters.sent.Add(1)
+		return nil
+	default:
+		c.cfg.counters.disconnected.Add(1)
+		c.log.Warn("disconnecting slow consumer", slog.String("c", id.String(c.id)))
+		c.closeMsg = slowConsumerMessage
+		c.discard = true
+		c.stop()
+		return ErrSlowConsumer
+	}
+}
+
+// Close flushes queued messages and closes the connection with msg as the
+// close frame.
+func (c *Client) Close(msg []byte) {
+	c.sendMu.Lock()
+	c.closeMsg = msg
+	c.sendMu.Unlock()
+
+	c.stop()
+}
+
+// Kick flushes queued messages and closes the connection as a policy violation.
+func (c *Client) Kick(reason string) {
+	c.Close(websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason))
+}
+
+func (c *Client) stop() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
 }
 
 func (c *Client) readPump() {
@@ -93,6 +234,7 @@ func (c *Client) readPump() {
 	log := c.log.With(slog.String("op", op))
 
 	defer func() {
+		c.stop()
 		if err := c.registry.Unregister(c.id); err != nil {
 			log.Error("failed to unregister client", logger.Err(err))
 		}
@@ -113,7 +255,40 @@ func (c *Client) readPump() {
 			break
 		}
 
-		c.handler.Handle(c, message)
+		if !c.cfg.handlers.begin() {
+			log.Debug("draining, message refused")
+			c.handler.Refuse(c, message)
+			continue
+		}
+		c.handler.Handle(c, message, c.cfg.handlers.end)
+	}
+}
+
+func (c *Client) flush() {
+	const op = "frontend.flush"
+	log := c.log.With(slog.String("op", op))
+
+	c.sendMu.Lock()
+	closeMsg, discard := c.closeMsg, c.discard
+	c.sendMu.Unlock()
+
+queued:
+	for !discard {
+		select {
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.cfg.writeWait))
+			if err := c.conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
+				log.Error("failed to write message", logger.Err(err))
+				return
+			}
+		default:
+			break queued
+		}
+	}
+
+	if closeMsg != nil {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(c.cfg.writeWait))
+		_ = c.conn.WriteMessage(websocket.CloseMessage, closeMsg)
 	}
 }
 
@@ -124,10 +299,22 @@ func (c *Client) writePump() {
 	ticker := time.NewTicker(c.cfg.pingPeriod)
 	defer func() {
 		ticker.Stop()
+		c.stop()
 		_ = c.conn.Close()
 	}()
 	for {
+		// done takes priority, so a discarding close skips queued messages
+		select {
+		case <-c.done:
+			c.flush()
+			return
+		default:
+		}
+
 		select {
+		case <-c.done:
+			c.flush()
+			return
 		case message, ok := <-c.send:
 			_ = c.conn.SetWriteDeadline(time.Now().Add(c.cfg.writeWait))
 			if !ok {
@@ -0,0 +1,100 @@
+package ws
+
+import (
+	"bytes"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var log = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+func newTestClient(policy SendPolicy, conn *websocket.Conn) *Client {
+	cfg := &ClientConfig{
+		sendMsgBuff: 2,
+		sendPolicy:  policy,
+		counters:    &sendCounters{},
+		writeWait:   time.Second,
+		pingPeriod:  time.Minute,
+	}
+
+	return NewClient([]byte("0123456789abcdef"), log, nil, nil, conn, cfg)
+}
+
+func TestClient_SendDropNewest(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(SendPolicyDropNewest, nil)
+
+	require.NoError(t, c.Send([]byte("1")))
+	require.NoError(t, c.Send([]byte("2")))
+	assert.ErrorIs(t, c.Send([]byte("3")), ErrSendBufferFull)
+
+	assert.Equal(t, []byte("1"), <-c.send)
+	assert.Equal(t, []byte("2"), <-c.send)
+	assert.Equal(t, SendStats{Sent: 2, DroppedNewest: 1}, c.cfg.counters.stats())
+}
+
+func TestClient_SendDropOldest(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(SendPolicyDropOldest, nil)
+
+	require.NoError(t, c.Send([]byte("1")))
+	require.NoError(t, c.Send([]byte("2")))
+	assert.NoError(t, c.Send([]byte("3")), "newest message is queued")
+
+	assert.Equal(t, []byte("2"), <-c.send)
+	assert.Equal(t, []byte("3"), <-c.send)
+	assert.Equal(t, SendStats{Sent: 3, DroppedOldest: 1}, c.cfg.counters.stats())
+}
+
+func TestClient_SendDisconnect(t *testing.T) {
+	t.Parallel()
+
+	closeCode := make(chan int, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		c := newTestClient(SendPolicyDisconnect, conn)
+		require.NoError(t, c.Send([]byte("1")))
+		require.NoError(t, c.Send([]byte("2")))
+		assert.ErrorIs(t, c.Send([]byte("3")), ErrSlowConsumer)
+		assert.ErrorIs(t, c.Send([]byte("4")), ErrClientClosed)
+		assert.Equal(t, SendStats{Sent: 2, Disconnected: 1}, c.cfg.counters.stats())
+
+		c.writePump()
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	conn.SetCloseHandler(func(code int, _ string) error {
+		closeCode <- code
+		return nil
+	})
+	_, _, err = conn.ReadMessage()
+	require.Error(t, err, "queued messages are discarded")
+
+	assert.Equal(t, websocket.CloseTryAgainLater, <-closeCode)
+}
+
+func TestParseSendPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := ParseSendPolicy("drop_oldest")
+	require.NoError(t, err)
+	assert.Equal(t, SendPolicyDropOldest, policy)
+
+	_, err = ParseSendPolicy("block")
+	assert.ErrorIs(t, err, ErrUnknownPolicy)
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
+	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/pkg/id"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
@@ -15,6 +18,7 @@ type AuthHandler struct {
 	log      *slog.Logger
 	registry primary.ClientRegistry
 	auth     primary.Auth
+	tv       primary.TokenVerifier
 }
 
 func RegisterAuthHandler(
@@ -22,26 +26,46 @@ func RegisterAuthHandler(
 	r *route.Router,
 	registry primary.ClientRegistry,
 	auth primary.Auth,
+	tv primary.TokenVerifier,
 	authMiddleware *middleware.AuthMiddleware,
+	rateLimit *middleware.RateLimitMiddleware,
 ) {
 	handler := AuthHandler{
 		log:      log,
 		registry: registry,
 		auth:     auth,
+		tv:       tv,
 	}
 
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_LOGIN,
 		frontendv1.DownstreamType_D_LOGIN,
 		&frontendv1.UpstreamLogin{},
-		handler.Login,
+		rateLimit.WithRateLimit(handler.Login),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_LOGOUT,
 		frontendv1.DownstreamType_D_LOGOUT,
 		&frontendv1.UpstreamLogout{},
-		authMiddleware.WithAuth(handler.Logout),
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.Logout)),
 	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_REFRESH,
+		frontendv1.DownstreamType_D_REFRESH,
+		&frontendv1.UpstreamRefresh{},
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.Refresh)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_AUTH_TOKEN,
+		frontendv1.DownstreamType_D_AUTH_TOKEN,
+		&frontendv1.UpstreamAuthToken{},
+		rateLimit.WithRateLimit(handler.AuthToken),
+	)
+
+	r.Exclusive(frontendv1.UpstreamType_U_LOGIN)
+	r.Exclusive(frontendv1.UpstreamType_U_LOGOUT)
+	r.Exclusive(frontendv1.UpstreamType_U_REFRESH)
+	r.Exclusive(frontendv1.UpstreamType_U_AUTH_TOKEN)
 
 	handler.log.Debug("auth handler registered")
 }
@@ -51,27 +75,100 @@ func (a *AuthHandler) Login(ctx context.Context, request *route.UpstreamRequest)
 	log := a.log.With(slog.String("op", op), slog.String("c", id.String(request.ClientId)))
 
 	upstream := request.Payload.(*frontendv1.UpstreamLogin)
-	token, err := a.auth.Login(ctx, upstream.Email, upstream.Password)
+	pair, err := a.auth.Login(ctx, upstream.Email, upstream.Password)
 	if err != nil {
+		if errors.Is(err, primary.ErrUnauthenticated) {
+			return &route.UpstreamResponse{ErrCode: frontendv1.ErrorCode_BAD_LOGIN, ErrDesc: "failed to login"}
+		}
+
 		log.Error("failed to login", logger.Err(err))
-		return &route.UpstreamResponse{ErrCode: frontendv1.ErrorCode_BAD_LOGIN, ErrDesc: "failed to login"}
+		return route.ErrResponse(err)
 	}
 
-	if err = a.registry.SetAuth(request.ClientId, token); err != nil {
+	if err = a.registry.SetAuth(request.ClientId, pair.Access); err != nil {
 		log.Error("failed to set auth", logger.Err(err))
 		return route.ErrResponseInternal
 	}
 
 	return &route.UpstreamResponse{
-		Payload: &frontendv1.DownstreamLogin{Token: token},
+		Payload: &frontendv1.DownstreamLogin{Token: pair.Access, RefreshToken: pair.Refresh},
+	}
+}
+
+func (a *AuthHandler) AuthToken(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.AuthToken"
+	log := a.log.With(slog.String("op", op), slog.String("c", id.String(request.ClientId)))
+
+	upstream := request.Payload.(*frontendv1.UpstreamAuthToken)
+	claims, err := a.tv.Verify(ctx, upstream.Token)
+	if err != nil {
+		log.Error("failed to verify token", logger.Err(err))
+		return route.ErrResponseUnauthorized
+	}
+	if claims.Type != model.TokenTypeAccess {
+		log.Error("not an access token", slog.String("typ", claims.Type))
+		return route.ErrResponseUnauthorized
+	}
+
+	if err = a.registry.SetAuth(request.ClientId, upstream.Token); err != nil {
+		log.Error("failed to set auth", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{
+		Payload: &frontendv1.DownstreamAuthToken{Uid: claims.Uid},
 	}
 }
 
-func (a *AuthHandler) Logout(_ context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+func (a *AuthHandler) Refresh(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.Refresh"
+	log := a.log.With(slog.String("op", op), slog.String("c", id.String(request.ClientId)))
+
+	upstream := request.Payload.(*frontendv1.UpstreamRefresh)
+	pair, err := a.auth.Refresh(ctx, upstream.RefreshToken)
+	if err != nil {
+		log.Error("failed to refresh", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	// the refresh token may belong to another user, the connection stays theirs
+	claims, err := a.tv.Verify(ctx, pair.Access)
+	if err != nil {
+		log.Error("failed to verify refreshed token", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+	if !bytes.Equal(claims.Uid, request.AuthUid) {
+		log.Error("refresh token of uid " + id.String(claims.Uid) + " used by uid " + id.String(request.AuthUid))
+		return route.ErrResponseUnauthorized
+	}
+
+	if err = a.registry.ReplaceAuth(request.ClientId, claims.Uid, pair.Access); err != nil {
+		log.Error("failed to replace auth", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+
+	return &route.UpstreamResponse{
+		Payload: &frontendv1.DownstreamRefresh{Token: pair.Access, RefreshToken: pair.Refresh},
+	}
+}
+
+func (a *AuthHandler) Logout(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
 	const op = "handler.Logout"
 	log := a.log.With(slog.String("op", op), slog.String("c", id.String(request.ClientId)))
 
-	if err := a.registry.UnsetAuth(request.ClientId); err != nil {
+	upstream := request.Payload.(*frontendv1.UpstreamLogout)
+	token, err := a.registry.Auth(request.ClientId)
+	if err != nil {
+		log.Error("failed to get auth", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+
+	if err = a.auth.Logout(ctx, token, upstream.RefreshToken); err != nil {
+		log.Error("failed to revoke tokens", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+
+	if err = a.registry.UnsetAuth(request.ClientId); err != nil {
 		log.Error("failed to logout", logger.Err(err))
 		return route.ErrResponseInternal
 	}
@@ -6,8 +6,10 @@ import (
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
 	"github.com/dvid-messanger/internal/core/domain/converter"
+	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/golang/protobuf/proto"
 	"log/slog"
 )
 
@@ -16,43 +18,129 @@ type ChatHandler struct {
 	chat primary.Chat
 }
 
-func RegisterChatHandler(log *slog.Logger, r *route.Router, chat primary.Chat, auth *middleware.AuthMiddleware) {
+func RegisterChatHandler(
+	log *slog.Logger,
+	r *route.Router,
+	chat primary.Chat,
+	auth *middleware.AuthMiddleware,
+	rateLimit *middleware.RateLimitMiddleware,
+) {
 	handler := ChatHandler{log: log, chat: chat}
 
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_GET_USER_CHATS,
 		frontendv1.DownstreamType_D_GET_USER_CHATS,
 		&frontendv1.UpstreamGetUserChats{},
-		auth.WithAuth(handler.GetUserChats),
+		auth.WithAuth(rateLimit.WithRateLimit(handler.GetUserChats)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_GET_CHAT,
 		frontendv1.DownstreamType_D_GET_CHAT,
 		&frontendv1.UpstreamGetChat{},
-		auth.WithAuth(handler.GetChat),
+		auth.WithAuth(rateLimit.WithRateLimit(handler.GetChat)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_CREATE_CHAT,
 		frontendv1.DownstreamType_D_CREATE_CHAT,
 		&frontendv1.UpstreamCreateChat{},
-		auth.WithAuth(handler.CreateChat),
+		auth.WithAuth(rateLimit.WithRateLimit(handler.CreateChat)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_CREATE_GROUP_CHAT,
+		frontendv1.DownstreamType_D_CREATE_GROUP_CHAT,
+		&frontendv1.UpstreamCreateGroupChat{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.CreateGroupChat)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_ADD_CHAT_MEMBER,
+		frontendv1.DownstreamType_D_ADD_CHAT_MEMBER,
+		&frontendv1.UpstreamAddChatMember{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.AddChatMember)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_REMOVE_CHAT_MEMBER,
+		frontendv1.DownstreamType_D_REMOVE_CHAT_MEMBER,
+		&frontendv1.UpstreamRemoveChatMember{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.RemoveChatMember)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_LEAVE_CHAT,
+		frontendv1.DownstreamType_D_LEAVE_CHAT,
+		&frontendv1.UpstreamLeaveChat{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.LeaveChat)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_SEND_MESSAGE,
 		frontendv1.DownstreamType_D_SEND_MESSAGE,
 		&frontendv1.UpstreamSendMessage{},
-		auth.WithAuth(handler.SendMessage),
+		auth.WithAuth(rateLimit.WithRateLimit(handler.SendMessage)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_EDIT_MESSAGE,
+		frontendv1.DownstreamType_D_EDIT_MESSAGE,
+		&frontendv1.UpstreamEditMessage{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.EditMessage)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_DELETE_MESSAGE,
+		frontendv1.DownstreamType_D_DELETE_MESSAGE,
+		&frontendv1.UpstreamDeleteMessage{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.DeleteMessage)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_CHAT_MESSAGES,
 		frontendv1.DownstreamType_D_CHAT_MESSAGES,
 		&frontendv1.UpstreamChatMessages{},
-		auth.WithAuth(handler.ChatMessages),
+		auth.WithAuth(rateLimit.WithRateLimit(handler.ChatMessages)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_SEARCH_MESSAGES,
+		frontendv1.DownstreamType_D_SEARCH_MESSAGES,
+		&frontendv1.UpstreamSearchMessages{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.SearchMessages)),
 	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_MARK_READ,
+		frontendv1.DownstreamType_D_MARK_READ,
+		&frontendv1.UpstreamMarkRead{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.MarkRead)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_ADD_REACTION,
+		frontendv1.DownstreamType_D_ADD_REACTION,
+		&frontendv1.UpstreamAddReaction{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.AddReaction)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_REMOVE_REACTION,
+		frontendv1.DownstreamType_D_REMOVE_REACTION,
+		&frontendv1.UpstreamRemoveReaction{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.RemoveReaction)),
+	)
+
+	r.Ordered(frontendv1.UpstreamType_U_SEND_MESSAGE, func(payload proto.Message) string {
+		return chatOrderKey(payload.(*frontendv1.UpstreamSendMessage).GetCid())
+	})
+	r.Ordered(frontendv1.UpstreamType_U_EDIT_MESSAGE, func(payload proto.Message) string {
+		return chatOrderKey(payload.(*frontendv1.UpstreamEditMessage).GetCid())
+	})
+	r.Ordered(frontendv1.UpstreamType_U_DELETE_MESSAGE, func(payload proto.Message) string {
+		return chatOrderKey(payload.(*frontendv1.UpstreamDeleteMessage).GetCid())
+	})
+	r.Ordered(frontendv1.UpstreamType_U_ADD_REACTION, func(payload proto.Message) string {
+		return chatOrderKey(payload.(*frontendv1.UpstreamAddReaction).GetCid())
+	})
+	r.Ordered(frontendv1.UpstreamType_U_REMOVE_REACTION, func(payload proto.Message) string {
+		return chatOrderKey(payload.(*frontendv1.UpstreamRemoveReaction).GetCid())
+	})
 
 	handler.log.Debug("chat handler registered")
 }
 
+func chatOrderKey(cid []byte) string {
+	return "chat:" + string(cid)
+}
+
 func (r *ChatHandler) GetUserChats(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
 	const op = "handler.GetUserChats"
 	log := r.log.With(slog.String("op", op))
@@ -60,10 +148,18 @@ func (r *ChatHandler) GetUserChats(ctx context.Context, request *route.UpstreamR
 	chats, err := r.chat.UserChats(ctx, request.AuthUid)
 	if err != nil {
 		log.Error("failed to get user chats", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
+	}
+	states, err := r.chat.ChatStates(ctx, request.AuthUid)
+	if err != nil {
+		log.Error("failed to get chat states", logger.Err(err))
+		return route.ErrResponse(err)
 	}
 
-	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamGetUserChats{Chats: converter.ChatsToDTO(chats)}}
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamGetUserChats{
+		Chats:  converter.ChatsToDTO(chats),
+		States: converter.ChatStatesToDTO(states),
+	}}
 }
 
 func (r *ChatHandler) GetChat(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
@@ -72,10 +168,10 @@ func (r *ChatHandler) GetChat(ctx context.Context, request *route.UpstreamReques
 
 	upstream := request.Payload.(*frontendv1.UpstreamGetChat)
 
-	chat, err := r.chat.Chat(ctx, upstream.GetCid())
+	chat, err := r.chat.Chat(ctx, upstream.GetCid(), request.AuthUid)
 	if err != nil {
 		log.Error("failed to get chat", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
 	}
 
 	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamGetChat{Chat: converter.ChatToDTO(chat)}}
@@ -90,38 +186,218 @@ func (r *ChatHandler) CreateChat(ctx context.Context, request *route.UpstreamReq
 	chat, err := r.chat.Create(ctx, request.AuthUid, upstream.GetUid())
 	if err != nil {
 		log.Error("failed to create chat", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
 	}
 
 	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamCreateChat{Chat: converter.ChatToDTO(chat)}}
 }
 
+func (r *ChatHandler) CreateGroupChat(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.CreateGroupChat"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamCreateGroupChat)
+
+	chat, err := r.chat.CreateGroup(ctx, request.AuthUid, upstream.GetTitle(), upstream.GetUids())
+	if err != nil {
+		log.Error("failed to create group chat", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamCreateGroupChat{Chat: converter.ChatToDTO(chat)}}
+}
+
+func (r *ChatHandler) AddChatMember(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.AddChatMember"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamAddChatMember)
+
+	chat, err := r.chat.AddMember(ctx, upstream.GetCid(), request.AuthUid, upstream.GetUid())
+	if err != nil {
+		log.Error("failed to add chat member", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamAddChatMember{Chat: converter.ChatToDTO(chat)}}
+}
+
+func (r *ChatHandler) RemoveChatMember(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.RemoveChatMember"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamRemoveChatMember)
+
+	chat, err := r.chat.RemoveMember(ctx, upstream.GetCid(), request.AuthUid, upstream.GetUid())
+	if err != nil {
+		log.Error("failed to remove chat member", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamRemoveChatMember{Chat: converter.ChatToDTO(chat)}}
+}
+
+func (r *ChatHandler) LeaveChat(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.LeaveChat"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamLeaveChat)
+
+	chat, err := r.chat.Leave(ctx, upstream.GetCid(), request.AuthUid)
+	if err != nil {
+		log.Error("failed to leave chat", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamLeaveChat{Chat: converter.ChatToDTO(chat)}}
+}
+
 func (r *ChatHandler) SendMessage(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
 	const op = "handler.SendMessage"
 	log := r.log.With(slog.String("op", op))
 
 	upstream := request.Payload.(*frontendv1.UpstreamSendMessage)
 
-	msg, err := r.chat.SendMessage(ctx, upstream.GetCid(), request.AuthUid, upstream.GetText())
+	refs := model.MessageRefs{ReplyTo: upstream.GetReplyToMid()}
+	if len(upstream.GetForwardMid()) != 0 {
+		refs.Forward = &model.ForwardedFrom{Cid: upstream.GetForwardCid(), Mid: upstream.GetForwardMid()}
+	}
+
+	msg, err := r.chat.SendMessage(ctx, upstream.GetCid(), request.AuthUid, upstream.GetText(), refs)
 	if err != nil {
 		log.Error("failed to send message", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
 	}
 
 	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamNewMessage{Message: converter.ChatMessageToDTO(msg)}}
 }
 
+func (r *ChatHandler) EditMessage(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.EditMessage"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamEditMessage)
+
+	msg, err := r.chat.EditMessage(ctx, upstream.GetCid(), upstream.GetMid(), request.AuthUid, upstream.GetText())
+	if err != nil {
+		log.Error("failed to edit message", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamEditMessage{Message: converter.ChatMessageToDTO(msg)}}
+}
+
+func (r *ChatHandler) DeleteMessage(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.DeleteMessage"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamDeleteMessage)
+
+	msg, err := r.chat.DeleteMessage(ctx, upstream.GetCid(), upstream.GetMid(), request.AuthUid)
+	if err != nil {
+		log.Error("failed to delete message", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamDeleteMessage{Message: converter.ChatMessageToDTO(msg)}}
+}
+
 func (r *ChatHandler) ChatMessages(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
 	const op = "handler.ChatMessages"
 	log := r.log.With(slog.String("op", op))
 
 	upstream := request.Payload.(*frontendv1.UpstreamChatMessages)
 
-	msgs, err := r.chat.Messages(ctx, upstream.GetCid())
+	page, err := r.chat.Messages(
+		ctx,
+		upstream.GetCid(),
+		request.AuthUid,
+		upstream.GetBefore(),
+		upstream.GetAfter(),
+		int(upstream.GetLimit()),
+	)
 	if err != nil {
 		log.Error("failed to get chat messages", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamChatMessages{
+		Messages:  converter.ChatMessagesToDTO(page.Messages),
+		Next:      page.Next,
+		Reactions: converter.MessageReactionsToDTO(page.Reactions),
+	}}
+}
+
+func (r *ChatHandler) SearchMessages(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.SearchMessages"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamSearchMessages)
+
+	page, err := r.chat.SearchMessages(
+		ctx,
+		request.AuthUid,
+		upstream.GetCid(),
+		upstream.GetQuery(),
+		upstream.GetAfter(),
+		int(upstream.GetLimit()),
+	)
+	if err != nil {
+		log.Error("failed to search messages", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamSearchMessages{
+		Hits: converter.MessageHitsToDTO(page.Hits),
+		Next: page.Next,
+	}}
+}
+
+func (r *ChatHandler) MarkRead(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.MarkRead"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamMarkRead)
+
+	receipt, err := r.chat.MarkRead(ctx, upstream.GetCid(), upstream.GetMid(), request.AuthUid)
+	if err != nil {
+		log.Error("failed to mark read", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamMarkRead{Receipt: converter.ReadReceiptToDTO(receipt)}}
+}
+
+func (r *ChatHandler) AddReaction(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.AddReaction"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamAddReaction)
+
+	update, err := r.chat.AddReaction(ctx, upstream.GetCid(), upstream.GetMid(), request.AuthUid, upstream.GetEmoji())
+	if err != nil {
+		log.Error("failed to add reaction", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamAddReaction{
+		Update: converter.ReactionUpdateToDTO(update),
+	}}
+}
+
+func (r *ChatHandler) RemoveReaction(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.RemoveReaction"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamRemoveReaction)
+
+	update, err := r.chat.RemoveReaction(ctx, upstream.GetCid(), upstream.GetMid(), request.AuthUid, upstream.GetEmoji())
+	if err != nil {
+		log.Error("failed to remove reaction", logger.Err(err))
+		return route.ErrResponse(err)
 	}
 
-	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamChatMessages{Messages: converter.ChatMessagesToDTO(msgs)}}
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamRemoveReaction{
+		Update: converter.ReactionUpdateToDTO(update),
+	}}
 }
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"log/slog"
+)
+
+type EphemeralHandler struct {
+	log       *slog.Logger
+	ephemeral primary.Ephemeral
+}
+
+func RegisterEphemeralHandler(
+	log *slog.Logger,
+	r *route.Router,
+	ephemeral primary.Ephemeral,
+	auth *middleware.AuthMiddleware,
+	rateLimit *middleware.RateLimitMiddleware,
+) {
+	handler := EphemeralHandler{log: log, ephemeral: ephemeral}
+
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_TYPING,
+		frontendv1.DownstreamType_D_TYPING,
+		&frontendv1.UpstreamTyping{},
+		auth.WithAuth(rateLimit.WithRateLimit(handler.Typing)),
+	)
+
+	handler.log.Debug("ephemeral handler registered")
+}
+
+func (r *EphemeralHandler) Typing(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.Typing"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamTyping)
+
+	err := r.ephemeral.Typing(ctx, request.ClientId, request.AuthUid, upstream.GetCid())
+	if err != nil {
+		if errors.Is(err, primary.ErrPermissionDenied) {
+			return route.ErrResponseForbidden
+		}
+		if !errors.Is(err, primary.ErrThrottled) {
+			log.Error("failed to send typing", logger.Err(err))
+		}
+	}
+
+	return &route.UpstreamResponse{}
+}
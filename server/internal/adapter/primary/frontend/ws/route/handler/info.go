@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
@@ -14,6 +16,8 @@ import (
 	"log/slog"
 )
 
+const syncConcurrency = 8
+
 type InfoHandler struct {
 	log      *slog.Logger
 	registry primary.ClientRegistry
@@ -28,6 +32,7 @@ func RegisterInfoHandler(
 	chat primary.Chat,
 	user primary.User,
 	authMiddleware *middleware.AuthMiddleware,
+	rateLimit *middleware.RateLimitMiddleware,
 ) {
 	handler := InfoHandler{
 		log:      log,
@@ -40,7 +45,13 @@ func RegisterInfoHandler(
 		frontendv1.UpstreamType_U_INFO_INIT,
 		frontendv1.DownstreamType_D_INFO_INIT,
 		&frontendv1.UpstreamInfoInit{},
-		authMiddleware.WithAuth(handler.InitInfo),
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.InitInfo)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_SYNC,
+		frontendv1.DownstreamType_D_SYNC,
+		&frontendv1.UpstreamSync{},
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.Sync)),
 	)
 
 	handler.log.Debug("info handler registered")
@@ -52,6 +63,7 @@ func (a *InfoHandler) InitInfo(ctx context.Context, request *route.UpstreamReque
 
 	var user *model.User
 	var chats []model.Chat
+	var states []model.ChatState
 
 	eg, gCtx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
@@ -72,6 +84,15 @@ func (a *InfoHandler) InitInfo(ctx context.Context, request *route.UpstreamReque
 		chats = uChats
 		return nil
 	})
+	eg.Go(func() error {
+		cStates, err := a.chat.ChatStates(gCtx, request.AuthUid)
+		if err != nil {
+			return err
+		}
+
+		states = cStates
+		return nil
+	})
 
 	if err := eg.Wait(); err != nil {
 		log.Error("failed to get info", logger.Err(err))
@@ -82,7 +103,110 @@ func (a *InfoHandler) InitInfo(ctx context.Context, request *route.UpstreamReque
 		return route.ErrResponseInternal
 	}
 
+	members, err := a.user.UsersByIds(ctx, memberUids(chats, request.AuthUid))
+	if err != nil {
+		log.Error("failed to get chat members", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+
 	return &route.UpstreamResponse{
-		Payload: &frontendv1.DownstreamInfoInit{User: converter.UserToDTO(user), Chats: converter.ChatsToDTO(chats)},
+		Payload: &frontendv1.DownstreamInfoInit{
+			User:    converter.UserToDTO(user),
+			Chats:   converter.ChatsToDTO(chats),
+			States:  converter.ChatStatesToDTO(states),
+			Members: converter.UsersToDTO(members),
+		},
+	}
+}
+
+// memberUids returns the distinct members of chats other than uid.
+func memberUids(chats []model.Chat, uid []byte) [][]byte {
+	seen := map[string]struct{}{string(uid): {}}
+	uids := make([][]byte, 0)
+	for _, chat := range chats {
+		for _, member := range chat.Members {
+			if _, ok := seen[string(member.Uid)]; ok {
+				continue
+			}
+			seen[string(member.Uid)] = struct{}{}
+			uids = append(uids, member.Uid)
+		}
 	}
+
+	return uids
+}
+
+// Sync returns messages the client missed in each of the user's chats. Chats
+// with a cursor get messages after it, unknown chats get the latest page.
+func (a *InfoHandler) Sync(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.Sync"
+	log := a.log.With(slog.String("op", op), slog.String("c", id.String(request.ClientId)))
+
+	upstream := request.Payload.(*frontendv1.UpstreamSync)
+
+	cursors := make(map[[16]byte][]byte, len(upstream.GetCursors()))
+	for _, cursor := range upstream.GetCursors() {
+		if len(cursor.GetCid()) != 16 {
+			continue
+		}
+		cursors[id.Id(cursor.GetCid())] = cursor.GetMid()
+	}
+
+	last, err := a.chat.LastMessages(ctx, request.AuthUid)
+	if err != nil {
+		log.Error("failed to get last messages", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+
+	chats := make([]*frontendv1.SyncChat, len(last))
+
+	eg, gCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(syncConcurrency)
+	for i, lastMessage := range last {
+		cid := lastMessage.Cid
+		cursor, ok := cursors[id.Id(cid)]
+		if ok && bytes.Equal(cursor, lastMessage.Id) {
+			continue
+		}
+
+		eg.Go(func() error {
+			page, err := a.chat.Messages(gCtx, cid, request.AuthUid, nil, cursor, int(upstream.GetLimit()))
+			if err != nil {
+				if errors.Is(err, primary.ErrPermissionDenied) || errors.Is(err, primary.ErrNotFound) {
+					return nil
+				}
+				return err
+			}
+			if len(page.Messages) == 0 {
+				return nil
+			}
+
+			chat := &frontendv1.SyncChat{
+				Cid:      cid,
+				Messages: converter.ChatMessagesToDTO(page.Messages),
+			}
+			if len(cursor) != 0 {
+				chat.After = page.Next
+			} else {
+				chat.Before = page.Next
+			}
+			chats[i] = chat
+
+			return nil
+		})
+	}
+
+	if err = eg.Wait(); err != nil {
+		log.Error("failed to get missed messages", logger.Err(err))
+		return route.ErrResponseInternal
+	}
+
+	downstream := &frontendv1.DownstreamSync{Chats: make([]*frontendv1.SyncChat, 0, len(chats))}
+	for _, chat := range chats {
+		if chat != nil {
+			downstream.Chats = append(downstream.Chats, chat)
+		}
+	}
+
+	return &route.UpstreamResponse{Payload: downstream}
 }
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/dvid-messanger/test/mocks/mock_primary"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"testing"
+)
+
+var log = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+func newId() []byte {
+	uid := uuid.New()
+	return uid[:]
+}
+
+func syncRequest(uid []byte, cursors ...*frontendv1.SyncCursor) *route.UpstreamRequest {
+	return &route.UpstreamRequest{
+		ClientId: newId(),
+		AuthUid:  uid,
+		Payload:  &frontendv1.UpstreamSync{Cursors: cursors, Limit: 10},
+	}
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	uid := newId()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		upToDate := model.ChatMessage{Id: newId(), Cid: newId()}
+		behind := model.ChatMessage{Id: newId(), Cid: newId()}
+		unknown := model.ChatMessage{Id: newId(), Cid: newId()}
+		left := model.ChatMessage{Id: newId(), Cid: newId()}
+		cursor := newId()
+
+		chat := mock_primary.NewMockChat(t)
+		chat.EXPECT().LastMessages(mock.Anything, uid).
+			Return([]model.ChatMessage{upToDate, behind, unknown, left}, nil)
+		chat.EXPECT().Messages(mock.Anything, behind.Cid, uid, []byte(nil), cursor, 10).
+			Return(&model.MessagesPage{Messages: []model.ChatMessage{behind}, Next: behind.Id}, nil)
+		chat.EXPECT().Messages(mock.Anything, unknown.Cid, uid, []byte(nil), []byte(nil), 10).
+			Return(&model.MessagesPage{Messages: []model.ChatMessage{unknown}, Next: unknown.Id}, nil)
+		chat.EXPECT().Messages(mock.Anything, left.Cid, uid, []byte(nil), []byte(nil), 10).
+			Return(nil, primary.ErrPermissionDenied)
+
+		handler := &InfoHandler{log: log, chat: chat}
+		resp := handler.Sync(context.Background(), syncRequest(
+			uid,
+			&frontendv1.SyncCursor{Cid: upToDate.Cid, Mid: upToDate.Id},
+			&frontendv1.SyncCursor{Cid: behind.Cid, Mid: cursor},
+		))
+		require.Zero(t, resp.ErrCode)
+
+		chats := resp.Payload.(*frontendv1.DownstreamSync).GetChats()
+		require.Len(t, chats, 2, "up to date and inaccessible chats are skipped")
+		assert.Equal(t, behind.Cid, chats[0].GetCid())
+		assert.Equal(t, behind.Id, chats[0].GetAfter(), "chat with cursor is paged forward")
+		assert.Equal(t, unknown.Cid, chats[1].GetCid())
+		assert.Equal(t, unknown.Id, chats[1].GetBefore(), "unknown chat gets the latest page")
+	})
+	t.Run("LastMessagesError", func(t *testing.T) {
+		t.Parallel()
+
+		chat := mock_primary.NewMockChat(t)
+		chat.EXPECT().LastMessages(mock.Anything, uid).Return(nil, errors.New("unavailable"))
+
+		handler := &InfoHandler{log: log, chat: chat}
+		assert.Same(t, route.ErrResponseInternal, handler.Sync(context.Background(), syncRequest(uid)))
+	})
+	t.Run("MessagesError", func(t *testing.T) {
+		t.Parallel()
+
+		last := model.ChatMessage{Id: newId(), Cid: newId()}
+
+		chat := mock_primary.NewMockChat(t)
+		chat.EXPECT().LastMessages(mock.Anything, uid).Return([]model.ChatMessage{last}, nil)
+		chat.EXPECT().Messages(mock.Anything, last.Cid, uid, []byte(nil), []byte(nil), 10).
+			Return(nil, errors.New("unavailable"))
+
+		handler := &InfoHandler{log: log, chat: chat}
+		assert.Same(t, route.ErrResponseInternal, handler.Sync(context.Background(), syncRequest(uid)))
+	})
+}
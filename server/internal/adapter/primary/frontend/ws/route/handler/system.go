@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"log/slog"
 )
@@ -14,6 +15,7 @@ type SystemHandler struct {
 func RegisterSystemHandler(
 	log *slog.Logger,
 	r *route.Router,
+	rateLimit *middleware.RateLimitMiddleware,
 ) {
 	handler := SystemHandler{log: log}
 
@@ -21,7 +23,7 @@ func RegisterSystemHandler(
 		frontendv1.UpstreamType_U_ECHO,
 		frontendv1.DownstreamType_D_ECHO,
 		&frontendv1.UpstreamEcho{},
-		handler.Echo,
+		rateLimit.WithRateLimit(handler.Echo),
 	)
 
 	handler.log.Debug("system handler registered")
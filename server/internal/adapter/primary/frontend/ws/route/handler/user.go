@@ -2,53 +2,71 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
 	"github.com/dvid-messanger/internal/core/domain/converter"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"log/slog"
 )
 
 type UserHandler struct {
-	log  *slog.Logger
-	user primary.User
-	auth primary.Auth
+	log          *slog.Logger
+	user         primary.User
+	registration primary.Registration
+	profile      primary.Profile
 }
 
 func RegisterUserHandler(
 	log *slog.Logger,
 	r *route.Router,
 	user primary.User,
-	auth primary.Auth,
+	registration primary.Registration,
+	profile primary.Profile,
 	authMiddleware *middleware.AuthMiddleware,
+	rateLimit *middleware.RateLimitMiddleware,
 ) {
-	handler := UserHandler{log: log, user: user, auth: auth}
+	handler := UserHandler{log: log, user: user, registration: registration, profile: profile}
 
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_CUR_USER,
 		frontendv1.DownstreamType_D_CUR_USER,
 		&frontendv1.UpstreamCurUser{},
-		authMiddleware.WithAuth(handler.GetCurUser),
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.GetCurUser)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_GET_USER,
 		frontendv1.DownstreamType_D_GET_USER,
 		&frontendv1.UpstreamGetUser{},
-		authMiddleware.WithAuth(handler.GetUser),
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.GetUser)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_GET_USERS,
 		frontendv1.DownstreamType_D_GET_USERS,
 		&frontendv1.UpstreamGetUsers{},
-		authMiddleware.WithAuth(handler.GetUsers),
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.GetUsers)),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_SEARCH_USERS,
+		frontendv1.DownstreamType_D_SEARCH_USERS,
+		&frontendv1.UpstreamSearchUsers{},
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.SearchUsers)),
 	)
 	r.RegisterHandler(
 		frontendv1.UpstreamType_U_REG_USER,
 		frontendv1.DownstreamType_D_REG_USER,
 		&frontendv1.UpstreamRegUser{},
-		authMiddleware.WithAuth(handler.RegUser),
+		rateLimit.WithRateLimit(handler.RegUser),
+	)
+	r.RegisterHandler(
+		frontendv1.UpstreamType_U_UPDATE_PROFILE,
+		frontendv1.DownstreamType_D_UPDATE_PROFILE,
+		&frontendv1.UpstreamUpdateProfile{},
+		authMiddleware.WithAuth(rateLimit.WithRateLimit(handler.UpdateProfile)),
 	)
 
 	handler.log.Debug("user handler registered")
@@ -61,7 +79,7 @@ func (r *UserHandler) GetCurUser(ctx context.Context, request *route.UpstreamReq
 	user, err := r.user.User(ctx, request.AuthUid)
 	if err != nil {
 		log.Error("failed to get cur user", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
 	}
 
 	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamCurUser{User: converter.UserToDTO(user)}}
@@ -76,7 +94,7 @@ func (r *UserHandler) GetUser(ctx context.Context, request *route.UpstreamReques
 	user, err := r.user.User(ctx, upstream.GetUid())
 	if err != nil {
 		log.Error("failed to get user", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
 	}
 
 	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamGetUser{User: converter.UserToDTO(user)}}
@@ -89,28 +107,78 @@ func (r *UserHandler) GetUsers(ctx context.Context, _ *route.UpstreamRequest) *r
 	users, err := r.user.Users(ctx)
 	if err != nil {
 		log.Error("failed to get users", logger.Err(err))
-		return route.ErrResponseInternal
+		return route.ErrResponse(err)
 	}
 
 	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamGetUsers{Users: converter.UsersToDTO(users)}}
 }
 
+func (r *UserHandler) SearchUsers(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.SearchUsers"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamSearchUsers)
+
+	page, err := r.user.Search(
+		ctx,
+		upstream.GetQuery(),
+		upstream.GetPrefix(),
+		upstream.GetAfter(),
+		int(upstream.GetLimit()),
+	)
+	if err != nil {
+		log.Error("failed to search users", logger.Err(err))
+		return route.ErrResponse(err)
+	}
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamSearchUsers{
+		Users: converter.UsersToDTO(page.Users),
+		Next:  page.Next,
+	}}
+}
+
 func (r *UserHandler) RegUser(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
 	const op = "handler.RegUser"
 	log := r.log.With(slog.String("op", op))
 
 	upstream := request.Payload.(*frontendv1.UpstreamRegUser)
 
-	usr, err := r.user.Create(ctx, upstream.GetEmail(), upstream.GetBio())
+	usr, err := r.registration.Register(ctx, upstream.GetEmail(), upstream.GetPassword(), upstream.GetBio())
 	if err != nil {
-		log.Error("failed to create user", logger.Err(err))
-		return route.ErrResponseInternal
+		switch {
+		case errors.Is(err, validate.ErrInvalidEmail):
+			return &route.UpstreamResponse{ErrCode: frontendv1.ErrorCode_INVALID_EMAIL, ErrDesc: "invalid email"}
+		case errors.Is(err, validate.ErrWeakPassword):
+			return &route.UpstreamResponse{
+				ErrCode: frontendv1.ErrorCode_WEAK_PASSWORD,
+				ErrDesc: "password must be 8 to 72 characters mixing letters, digits or symbols",
+			}
+		case errors.Is(err, primary.ErrAlreadyExists):
+			return &route.UpstreamResponse{ErrCode: frontendv1.ErrorCode_EMAIL_TAKEN, ErrDesc: "email already registered"}
+		}
+
+		log.Error("failed to register user", logger.Err(err))
+		return route.ErrResponse(err)
 	}
-	_, err = r.auth.Create(ctx, usr.Id, upstream.GetEmail(), upstream.GetPassword())
+
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamRegUser{User: converter.UserToDTO(usr)}}
+}
+
+func (r *UserHandler) UpdateProfile(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+	const op = "handler.UpdateProfile"
+	log := r.log.With(slog.String("op", op))
+
+	upstream := request.Payload.(*frontendv1.UpstreamUpdateProfile)
+
+	usr, err := r.profile.Update(ctx, request.AuthUid, model.ProfileUpdate{
+		DisplayName: upstream.DisplayName,
+		Avatar:      upstream.Avatar,
+		Bio:         upstream.Bio,
+	})
 	if err != nil {
-		log.Error("failed to create auth", logger.Err(err))
-		return route.ErrResponseInternal
+		log.Error("failed to update profile", logger.Err(err))
+		return route.ErrResponse(err)
 	}
 
-	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamRegUser{User: converter.UserToDTO(usr)}}
+	return &route.UpstreamResponse{Payload: &frontendv1.DownstreamUpdateProfile{User: converter.UserToDTO(usr)}}
 }
@@ -2,9 +2,9 @@ package middleware
 
 import (
 	"context"
-	"encoding/base64"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
+	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"log/slog"
 )
@@ -30,29 +30,17 @@ func (a *AuthMiddleware) WithAuth(fun route.HandlerFunc) route.HandlerFunc {
 			return route.ErrResponseUnauthorized
 		}
 
-		claims, err := a.tv.Verify(token)
+		claims, err := a.tv.Verify(ctx, token)
 		if err != nil {
 			log.Error("failed to verify auth token", logger.Err(err))
 			return route.ErrResponseUnauthorized
 		}
-
-		uidClaim, ok := claims["uid"]
-		if !ok {
-			log.Error("uid claim missing")
-			return route.ErrResponseUnauthorized
-		}
-		uidStr, ok := uidClaim.(string)
-		if !ok {
-			log.Error("uid claim is not a string")
-			return route.ErrResponseUnauthorized
-		}
-		uid, err := base64.StdEncoding.DecodeString(uidStr)
-		if err != nil {
-			log.Error("failed to decode uid string", logger.Err(err))
+		if claims.Type != model.TokenTypeAccess {
+			log.Error("not an access token", slog.String("typ", claims.Type))
 			return route.ErrResponseUnauthorized
 		}
 
-		request.AuthUid = uid
+		request.AuthUid = claims.Uid
 		return fun(ctx, request)
 	}
 }
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
+	"github.com/dvid-messanger/internal/pkg/ratelimit"
+	"github.com/dvid-messanger/pkg/id"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"log/slog"
+)
+
+type limiters struct {
+	client *ratelimit.Limiter
+	user   *ratelimit.Limiter
+}
+
+type RateLimitMiddleware struct {
+	log      *slog.Logger
+	limiters map[frontendv1.UpstreamType]*limiters
+	strikes  *ratelimit.Limiter
+}
+
+// NewRateLimitMiddleware limits every upstream type in limits separately per
+// client and per authenticated user. A client running out of strikes for
+// rate limited requests is disconnected.
+func NewRateLimitMiddleware(
+	log *slog.Logger,
+	limits map[frontendv1.UpstreamType]ratelimit.Limit,
+	strikes ratelimit.Limit,
+) *RateLimitMiddleware {
+	m := &RateLimitMiddleware{
+		log:      log,
+		limiters: make(map[frontendv1.UpstreamType]*limiters, len(limits)),
+	}
+	for ut, limit := range limits {
+		m.limiters[ut] = &limiters{
+			client: ratelimit.NewLimiter(limit),
+			user:   ratelimit.NewLimiter(limit),
+		}
+	}
+	if strikes.Burst > 0 {
+		m.strikes = ratelimit.NewLimiter(strikes)
+	}
+
+	return m
+}
+
+func (m *RateLimitMiddleware) WithRateLimit(fun route.HandlerFunc) route.HandlerFunc {
+	return func(ctx context.Context, request *route.UpstreamRequest) *route.UpstreamResponse {
+		const op = "middleware.WithRateLimit"
+		log := m.log.With(slog.String("op", op), slog.String("ut", request.Type.String()))
+
+		l := m.limiters[request.Type]
+		if l == nil {
+			return fun(ctx, request)
+		}
+
+		ok, retryAfter := l.client.Allow(id.String(request.ClientId))
+		if ok && request.AuthUid != nil {
+			ok, retryAfter = l.user.Allow(id.String(request.AuthUid))
+		}
+		if ok {
+			return fun(ctx, request)
+		}
+
+		log.Warn("rate limited", slog.String("c", id.String(request.ClientId)))
+		return route.ErrResponseRateLimited(retryAfter, !m.strike(request.ClientId))
+	}
+}
+
+// strike records a violation of the client, reporting false once the client
+// ran out of strikes.
+func (m *RateLimitMiddleware) strike(clientId []byte) bool {
+	if m.strikes == nil {
+		return true
+	}
+
+	ok, _ := m.strikes.Allow(id.String(clientId))
+	return ok
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
+	"github.com/dvid-messanger/internal/pkg/ratelimit"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+var log = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+func ok(_ context.Context, _ *route.UpstreamRequest) *route.UpstreamResponse {
+	return &route.UpstreamResponse{}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	m := NewRateLimitMiddleware(
+		log,
+		map[frontendv1.UpstreamType]ratelimit.Limit{
+			frontendv1.UpstreamType_U_SEND_MESSAGE: {Rate: 1, Burst: 1},
+		},
+		ratelimit.PerWindow(2, time.Hour),
+	)
+	handler := m.WithRateLimit(ok)
+
+	request := func(ut frontendv1.UpstreamType, clientId string, uid string) *route.UpstreamResponse {
+		req := &route.UpstreamRequest{Type: ut, ClientId: []byte(clientId)}
+		if uid != "" {
+			req.AuthUid = []byte(uid)
+		}
+		return handler(context.Background(), req)
+	}
+
+	assert.Equal(t, frontendv1.ErrorCode_NO_ERROR, request(frontendv1.UpstreamType_U_SEND_MESSAGE, "c1", "u1").ErrCode)
+	assert.Equal(t, frontendv1.ErrorCode_NO_ERROR, request(frontendv1.UpstreamType_U_ECHO, "c1", "u1").ErrCode, "unlimited type")
+
+	resp := request(frontendv1.UpstreamType_U_SEND_MESSAGE, "c1", "u1")
+	assert.Equal(t, frontendv1.ErrorCode_RATE_LIMITED, resp.ErrCode)
+	assert.Positive(t, resp.RetryAfter)
+	assert.False(t, resp.Disconnect)
+
+	resp = request(frontendv1.UpstreamType_U_SEND_MESSAGE, "c2", "u1")
+	assert.Equal(t, frontendv1.ErrorCode_RATE_LIMITED, resp.ErrCode, "user limited on other connections")
+
+	assert.Equal(t, frontendv1.ErrorCode_NO_ERROR, request(frontendv1.UpstreamType_U_SEND_MESSAGE, "c3", "u2").ErrCode)
+
+	resp = request(frontendv1.UpstreamType_U_SEND_MESSAGE, "c1", "u1")
+	assert.False(t, resp.Disconnect, "second strike")
+	resp = request(frontendv1.UpstreamType_U_SEND_MESSAGE, "c1", "u1")
+	assert.True(t, resp.Disconnect, "out of strikes")
+}
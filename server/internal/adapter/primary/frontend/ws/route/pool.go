@@ -0,0 +1,116 @@
+package route
+
+import (
+	"sync"
+)
+
+// pool bounds the requests of a single connection processed at once and
+// orders them according to their handler's ordering.
+type pool struct {
+	sem      chan struct{}
+	pending  int
+	inflight map[chan struct{}]struct{}
+	barrier  chan struct{}
+	lanes    map[string]chan struct{}
+}
+
+type task struct {
+	done chan struct{}
+	deps []chan struct{}
+	key  string
+}
+
+type pools struct {
+	mu          sync.Mutex
+	concurrency int
+	conns       map[string]*pool
+}
+
+func newPools(concurrency int) *pools {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &pools{concurrency: concurrency, conns: make(map[string]*pool)}
+}
+
+// acquire returns the pool of the connection, keeping it alive until release.
+func (ps *pools) acquire(conn string) *pool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	p := ps.conns[conn]
+	if p == nil {
+		p = &pool{
+			sem:      make(chan struct{}, ps.concurrency),
+			inflight: make(map[chan struct{}]struct{}),
+			lanes:    make(map[string]chan struct{}),
+		}
+		ps.conns[conn] = p
+	}
+	p.pending++
+
+	return p
+}
+
+// schedule takes a slot of the pool, blocking while all of them are taken, and
+// returns a task waiting for the requests it must run after.
+func (ps *pools) schedule(p *pool, key string, exclusive bool) *task {
+	p.sem <- struct{}{}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	t := &task{done: make(chan struct{}), key: key}
+	if exclusive {
+		for done := range p.inflight {
+			t.deps = append(t.deps, done)
+		}
+		p.barrier = t.done
+	} else {
+		if p.barrier != nil {
+			t.deps = append(t.deps, p.barrier)
+		}
+		if key != "" {
+			if prev := p.lanes[key]; prev != nil {
+				t.deps = append(t.deps, prev)
+			}
+			p.lanes[key] = t.done
+		}
+	}
+	p.inflight[t.done] = struct{}{}
+
+	return t
+}
+
+func (t *task) wait() {
+	for _, dep := range t.deps {
+		<-dep
+	}
+}
+
+// finish marks the task done and frees its slot.
+func (ps *pools) finish(p *pool, t *task) {
+	close(t.done)
+
+	ps.mu.Lock()
+	delete(p.inflight, t.done)
+	if p.barrier == t.done {
+		p.barrier = nil
+	}
+	if t.key != "" && p.lanes[t.key] == t.done {
+		delete(p.lanes, t.key)
+	}
+	ps.mu.Unlock()
+
+	<-p.sem
+}
+
+func (ps *pools) release(conn string, p *pool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	p.pending--
+	if p.pending == 0 {
+		delete(ps.conns, conn)
+	}
+}
@@ -1,21 +1,31 @@
 package route
 
 import (
+	"errors"
 	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
+	"math"
+	"time"
 )
 
 type UpstreamRequest struct {
-	ClientId []byte
-	AuthUid  []byte
-	Payload  proto.Message
+	Type      frontendv1.UpstreamType
+	RequestId uint64
+	ClientId  []byte
+	AuthUid   []byte
+	Payload   proto.Message
 }
 
 type UpstreamResponse struct {
-	ErrCode frontendv1.ErrorCode
-	ErrDesc string
-	Payload proto.Message
+	ErrCode    frontendv1.ErrorCode
+	ErrDesc    string
+	ErrReason  string
+	RetryAfter time.Duration
+	Payload    proto.Message
+	// Disconnect closes the connection once the response is sent
+	Disconnect bool
 }
 
 var ErrResponseInternal = &UpstreamResponse{
@@ -33,11 +43,64 @@ var ErrResponseUnauthorized = &UpstreamResponse{
 	ErrDesc: "unauthorized",
 }
 
-func MarshalResponse(response *UpstreamResponse, dt frontendv1.DownstreamType) ([]byte, error) {
+var ErrResponseForbidden = &UpstreamResponse{
+	ErrCode: frontendv1.ErrorCode_FORBIDDEN,
+	ErrDesc: "forbidden",
+}
+
+var ErrResponseUnavailable = &UpstreamResponse{
+	ErrCode: frontendv1.ErrorCode_UNAVAILABLE,
+	ErrDesc: "server is shutting down",
+}
+
+func ErrResponseRateLimited(retryAfter time.Duration, disconnect bool) *UpstreamResponse {
+	return &UpstreamResponse{
+		ErrCode:    frontendv1.ErrorCode_RATE_LIMITED,
+		ErrDesc:    "rate limited",
+		RetryAfter: retryAfter,
+		Disconnect: disconnect,
+	}
+}
+
+var errCodes = []struct {
+	kind error
+	code frontendv1.ErrorCode
+}{
+	{primary.ErrNotFound, frontendv1.ErrorCode_NOT_FOUND},
+	{primary.ErrAlreadyExists, frontendv1.ErrorCode_ALREADY_EXISTS},
+	{primary.ErrInvalidArgument, frontendv1.ErrorCode_INVALID_ARGUMENT},
+	{primary.ErrFailedPrecondition, frontendv1.ErrorCode_FAILED_PRECONDITION},
+	{primary.ErrPermissionDenied, frontendv1.ErrorCode_FORBIDDEN},
+	{primary.ErrUnauthenticated, frontendv1.ErrorCode_UNAUTHORIZED},
+	{primary.ErrThrottled, frontendv1.ErrorCode_RATE_LIMITED},
+}
+
+// ErrResponse maps a domain error to its response, unknown errors are internal.
+func ErrResponse(err error) *UpstreamResponse {
+	for _, c := range errCodes {
+		if !errors.Is(err, c.kind) {
+			continue
+		}
+
+		response := &UpstreamResponse{ErrCode: c.code, ErrDesc: c.kind.Error()}
+		var domainErr *primary.Error
+		if errors.As(err, &domainErr) {
+			response.ErrDesc = domainErr.Desc
+			response.ErrReason = domainErr.Reason
+			response.RetryAfter = domainErr.RetryAfter
+		}
+		return response
+	}
+
+	return ErrResponseInternal
+}
+
+func MarshalResponse(response *UpstreamResponse, dt frontendv1.DownstreamType, requestId uint64) ([]byte, error) {
 	const op = "request.MakeResponse"
 
 	downstream := &frontendv1.Downstream{
-		Type: dt,
+		Type:      dt,
+		RequestId: requestId,
 	}
 	if response.ErrCode == 0 {
 		if response.Payload == nil {
@@ -51,8 +114,10 @@ func MarshalResponse(response *UpstreamResponse, dt frontendv1.DownstreamType) (
 		downstream.Payload = marshalled
 	} else {
 		downstream.Error = &frontendv1.DownstreamError{
-			Code: response.ErrCode,
-			Desc: response.ErrDesc,
+			Code:         response.ErrCode,
+			Desc:         response.ErrDesc,
+			RetryAfterMs: retryAfterMs(response.RetryAfter),
+			Reason:       response.ErrReason,
 		}
 	}
 
@@ -63,3 +128,14 @@ func MarshalResponse(response *UpstreamResponse, dt frontendv1.DownstreamType) (
 
 	return res, nil
 }
+
+// retryAfterMs saturates instead of wrapping around for delays not fitting
+// the field.
+func retryAfterMs(retryAfter time.Duration) uint32 {
+	ms := retryAfter.Milliseconds()
+	if ms > math.MaxUint32 {
+		return math.MaxUint32
+	}
+
+	return uint32(max(ms, 0))
+}
@@ -0,0 +1,69 @@
+package route
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestErrResponse(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("op: %w", &primary.Error{Kind: primary.ErrNotFound, Reason: "CHAT_NOT_FOUND", Desc: "chat not found"})
+	resp := ErrResponse(err)
+	assert.Equal(t, frontendv1.ErrorCode_NOT_FOUND, resp.ErrCode)
+	assert.Equal(t, "chat not found", resp.ErrDesc)
+	assert.Equal(t, "CHAT_NOT_FOUND", resp.ErrReason)
+
+	msg, err := MarshalResponse(resp, frontendv1.DownstreamType_D_GET_CHAT, 1)
+	require.NoError(t, err)
+	downstream := &frontendv1.Downstream{}
+	require.NoError(t, proto.Unmarshal(msg, downstream))
+	assert.Equal(t, "CHAT_NOT_FOUND", downstream.GetError().GetReason())
+
+	assert.Equal(t, frontendv1.ErrorCode_FORBIDDEN, ErrResponse(fmt.Errorf("op: %w", primary.ErrPermissionDenied)).ErrCode)
+	assert.Same(t, ErrResponseInternal, ErrResponse(errors.New("failed")))
+
+	throttled := ErrResponse(fmt.Errorf("op: %w", &primary.Error{
+		Kind:       primary.ErrThrottled,
+		Reason:     "RATE_LIMITED",
+		Desc:       "rate limited",
+		RetryAfter: time.Second,
+	}))
+	assert.Equal(t, frontendv1.ErrorCode_RATE_LIMITED, throttled.ErrCode)
+	assert.Equal(t, time.Second, throttled.RetryAfter)
+}
+
+func TestMarshalResponse_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		retryAfter time.Duration
+		ms         uint32
+	}{
+		{name: "Regular", retryAfter: 1500 * time.Millisecond, ms: 1500},
+		{name: "Saturated", retryAfter: math.MaxInt64, ms: math.MaxUint32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := ErrResponseRateLimited(tt.retryAfter, false)
+			msg, err := MarshalResponse(resp, frontendv1.DownstreamType_D_SEND_MESSAGE, 1)
+			require.NoError(t, err)
+
+			downstream := &frontendv1.Downstream{}
+			require.NoError(t, proto.Unmarshal(msg, downstream))
+			assert.Equal(t, tt.ms, downstream.GetError().GetRetryAfterMs())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws"
 	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/id"
 	"github.com/dvid-messanger/protos/gen/frontend"
 	"github.com/golang/protobuf/proto"
 	"log/slog"
@@ -17,19 +18,32 @@ const (
 
 type HandlerFunc = func(context.Context, *UpstreamRequest) *UpstreamResponse
 
+// OrderKeyFunc returns the key of a request payload, requests of a connection
+// with the same key are processed in the order they were received.
+type OrderKeyFunc = func(payload proto.Message) string
+
 type Handler struct {
-	dt  frontendv1.DownstreamType
-	msg proto.Message
-	fun HandlerFunc
+	dt        frontendv1.DownstreamType
+	msg       proto.Message
+	fun       HandlerFunc
+	exclusive bool
+	orderKey  OrderKeyFunc
 }
 
 type Router struct {
 	log    *slog.Logger
 	routes map[frontendv1.UpstreamType]*Handler
+	pools  *pools
 }
 
-func NewRouter(log *slog.Logger) *Router {
-	return &Router{log: log, routes: make(map[frontendv1.UpstreamType]*Handler)}
+// NewRouter creates a router processing up to concurrency requests of every
+// connection at once.
+func NewRouter(log *slog.Logger, concurrency int) *Router {
+	return &Router{
+		log:    log,
+		routes: make(map[frontendv1.UpstreamType]*Handler),
+		pools:  newPools(concurrency),
+	}
 }
 
 func (r *Router) RegisterHandler(ut frontendv1.UpstreamType, dt frontendv1.DownstreamType, msg proto.Message, fun HandlerFunc) {
@@ -44,21 +58,38 @@ func (r *Router) RegisterHandler(ut frontendv1.UpstreamType, dt frontendv1.Downs
 	r.routes[ut] = &Handler{dt: dt, msg: msg, fun: fun}
 }
 
-func (r *Router) Handle(c *ws.Client, msg []byte) {
+// Exclusive makes requests of ut wait for every earlier request of the
+// connection and hold back every later one, as needed for auth state changes.
+func (r *Router) Exclusive(ut frontendv1.UpstreamType) {
+	if handler := r.routes[ut]; handler != nil {
+		handler.exclusive = true
+	}
+}
+
+// Ordered processes requests of ut sharing a key in the order they were received.
+func (r *Router) Ordered(ut frontendv1.UpstreamType, key OrderKeyFunc) {
+	if handler := r.routes[ut]; handler != nil {
+		handler.orderKey = key
+	}
+}
+
+func (r *Router) Handle(c *ws.Client, msg []byte, done func()) {
 	const op = "upstream.HandleMsg"
 	log := r.log.With(slog.String("op", op), slog.String("cl", c.GetAddr().String()))
 
 	upstream := &frontendv1.Upstream{}
 	if err := proto.Unmarshal(msg, upstream); err != nil {
 		log.Error("failed to unmarshal upstream", logger.Err(err))
+		done()
 		return
 	}
 
-	log = log.With(slog.String("ut", upstream.Type.String()))
+	log = log.With(slog.String("ut", upstream.Type.String()), slog.Uint64("rid", upstream.RequestId))
 
 	handler := r.routes[upstream.Type]
 	if handler == nil {
 		log.Error("handler not found")
+		done()
 		return
 	}
 
@@ -66,13 +97,67 @@ func (r *Router) Handle(c *ws.Client, msg []byte) {
 	err := proto.Unmarshal(upstream.Payload, payload)
 	if err != nil {
 		log.Error("failed to unmarshal upstream payload", logger.Err(err))
+		done()
+		return
+	}
+
+	var key string
+	if handler.orderKey != nil {
+		key = handler.orderKey(payload)
+	}
+
+	conn := id.String(c.GetId())
+	p := r.pools.acquire(conn)
+	t := r.pools.schedule(p, key, handler.exclusive)
+
+	go func() {
+		defer done()
+		defer r.pools.release(conn, p)
+		defer r.pools.finish(p, t)
+
+		t.wait()
+		r.serve(log, c, handler, upstream, payload)
+	}()
+}
+
+// Refuse answers the request in msg with an unavailable error, so the client
+// can retry it on a new connection.
+func (r *Router) Refuse(c *ws.Client, msg []byte) {
+	const op = "upstream.Refuse"
+	log := r.log.With(slog.String("op", op), slog.String("cl", c.GetAddr().String()))
+
+	upstream := &frontendv1.Upstream{}
+	if err := proto.Unmarshal(msg, upstream); err != nil {
+		log.Error("failed to unmarshal upstream", logger.Err(err))
+		return
+	}
+
+	handler := r.routes[upstream.Type]
+	if handler == nil {
+		log.Error("handler not found")
 		return
 	}
 
+	downstream, err := MarshalResponse(ErrResponseUnavailable, handler.dt, upstream.RequestId)
+	if err != nil {
+		log.Error("failed to marshal response", logger.Err(err))
+		return
+	}
+	if err = c.Send(downstream); err != nil {
+		log.Warn("failed to send response", logger.Err(err))
+	}
+}
+
+func (r *Router) serve(log *slog.Logger, c *ws.Client, handler *Handler, upstream *frontendv1.Upstream, payload proto.Message) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
 	defer cancel()
 
-	resp := handler.fun(ctx, &UpstreamRequest{ClientId: c.GetId(), Payload: payload})
+	resp := handler.fun(ctx, &UpstreamRequest{
+		Type:      upstream.Type,
+		RequestId: upstream.RequestId,
+		ClientId:  c.GetId(),
+		Payload:   payload,
+	})
 	if ctx.Err() != nil {
 		log.Error("request timeout")
 		resp = ErrResponseTimeout
@@ -82,14 +167,20 @@ func (r *Router) Handle(c *ws.Client, msg []byte) {
 		resp = ErrResponseInternal
 	}
 
-	downstream, err := MarshalResponse(resp, handler.dt)
+	downstream, err := MarshalResponse(resp, handler.dt, upstream.RequestId)
 	if err != nil {
 		log.Error("failed to marshal response", logger.Err(err))
 		return
 	}
 
 	if downstream != nil {
-		_ = c.Send(downstream)
+		if err = c.Send(downstream); err != nil {
+			log.Warn("failed to send response", logger.Err(err))
+		}
+	}
+	if resp.Disconnect {
+		log.Warn("disconnecting client")
+		c.Kick(resp.ErrDesc)
 	}
 	log.Debug("msg handled")
 }
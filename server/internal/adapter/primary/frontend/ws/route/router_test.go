@@ -0,0 +1,183 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws"
+	"github.com/dvid-messanger/internal/core/service/frontend"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var log = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+type gatedHandler struct {
+	mu      sync.Mutex
+	gates   map[string]chan struct{}
+	started []string
+}
+
+func (h *gatedHandler) gate(content string) chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	gate := make(chan struct{})
+	h.gates[content] = gate
+	return gate
+}
+
+func (h *gatedHandler) hasStarted(content string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, s := range h.started {
+		if s == content {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *gatedHandler) Echo(ctx context.Context, request *UpstreamRequest) *UpstreamResponse {
+	content := request.Payload.(*frontendv1.UpstreamEcho).GetContent()
+
+	h.mu.Lock()
+	h.started = append(h.started, content)
+	gate := h.gates[content]
+	h.mu.Unlock()
+
+	if gate != nil {
+		select {
+		case <-gate:
+		case <-ctx.Done():
+		}
+	}
+
+	return &UpstreamResponse{Payload: &frontendv1.DownstreamEcho{Content: content}}
+}
+
+func setupRouter(t *testing.T, concurrency int) (*gatedHandler, *websocket.Conn) {
+	t.Helper()
+
+	handler := &gatedHandler{gates: make(map[string]chan struct{})}
+	router := NewRouter(log, concurrency)
+	router.RegisterHandler(frontendv1.UpstreamType_U_ECHO, frontendv1.DownstreamType_D_ECHO, &frontendv1.UpstreamEcho{}, handler.Echo)
+	router.RegisterHandler(frontendv1.UpstreamType_U_SEND_MESSAGE, frontendv1.DownstreamType_D_SEND_MESSAGE, &frontendv1.UpstreamEcho{}, handler.Echo)
+	router.RegisterHandler(frontendv1.UpstreamType_U_LOGIN, frontendv1.DownstreamType_D_LOGIN, &frontendv1.UpstreamEcho{}, handler.Echo)
+	router.Ordered(frontendv1.UpstreamType_U_SEND_MESSAGE, func(payload proto.Message) string {
+		return payload.(*frontendv1.UpstreamEcho).GetContent()[:1]
+	})
+	router.Exclusive(frontendv1.UpstreamType_U_LOGIN)
+
+	registry := frontend.NewClientRegistry(log, nil, nil)
+	server := ws.NewWsServer(log, registry, nil, nil, nil, router, 16, ws.SendPolicyDisconnect, 1024, 1024, time.Second, 1024, time.Second, time.Minute)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(server.Handle))
+	t.Cleanup(httpServer.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return handler, conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, ut frontendv1.UpstreamType, rid uint64, content string) {
+	t.Helper()
+
+	payload, err := proto.Marshal(&frontendv1.UpstreamEcho{Content: content})
+	require.NoError(t, err)
+	msg, err := proto.Marshal(&frontendv1.Upstream{Type: ut, Payload: payload, RequestId: rid})
+	require.NoError(t, err)
+
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, msg))
+}
+
+func receive(t *testing.T, conn *websocket.Conn, n int) []uint64 {
+	t.Helper()
+
+	rids := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		_, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+
+		downstream := &frontendv1.Downstream{}
+		require.NoError(t, proto.Unmarshal(msg, downstream))
+		rids = append(rids, downstream.GetRequestId())
+	}
+
+	return rids
+}
+
+func TestRouter_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	handler, conn := setupRouter(t, 4)
+	gate := handler.gate("slow")
+
+	send(t, conn, frontendv1.UpstreamType_U_ECHO, 1, "slow")
+	send(t, conn, frontendv1.UpstreamType_U_ECHO, 2, "fast")
+
+	assert.Equal(t, []uint64{2}, receive(t, conn, 1), "slow request does not block the connection")
+	close(gate)
+	assert.Equal(t, []uint64{1}, receive(t, conn, 1))
+}
+
+func TestRouter_ConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	handler, conn := setupRouter(t, 1)
+	gate := handler.gate("slow")
+
+	send(t, conn, frontendv1.UpstreamType_U_ECHO, 1, "slow")
+	send(t, conn, frontendv1.UpstreamType_U_ECHO, 2, "fast")
+
+	require.Eventually(t, func() bool { return handler.hasStarted("slow") }, time.Second, 5*time.Millisecond)
+	close(gate)
+	assert.Equal(t, []uint64{1, 2}, receive(t, conn, 2))
+}
+
+func TestRouter_Ordered(t *testing.T) {
+	t.Parallel()
+
+	handler, conn := setupRouter(t, 4)
+	gate := handler.gate("a-slow")
+
+	send(t, conn, frontendv1.UpstreamType_U_SEND_MESSAGE, 1, "a-slow")
+	send(t, conn, frontendv1.UpstreamType_U_SEND_MESSAGE, 2, "a-fast")
+	send(t, conn, frontendv1.UpstreamType_U_SEND_MESSAGE, 3, "b-fast")
+
+	assert.Equal(t, []uint64{3}, receive(t, conn, 1), "other keys are not held back")
+	assert.False(t, handler.hasStarted("a-fast"), "same key waits for the earlier request")
+	close(gate)
+	assert.Equal(t, []uint64{1, 2}, receive(t, conn, 2))
+}
+
+func TestRouter_Exclusive(t *testing.T) {
+	t.Parallel()
+
+	handler, conn := setupRouter(t, 4)
+	gate := handler.gate("slow")
+
+	send(t, conn, frontendv1.UpstreamType_U_ECHO, 1, "slow")
+	send(t, conn, frontendv1.UpstreamType_U_LOGIN, 2, "login")
+	send(t, conn, frontendv1.UpstreamType_U_ECHO, 3, "fast")
+
+	require.Eventually(t, func() bool { return handler.hasStarted("slow") }, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, handler.hasStarted("login"), "exclusive request waits for earlier ones")
+	assert.False(t, handler.hasStarted("fast"), "later requests wait for the exclusive one")
+
+	close(gate)
+	assert.Equal(t, []uint64{1, 2, 3}, receive(t, conn, 3))
+}
@@ -0,0 +1,64 @@
+package ws
+
+import (
+	"context"
+	"sync"
+)
+
+// tracker counts running operations and lets a drain wait for them to
+// finish, refusing new ones once draining started.
+type tracker struct {
+	mu       sync.Mutex
+	n        int
+	draining bool
+	idle     chan struct{}
+}
+
+func newTracker() *tracker {
+	return &tracker{idle: make(chan struct{})}
+}
+
+func (t *tracker) begin() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.draining {
+		return false
+	}
+	t.n++
+
+	return true
+}
+
+func (t *tracker) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.n--
+	if t.draining && t.n == 0 {
+		close(t.idle)
+	}
+}
+
+func (t *tracker) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.draining {
+		t.draining = true
+		if t.n == 0 {
+			close(t.idle)
+		}
+	}
+}
+
+func (t *tracker) drain(ctx context.Context) error {
+	t.stop()
+
+	select {
+	case <-t.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
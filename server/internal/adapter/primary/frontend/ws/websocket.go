@@ -1,28 +1,56 @@
 package ws
 
 import (
+	"context"
+	"fmt"
 	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	reconnectHint       = "server shutting down, reconnect"
+	drainRetryAfter     = "1"
+	Subprotocol         = "messenger"
+	tokenProtocolPrefix = "bearer."
+	tokenQueryParam     = "access_token"
+	bearerPrefix        = "Bearer "
+)
+
+var goingAwayMessage = websocket.FormatCloseMessage(websocket.CloseGoingAway, reconnectHint)
+
 type Server struct {
 	log       *slog.Logger
 	upgrader  websocket.Upgrader
 	clientCfg ClientConfig
 	registry  primary.ClientRegistry
+	tv        primary.TokenVerifier
+	user      primary.User
+	chat      primary.Chat
 	handler   MsgHandler
+
+	conns   *tracker
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	closing bool
 }
 
 func NewWsServer(
 	log *slog.Logger,
 	registry primary.ClientRegistry,
+	tv primary.TokenVerifier,
+	user primary.User,
+	chat primary.Chat,
 	handler MsgHandler,
 	sendBuffSize int,
+	sendPolicy SendPolicy,
 	rBuffSize int,
 	wBuffSize int,
 	hsTimeout time.Duration,
@@ -36,11 +64,20 @@ func NewWsServer(
 			HandshakeTimeout: hsTimeout,
 			ReadBufferSize:   rBuffSize,
 			WriteBufferSize:  wBuffSize,
+			Subprotocols:     []string{Subprotocol},
 		},
 		registry: registry,
+		tv:       tv,
+		user:     user,
+		chat:     chat,
+		conns:    newTracker(),
+		clients:  make(map[*Client]struct{}),
 		handler:  handler,
 		clientCfg: ClientConfig{
 			sendMsgBuff:  sendBuffSize,
+			sendPolicy:   sendPolicy,
+			counters:     &sendCounters{},
+			handlers:     newTracker(),
 			readMsgLimit: msgLimit,
 			writeWait:    writeWait,
 			pongWait:     pongWait,
@@ -49,16 +86,182 @@ func NewWsServer(
 	}
 }
 
+func (s *Server) Stats() SendStats {
+	return s.clientCfg.counters.stats()
+}
+
+// ReportStats logs the send stats every interval in which messages were lost,
+// until stop is closed. A zero interval disables the reports.
+func (s *Server) ReportStats(interval time.Duration, stop <-chan struct{}) {
+	const op = "websocket.ReportStats"
+	log := s.log.With(slog.String("op", op))
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var reported SendStats
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		stats := s.Stats()
+		if stats.Lost() == reported.Lost() {
+			continue
+		}
+
+		log.Warn(
+			"websocket messages lost",
+			slog.Uint64("dropped_oldest", stats.DroppedOldest-reported.DroppedOldest),
+			slog.Uint64("dropped_newest", stats.DroppedNewest-reported.DroppedNewest),
+			slog.Uint64("disconnected", stats.Disconnected-reported.Disconnected),
+			slog.Any("total", stats),
+		)
+		reported = stats
+	}
+}
+
 func (s *Server) Handle(w http.ResponseWriter, r *http.Request) {
 	const op = "websocket.Handle"
 	log := s.log.With(slog.String("op", op))
 
-	conn, err := s.upgrader.Upgrade(w, r, nil)
+	token := handshakeToken(r)
+	var claims *model.TokenClaims
+	if token != "" {
+		var err error
+		claims, err = s.tv.Verify(r.Context(), token)
+		if err != nil || claims.Type != model.TokenTypeAccess {
+			log.Debug("handshake token rejected", logger.Err(err))
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if !s.conns.begin() {
+		w.Header().Set("Retry-After", drainRetryAfter)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	defer s.conns.end()
+
+	// an authenticated connection gets its user and chats at once, as InitInfo
+	// would, so pushes reach it before the first request
+	var user *model.User
+	var chats []model.Chat
+	if claims != nil {
+		var err error
+		if user, chats, err = s.info(r.Context(), claims.Uid); err != nil {
+			log.Error("failed to get handshake info", logger.Err(err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	upgrader := s.upgrader
+	if protocol := tokenProtocol(r); protocol != "" {
+		// a client may offer the token subprotocol alone, browsers then need
+		// it echoed back to accept the handshake
+		upgrader.Subprotocols = []string{Subprotocol, protocol}
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Error("connection upgrade failed", logger.Err(err))
 		return
 	}
 
 	id := uuid.New()
-	NewClient(id[:], s.log, s.registry, s.handler, conn, &s.clientCfg).Serve()
+	client := NewClient(id[:], s.log, s.registry, s.handler, conn, &s.clientCfg)
+
+	s.mu.Lock()
+	s.clients[client] = struct{}{}
+	if s.closing {
+		client.Close(goingAwayMessage)
+	}
+	s.mu.Unlock()
+
+	client.Serve(token, user, chats)
+
+	s.mu.Lock()
+	delete(s.clients, client)
+	s.mu.Unlock()
+}
+
+func (s *Server) info(ctx context.Context, uid []byte) (*model.User, []model.Chat, error) {
+	user, err := s.user.User(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chats, err := s.chat.UserChats(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, chats, nil
+}
+
+// Shutdown stops accepting connections, waits for in-flight handlers and
+// closes every client with CloseGoingAway. Connections still open when ctx
+// is done are closed forcibly.
+func (s *Server) Shutdown(ctx context.Context) error {
+	const op = "websocket.Shutdown"
+	log := s.log.With(slog.String("op", op))
+
+	s.conns.stop()
+
+	if err := s.clientCfg.handlers.drain(ctx); err != nil {
+		log.Warn("in-flight handlers not finished", logger.Err(err))
+	}
+
+	s.mu.Lock()
+	s.closing = true
+	for client := range s.clients {
+		client.Close(goingAwayMessage)
+	}
+	s.mu.Unlock()
+
+	if err := s.conns.drain(ctx); err != nil {
+		s.mu.Lock()
+		log.Warn("closing remaining connections", slog.Int("count", len(s.clients)))
+		for client := range s.clients {
+			_ = client.conn.Close()
+		}
+		s.mu.Unlock()
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("connections drained")
+	return nil
+}
+
+// handshakeToken looks up an access token in the Authorization header, a
+// "bearer.<token>" subprotocol or the access_token query parameter.
+func handshakeToken(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, bearerPrefix) {
+		return strings.TrimPrefix(header, bearerPrefix)
+	}
+
+	if protocol := tokenProtocol(r); protocol != "" {
+		return strings.TrimPrefix(protocol, tokenProtocolPrefix)
+	}
+
+	return r.URL.Query().Get(tokenQueryParam)
+}
+
+func tokenProtocol(r *http.Request) string {
+	for _, protocol := range websocket.Subprotocols(r) {
+		if strings.HasPrefix(protocol, tokenProtocolPrefix) {
+			return protocol
+		}
+	}
+
+	return ""
 }
@@ -0,0 +1,183 @@
+package ws
+
+import (
+	"context"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/frontend"
+	"github.com/dvid-messanger/internal/pkg/jwt"
+	"github.com/dvid-messanger/test/mocks/mock_primary"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandshakeToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		url    string
+		token  string
+	}{
+		{name: "Header", header: "Authorization", value: "Bearer tok.en", url: "/ws", token: "tok.en"},
+		{name: "Subprotocol", header: "Sec-WebSocket-Protocol", value: "messenger, bearer.tok.en", url: "/ws", token: "tok.en"},
+		{name: "Query", url: "/ws?access_token=tok.en", token: "tok.en"},
+		{name: "NoToken", header: "Authorization", value: "Basic abc", url: "/ws", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.header != "" {
+				r.Header.Set(tt.header, tt.value)
+			}
+
+			assert.Equal(t, tt.token, handshakeToken(r))
+		})
+	}
+}
+
+func TestServer_TokenSubprotocol(t *testing.T) {
+	t.Parallel()
+
+	keys := jwt.NewKeySet()
+	require.NoError(t, keys.Generate("k1"))
+	token, err := jwt.NewTokenizer(keys, nil).MakeToken(
+		model.UserCredentials{Id: []byte("0123456789abcdef")},
+		model.TokenTypeAccess,
+		time.Hour,
+	)
+	require.NoError(t, err)
+
+	uid := []byte("0123456789abcdef")
+	chat := model.Chat{Id: []byte("fedcba9876543210"), Members: []model.ChatMember{{Uid: uid}}}
+	users := mock_primary.NewMockUser(t)
+	users.EXPECT().User(mock.Anything, uid).Return(&model.User{Id: uid}, nil)
+	chats := mock_primary.NewMockChat(t)
+	chats.EXPECT().UserChats(mock.Anything, uid).Return([]model.Chat{chat}, nil)
+
+	registry := frontend.NewClientRegistry(log, nil, nil)
+	server := NewWsServer(
+		log,
+		registry,
+		jwt.NewVerifier(keys, nil),
+		users,
+		chats,
+		&blockingHandler{},
+		8,
+		SendPolicyDisconnect,
+		1024,
+		1024,
+		time.Second,
+		1024,
+		time.Second,
+		time.Minute,
+	)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(server.Handle))
+	defer httpServer.Close()
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+
+	tests := []struct {
+		name      string
+		protocols []string
+		selected  string
+	}{
+		{name: "WithMessenger", protocols: []string{Subprotocol, "bearer." + token}, selected: Subprotocol},
+		{name: "TokenOnly", protocols: []string{"bearer." + token}, selected: "bearer." + token},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer := websocket.Dialer{Subprotocols: tt.protocols}
+			conn, _, err := dialer.Dial(url, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			assert.Equal(t, tt.selected, conn.Subprotocol())
+			assert.Eventually(t, func() bool {
+				clients, _ := registry.Clients(chat.Id)
+				return len(clients) != 0
+			}, time.Second, 10*time.Millisecond, "handshake maps the client to the user chats")
+		})
+	}
+}
+
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *blockingHandler) Handle(c *Client, msg []byte, done func()) {
+	go func() {
+		defer done()
+		h.started <- struct{}{}
+		<-h.release
+		_ = c.Send(msg)
+	}()
+}
+
+func (h *blockingHandler) Refuse(c *Client, msg []byte) {
+	_ = c.Send(append([]byte("refused "), msg...))
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	handler := &blockingHandler{started: make(chan struct{}), release: make(chan struct{})}
+	registry := frontend.NewClientRegistry(log, nil, nil)
+	server := NewWsServer(log, registry, nil, nil, nil, handler, 8, SendPolicyDisconnect, 1024, 1024, time.Second, 1024, time.Second, time.Minute)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(server.Handle))
+	defer httpServer.Close()
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte("in-flight")))
+	<-handler.started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdown <- server.Shutdown(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		return err != nil && resp != nil && resp.StatusCode == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond, "upgrades rejected while draining")
+
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte("late")))
+	_, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("refused late"), msg, "requests answered while draining")
+
+	close(handler.release)
+
+	_, msg, err = conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("in-flight"), msg, "in-flight response delivered before close")
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, websocket.CloseGoingAway, closeErr.Code)
+	assert.Equal(t, reconnectHint, closeErr.Text)
+
+	require.NoError(t, <-shutdown)
+	assert.Empty(t, server.clients)
+}
@@ -3,37 +3,85 @@ package primary
 import (
 	"context"
 	"github.com/dvid-messanger/internal/core/domain/model"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type User interface {
 	Create(ctx context.Context, email string, bio string) (*model.User, error)
 	User(ctx context.Context, uid []byte) (*model.User, error)
 	Users(ctx context.Context) ([]model.User, error)
+	UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error)
+	Search(ctx context.Context, query string, prefix bool, after []byte, limit int) (*model.UsersPage, error)
+	Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error)
+	Delete(ctx context.Context, uid []byte) error
 }
 
 type Auth interface {
 	Create(ctx context.Context, uid []byte, email string, pass string) ([]byte, error)
-	Login(ctx context.Context, email string, pass string) (string, error)
+	Login(ctx context.Context, email string, pass string) (*model.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	Logout(ctx context.Context, accessToken string, refreshToken string) error
+	Revoked(ctx context.Context, jti string) (bool, error)
+	PublicKeys(ctx context.Context) ([]model.PublicKey, error)
+	Delete(ctx context.Context, uid []byte) error
+}
+
+type Registration interface {
+	Register(ctx context.Context, email string, password string, bio string) (*model.User, error)
+}
+
+type Profile interface {
+	Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error)
 }
 
 type Chat interface {
 	Create(ctx context.Context, fromUid []byte, toUid []byte) (*model.Chat, error)
+	CreateGroup(ctx context.Context, ownerUid []byte, title string, memberUids [][]byte) (*model.Chat, error)
 
-	Chat(ctx context.Context, cid []byte) (*model.Chat, error)
+	AddMember(ctx context.Context, cid []byte, uid []byte, memberUid []byte) (*model.Chat, error)
+	RemoveMember(ctx context.Context, cid []byte, uid []byte, memberUid []byte) (*model.Chat, error)
+	Leave(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error)
+
+	Chat(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error)
 	UserChats(ctx context.Context, uid []byte) ([]model.Chat, error)
+	ChatStates(ctx context.Context, uid []byte) ([]model.ChatState, error)
+	LastMessages(ctx context.Context, uid []byte) ([]model.ChatMessage, error)
 
-	SendMessage(ctx context.Context, cid []byte, uid []byte, text string) (*model.ChatMessage, error)
-	Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error)
+	SendMessage(ctx context.Context, cid []byte, uid []byte, text string, refs model.MessageRefs) (*model.ChatMessage, error)
+	EditMessage(ctx context.Context, cid []byte, mid []byte, uid []byte, text string) (*model.ChatMessage, error)
+	DeleteMessage(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ChatMessage, error)
+	Messages(ctx context.Context, cid []byte, uid []byte, before []byte, after []byte, limit int) (*model.MessagesPage, error)
+	SearchMessages(
+		ctx context.Context,
+		uid []byte,
+		cid []byte,
+		query string,
+		after []byte,
+		limit int,
+	) (*model.MessageHitsPage, error)
+	MarkRead(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ReadReceipt, error)
+	AddReaction(ctx context.Context, cid []byte, mid []byte, uid []byte, emoji string) (*model.ReactionUpdate, error)
+	RemoveReaction(ctx context.Context, cid []byte, mid []byte, uid []byte, emoji string) (*model.ReactionUpdate, error)
 }
 
 type Notifier interface {
 	NewMessage(ctx context.Context, message *model.ChatMessage) error
 	NewChat(ctx context.Context, message *model.Chat) error
+	ChatUpdated(ctx context.Context, chat *model.Chat) error
+	MessageEdited(ctx context.Context, message *model.ChatMessage) error
+	MessageDeleted(ctx context.Context, message *model.ChatMessage) error
+	MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error
+	ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error
+	Typing(ctx context.Context, cid []byte, uid []byte) error
+	Presence(ctx context.Context, cid []byte, uid []byte, online bool) error
+	ProfileUpdated(ctx context.Context, cids [][]byte, user *model.User) error
+}
+
+type Ephemeral interface {
+	Typing(ctx context.Context, clientId []byte, uid []byte, cid []byte) error
 }
 
 type TokenVerifier interface {
-	Verify(token string) (jwt.MapClaims, error)
+	Verify(ctx context.Context, token string) (*model.TokenClaims, error)
 }
 
 type Client interface {
@@ -44,6 +92,7 @@ type Client interface {
 type ClientRegistry interface {
 	Register(client Client) error
 	SetAuth(clientId []byte, auth string) error
+	ReplaceAuth(clientId []byte, uid []byte, auth string) error
 	UnsetAuth(clientId []byte) error
 	Auth(clientId []byte) (string, error)
 	SetInfo(clientId []byte, user *model.User, chats []model.Chat) error
@@ -5,12 +5,19 @@ import (
 	"errors"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/core/domain/converter"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/internal/core/service/user"
 	grpcutil "github.com/dvid-messanger/internal/pkg/grpc"
 	userv1 "github.com/dvid-messanger/protos/gen/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"unicode/utf8"
+)
+
+const (
+	maxQueryLen   = 64
+	maxUsersByIds = 500
 )
 
 type serverApi struct {
@@ -22,6 +29,29 @@ func Register(gRpc *grpc.Server, user primary.User) {
 	userv1.RegisterUserServiceServer(gRpc, &serverApi{user: user})
 }
 
+type internalApi struct {
+	userv1.UnimplementedUserInternalServiceServer
+	user primary.User
+}
+
+// RegisterInternal registers the calls meant for other services only, gRpc
+// must not listen where clients can reach it.
+func RegisterInternal(gRpc *grpc.Server, user primary.User) {
+	userv1.RegisterUserInternalServiceServer(gRpc, &internalApi{user: user})
+}
+
+func (s *internalApi) Delete(ctx context.Context, req *userv1.DeleteRequest) (*userv1.DeleteResponse, error) {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return nil, err
+	}
+
+	if err := s.user.Delete(ctx, req.GetUid()); err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &userv1.DeleteResponse{}, nil
+}
+
 func (s *serverApi) Create(ctx context.Context, req *userv1.CreateRequest) (*userv1.CreateResponse, error) {
 	if err := validateRegister(req); err != nil {
 		return nil, err
@@ -30,10 +60,13 @@ func (s *serverApi) Create(ctx context.Context, req *userv1.CreateRequest) (*use
 	usr, err := s.user.Create(ctx, req.GetEmail(), req.GetBio())
 	if err != nil {
 		if errors.Is(err, user.ErrUserExists) {
-			return nil, status.Error(codes.AlreadyExists, "user already exists")
+			return nil, grpcutil.Error(codes.AlreadyExists, grpcutil.ReasonUserExists, "user already exists")
+		}
+		if errors.Is(err, validate.ErrBio) {
+			return nil, grpcutil.Error(codes.InvalidArgument, grpcutil.ReasonInvalidBio, "invalid bio")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 
 	return &userv1.CreateResponse{User: converter.UserToDTO(usr)}, nil
@@ -47,10 +80,10 @@ func (s *serverApi) User(ctx context.Context, req *userv1.UserRequest) (*userv1.
 	usr, err := s.user.User(ctx, req.GetUid())
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
-			return nil, status.Error(codes.NotFound, "user not found")
+			return nil, grpcutil.Error(codes.NotFound, grpcutil.ReasonUserNotFound, "user not found")
 		}
 
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 	return &userv1.UserResponse{User: converter.UserToDTO(usr)}, nil
 }
@@ -58,11 +91,71 @@ func (s *serverApi) User(ctx context.Context, req *userv1.UserRequest) (*userv1.
 func (s *serverApi) Users(ctx context.Context, req *userv1.UsersRequest) (*userv1.UsersResponse, error) {
 	users, err := s.user.Users(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, grpcutil.ErrInternal
 	}
 	return &userv1.UsersResponse{Users: converter.UsersToDTO(users)}, nil
 }
 
+func (s *serverApi) SearchUsers(
+	ctx context.Context,
+	req *userv1.SearchUsersRequest,
+) (*userv1.SearchUsersResponse, error) {
+	if err := validateSearchUsers(req); err != nil {
+		return nil, err
+	}
+
+	page, err := s.user.Search(ctx, req.GetQuery(), req.GetPrefix(), req.GetAfter(), int(req.GetLimit()))
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &userv1.SearchUsersResponse{Users: converter.UsersToDTO(page.Users), Next: page.Next}, nil
+}
+
+func (s *serverApi) UsersByIds(
+	ctx context.Context,
+	req *userv1.UsersByIdsRequest,
+) (*userv1.UsersByIdsResponse, error) {
+	if err := validateUsersByIds(req); err != nil {
+		return nil, err
+	}
+
+	users, err := s.user.UsersByIds(ctx, req.GetUids())
+	if err != nil {
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &userv1.UsersByIdsResponse{Users: converter.UsersToDTO(users)}, nil
+}
+
+func (s *serverApi) Update(ctx context.Context, req *userv1.UpdateRequest) (*userv1.UpdateResponse, error) {
+	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
+		return nil, err
+	}
+
+	usr, err := s.user.Update(ctx, req.GetUid(), model.ProfileUpdate{
+		DisplayName: req.DisplayName,
+		Avatar:      req.Avatar,
+		Bio:         req.Bio,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			return nil, grpcutil.Error(codes.NotFound, grpcutil.ReasonUserNotFound, "user not found")
+		case errors.Is(err, validate.ErrDisplayName):
+			return nil, grpcutil.Error(codes.InvalidArgument, grpcutil.ReasonInvalidDisplayName, "invalid display name")
+		case errors.Is(err, validate.ErrAvatar):
+			return nil, grpcutil.Error(codes.InvalidArgument, grpcutil.ReasonInvalidAvatar, "invalid avatar")
+		case errors.Is(err, validate.ErrBio):
+			return nil, grpcutil.Error(codes.InvalidArgument, grpcutil.ReasonInvalidBio, "invalid bio")
+		}
+
+		return nil, grpcutil.ErrInternal
+	}
+
+	return &userv1.UpdateResponse{User: converter.UserToDTO(usr)}, nil
+}
+
 func validateUser(req *userv1.UserRequest) error {
 	if err := grpcutil.ValidateId(req.GetUid(), "uid"); err != nil {
 		return err
@@ -71,9 +164,38 @@ func validateUser(req *userv1.UserRequest) error {
 	return nil
 }
 
+func validateSearchUsers(req *userv1.SearchUsersRequest) error {
+	if utf8.RuneCountInString(req.GetQuery()) > maxQueryLen {
+		return grpcutil.FieldError("query", "is too long")
+	}
+	if len(req.GetAfter()) != 0 {
+		if err := grpcutil.ValidateId(req.GetAfter(), "after"); err != nil {
+			return err
+		}
+	}
+	if req.GetLimit() < 0 {
+		return grpcutil.FieldError("limit", "is bad")
+	}
+
+	return nil
+}
+
+func validateUsersByIds(req *userv1.UsersByIdsRequest) error {
+	if len(req.GetUids()) > maxUsersByIds {
+		return grpcutil.FieldError("uids", "has too many ids")
+	}
+	for _, uid := range req.GetUids() {
+		if err := grpcutil.ValidateId(uid, "uids"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateRegister(req *userv1.CreateRequest) error {
 	if req.GetEmail() == "" {
-		return status.Error(codes.InvalidArgument, "email is required")
+		return grpcutil.FieldError("email", "is required")
 	}
 
 	return nil
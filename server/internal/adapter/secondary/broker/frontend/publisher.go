@@ -0,0 +1,204 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/core/domain/converter"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/proto"
+	"github.com/dvid-messanger/internal/pkg/topic"
+	"github.com/dvid-messanger/pkg/pubsub"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"log/slog"
+)
+
+type Publisher struct {
+	log    *slog.Logger
+	broker pubsub.Broker
+}
+
+func New(log *slog.Logger, broker pubsub.Broker) *Publisher {
+	return &Publisher{log: log, broker: broker}
+}
+
+func (p *Publisher) NewMessage(ctx context.Context, message *model.ChatMessage) error {
+	const op = "broker.frontend.NewMessage"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.NewMessageRequest{Message: converter.ChatMessageToDTO(message)},
+		frontendv1.ChatEventType_E_NEW_MESSAGE,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(message.Cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) NewChat(ctx context.Context, chat *model.Chat) error {
+	const op = "broker.frontend.NewChat"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.NewChatRequest{Chat: converter.ChatToDTO(chat)},
+		frontendv1.ChatEventType_E_NEW_CHAT,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chats, event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) ChatUpdated(ctx context.Context, chat *model.Chat) error {
+	const op = "broker.frontend.ChatUpdated"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.ChatUpdatedRequest{Chat: converter.ChatToDTO(chat)},
+		frontendv1.ChatEventType_E_CHAT_UPDATED,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chats, event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) MessageEdited(ctx context.Context, message *model.ChatMessage) error {
+	const op = "broker.frontend.MessageEdited"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.MessageEditedRequest{Message: converter.ChatMessageToDTO(message)},
+		frontendv1.ChatEventType_E_MESSAGE_EDITED,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(message.Cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) MessageDeleted(ctx context.Context, message *model.ChatMessage) error {
+	const op = "broker.frontend.MessageDeleted"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.MessageDeletedRequest{Message: converter.ChatMessageToDTO(message)},
+		frontendv1.ChatEventType_E_MESSAGE_DELETED,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(message.Cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error {
+	const op = "broker.frontend.MessagesRead"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.MessagesReadRequest{Receipt: converter.ReadReceiptToDTO(receipt)},
+		frontendv1.ChatEventType_E_MESSAGES_READ,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(receipt.Cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error {
+	const op = "broker.frontend.ReactionUpdated"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.ReactionUpdatedRequest{Update: converter.ReactionUpdateToDTO(update)},
+		frontendv1.ChatEventType_E_REACTION_UPDATED,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(update.Cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) Typing(ctx context.Context, cid []byte, uid []byte) error {
+	const op = "broker.frontend.Typing"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.TypingEvent{Cid: cid, Uid: uid},
+		frontendv1.ChatEventType_E_TYPING,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) Presence(ctx context.Context, cid []byte, uid []byte, online bool) error {
+	const op = "broker.frontend.Presence"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.PresenceEvent{Cid: cid, Uid: uid, Online: online},
+		frontendv1.ChatEventType_E_PRESENCE,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chat(cid), event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ProfileUpdated publishes on the chats topic, one event reaching every frontend
+// instead of one per chat lets each recipient get it only once.
+func (p *Publisher) ProfileUpdated(ctx context.Context, cids [][]byte, user *model.User) error {
+	const op = "broker.frontend.ProfileUpdated"
+
+	event, err := proto.MarshalChatEvent(
+		&frontendv1.ProfileUpdatedEvent{Cids: cids, User: converter.UserToDTO(user)},
+		frontendv1.ChatEventType_E_PROFILE_UPDATED,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = p.broker.Publish(ctx, topic.Chats, event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -2,7 +2,10 @@ package auth
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"github.com/dvid-messanger/internal/adapter/secondary/client"
+	"github.com/dvid-messanger/internal/core/domain/model"
 	authv1 "github.com/dvid-messanger/protos/gen/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
@@ -14,21 +17,47 @@ import (
 )
 
 type Client struct {
-	api authv1.AuthServiceClient
-	log *slog.Logger
+	api      authv1.AuthServiceClient
+	internal authv1.AuthInternalServiceClient
+	log      *slog.Logger
 }
 
 func New(
 	ctx context.Context,
 	log *slog.Logger,
 	addr string,
+	internalAddr string,
 	timeout time.Duration,
 	retriesCount int,
 ) (*Client, error) {
 	const op = "client.auth.New"
 
+	cc, err := dial(ctx, log, addr, timeout, retriesCount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	internalCc, err := dial(ctx, log, internalAddr, timeout, retriesCount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		api:      authv1.NewAuthServiceClient(cc),
+		internal: authv1.NewAuthInternalServiceClient(internalCc),
+		log:      log,
+	}, nil
+}
+
+func dial(
+	ctx context.Context,
+	log *slog.Logger,
+	addr string,
+	timeout time.Duration,
+	retriesCount int,
+) (*grpc.ClientConn, error) {
 	retryOpts := []retry.CallOption{
-		retry.WithCodes(codes.NotFound, codes.Aborted, codes.DeadlineExceeded),
+		retry.WithCodes(codes.Unavailable, codes.Aborted, codes.DeadlineExceeded),
 		retry.WithMax(uint(retriesCount)),
 		retry.WithPerRetryTimeout(timeout),
 	}
@@ -37,21 +66,13 @@ func New(
 		logging.WithLogOnEvents(logging.PayloadReceived, logging.PayloadSent),
 	}
 
-	cc, err := grpc.DialContext(ctx, addr,
+	return grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithChainUnaryInterceptor(
 			logging.UnaryClientInterceptor(InterceptorLogger(log), logOpts...),
 			retry.UnaryClientInterceptor(retryOpts...),
 		),
 	)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
-	return &Client{
-		api: authv1.NewAuthServiceClient(cc),
-		log: log,
-	}, nil
 }
 
 func InterceptorLogger(l *slog.Logger) logging.Logger {
@@ -61,23 +82,87 @@ func InterceptorLogger(l *slog.Logger) logging.Logger {
 }
 
 func (c *Client) Create(ctx context.Context, uid []byte, email string, pass string) ([]byte, error) {
-	const op = "client.auth.Register"
+	const op = "client.auth.Create"
 
 	resp, err := c.api.Create(ctx, &authv1.CreateRequest{Uid: uid, Email: email, Password: pass})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
 	}
 
 	return resp.GetUid(), nil
 }
 
-func (c *Client) Login(ctx context.Context, email string, pass string) (string, error) {
+func (c *Client) Delete(ctx context.Context, uid []byte) error {
+	const op = "client.auth.Delete"
+
+	if _, err := c.internal.Delete(ctx, &authv1.DeleteRequest{Uid: uid}); err != nil {
+		return fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return nil
+}
+
+func (c *Client) Login(ctx context.Context, email string, pass string) (*model.TokenPair, error) {
 	const op = "client.auth.Login"
 
 	resp, err := c.api.Login(ctx, &authv1.LoginRequest{Email: email, Password: pass})
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return &model.TokenPair{Access: resp.GetToken(), Refresh: resp.GetRefreshToken()}, nil
+}
+
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	const op = "client.auth.Refresh"
+
+	resp, err := c.api.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return &model.TokenPair{Access: resp.GetToken(), Refresh: resp.GetRefreshToken()}, nil
+}
+
+func (c *Client) Logout(ctx context.Context, accessToken string, refreshToken string) error {
+	const op = "client.auth.Logout"
+
+	_, err := c.api.Logout(ctx, &authv1.LogoutRequest{Token: accessToken, RefreshToken: refreshToken})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return nil
+}
+
+func (c *Client) Revoked(ctx context.Context, jti string) (bool, error) {
+	const op = "client.auth.Revoked"
+
+	resp, err := c.api.Revoked(ctx, &authv1.RevokedRequest{Jti: jti})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return resp.GetRevoked(), nil
+}
+
+func (c *Client) PublicKeys(ctx context.Context) ([]model.PublicKey, error) {
+	const op = "client.auth.PublicKeys"
+
+	resp, err := c.api.PublicKeys(ctx, &authv1.PublicKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	keys := make([]model.PublicKey, 0, len(resp.GetKeys()))
+	for _, key := range resp.GetKeys() {
+		public, err := x509.ParsePKIXPublicKey(key.GetDer())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		keys = append(keys, model.PublicKey{Id: key.GetKid(), Alg: key.GetAlg(), Key: public})
 	}
 
-	return resp.GetToken(), nil
+	return keys, nil
 }
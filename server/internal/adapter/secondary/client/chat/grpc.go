@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/adapter/secondary/client"
 	"github.com/dvid-messanger/internal/core/domain/converter"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	chatv1 "github.com/dvid-messanger/protos/gen/chat"
@@ -12,7 +14,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/status"
 	"log/slog"
 	"time"
 )
@@ -34,7 +35,7 @@ func New(
 	const op = "client.chat.New"
 
 	retryOpts := []retry.CallOption{
-		retry.WithCodes(codes.NotFound, codes.Aborted, codes.DeadlineExceeded),
+		retry.WithCodes(codes.Unavailable, codes.Aborted, codes.DeadlineExceeded),
 		retry.WithMax(uint(retriesCount)),
 		retry.WithPerRetryTimeout(timeout),
 	}
@@ -71,18 +72,62 @@ func (c *Client) Create(ctx context.Context, from []byte, to []byte) (*model.Cha
 
 	resp, err := c.api.Create(ctx, &chatv1.CreateChatRequest{FromUid: from, ToUid: to})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatFromDTO(resp.GetChat()), nil
+}
+
+func (c *Client) CreateGroup(ctx context.Context, owner []byte, title string, members [][]byte) (*model.Chat, error) {
+	const op = "client.chat.CreateGroup"
+
+	resp, err := c.api.CreateGroup(ctx, &chatv1.CreateGroupChatRequest{OwnerUid: owner, Title: title, MemberUids: members})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatFromDTO(resp.GetChat()), nil
+}
+
+func (c *Client) AddMember(ctx context.Context, cid []byte, uid []byte, member []byte) (*model.Chat, error) {
+	const op = "client.chat.AddMember"
+
+	resp, err := c.api.AddMember(ctx, &chatv1.AddMemberRequest{Cid: cid, Uid: uid, MemberUid: member})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
 	}
 
 	return converter.ChatFromDTO(resp.GetChat()), nil
 }
 
-func (c *Client) Chat(ctx context.Context, cid []byte) (*model.Chat, error) {
+func (c *Client) RemoveMember(ctx context.Context, cid []byte, uid []byte, member []byte) (*model.Chat, error) {
+	const op = "client.chat.RemoveMember"
+
+	resp, err := c.api.RemoveMember(ctx, &chatv1.RemoveMemberRequest{Cid: cid, Uid: uid, MemberUid: member})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatFromDTO(resp.GetChat()), nil
+}
+
+func (c *Client) Leave(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error) {
+	const op = "client.chat.Leave"
+
+	resp, err := c.api.Leave(ctx, &chatv1.LeaveRequest{Cid: cid, Uid: uid})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatFromDTO(resp.GetChat()), nil
+}
+
+func (c *Client) Chat(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error) {
 	const op = "client.chat.Chat"
 
-	resp, err := c.api.Chat(ctx, &chatv1.ChatRequest{Cid: cid})
+	resp, err := c.api.Chat(ctx, &chatv1.ChatRequest{Cid: cid, Uid: uid})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
 	}
 
 	return converter.ChatFromDTO(resp.GetChat()), nil
@@ -93,8 +138,8 @@ func (c *Client) UserChats(ctx context.Context, uid []byte) ([]model.Chat, error
 
 	resp, err := c.api.UserChats(ctx, &chatv1.UserChatsRequest{Uid: uid})
 	if err != nil {
-		errStatus, ok := status.FromError(err)
-		if ok && errStatus.Code() == codes.NotFound {
+		err = client.Error(err)
+		if errors.Is(err, primary.ErrNotFound) {
 			return make([]model.Chat, 0), nil
 		}
 
@@ -104,24 +149,184 @@ func (c *Client) UserChats(ctx context.Context, uid []byte) ([]model.Chat, error
 	return converter.ChatsFromDTO(resp.GetChats()), nil
 }
 
-func (c *Client) SendMessage(ctx context.Context, cid []byte, uid []byte, text string) (*model.ChatMessage, error) {
-	const op = "client.chat.SendMessage"
+func (c *Client) ChatStates(ctx context.Context, uid []byte) ([]model.ChatState, error) {
+	const op = "client.chat.ChatStates"
 
-	resp, err := c.api.SendMessage(ctx, &chatv1.SendMessageRequest{Cid: cid, Uid: uid, Text: text})
+	resp, err := c.api.ChatStates(ctx, &chatv1.ChatStatesRequest{Uid: uid})
 	if err != nil {
+		err = client.Error(err)
+		if errors.Is(err, primary.ErrNotFound) {
+			return make([]model.ChatState, 0), nil
+		}
+
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return converter.ChatMessageFromDTO(resp.GetMessage()), nil
+	return converter.ChatStatesFromDTO(resp.GetStates()), nil
 }
 
-func (c *Client) Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error) {
-	const op = "client.chat.Messages"
+func (c *Client) LastMessages(ctx context.Context, uid []byte) ([]model.ChatMessage, error) {
+	const op = "client.chat.LastMessages"
 
-	resp, err := c.api.Messages(ctx, &chatv1.MessagesRequest{Cid: cid})
+	resp, err := c.api.LastMessages(ctx, &chatv1.LastMessagesRequest{Uid: uid})
 	if err != nil {
+		err = client.Error(err)
+		if errors.Is(err, primary.ErrNotFound) {
+			return make([]model.ChatMessage, 0), nil
+		}
+
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return converter.ChatMessagesFromDTO(resp.GetMessages()), nil
 }
+
+func (c *Client) SendMessage(
+	ctx context.Context,
+	cid []byte,
+	uid []byte,
+	text string,
+	refs model.MessageRefs,
+) (*model.ChatMessage, error) {
+	const op = "client.chat.SendMessage"
+
+	req := &chatv1.SendMessageRequest{Cid: cid, Uid: uid, Text: text, ReplyToMid: refs.ReplyTo}
+	if refs.Forward != nil {
+		req.ForwardCid, req.ForwardMid = refs.Forward.Cid, refs.Forward.Mid
+	}
+
+	resp, err := c.api.SendMessage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatMessageFromDTO(resp.GetMessage()), nil
+}
+
+func (c *Client) EditMessage(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+	text string,
+) (*model.ChatMessage, error) {
+	const op = "client.chat.EditMessage"
+
+	resp, err := c.api.EditMessage(ctx, &chatv1.EditMessageRequest{Cid: cid, Mid: mid, Uid: uid, Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatMessageFromDTO(resp.GetMessage()), nil
+}
+
+func (c *Client) DeleteMessage(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ChatMessage, error) {
+	const op = "client.chat.DeleteMessage"
+
+	resp, err := c.api.DeleteMessage(ctx, &chatv1.DeleteMessageRequest{Cid: cid, Mid: mid, Uid: uid})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ChatMessageFromDTO(resp.GetMessage()), nil
+}
+
+func (c *Client) Messages(
+	ctx context.Context,
+	cid []byte,
+	uid []byte,
+	before []byte,
+	after []byte,
+	limit int,
+) (*model.MessagesPage, error) {
+	const op = "client.chat.Messages"
+
+	resp, err := c.api.Messages(ctx, &chatv1.MessagesRequest{
+		Cid:    cid,
+		Uid:    uid,
+		Before: before,
+		After:  after,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return &model.MessagesPage{
+		Messages:  converter.ChatMessagesFromDTO(resp.GetMessages()),
+		Next:      resp.GetNext(),
+		Reactions: converter.MessageReactionsFromDTO(resp.GetReactions()),
+	}, nil
+}
+
+func (c *Client) SearchMessages(
+	ctx context.Context,
+	uid []byte,
+	cid []byte,
+	query string,
+	after []byte,
+	limit int,
+) (*model.MessageHitsPage, error) {
+	const op = "client.chat.SearchMessages"
+
+	resp, err := c.api.SearchMessages(ctx, &chatv1.SearchMessagesRequest{
+		Uid:   uid,
+		Cid:   cid,
+		Query: query,
+		After: after,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return &model.MessageHitsPage{
+		Hits: converter.MessageHitsFromDTO(resp.GetHits()),
+		Next: resp.GetNext(),
+	}, nil
+}
+
+func (c *Client) MarkRead(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ReadReceipt, error) {
+	const op = "client.chat.MarkRead"
+
+	resp, err := c.api.MarkRead(ctx, &chatv1.MarkReadRequest{Cid: cid, Mid: mid, Uid: uid})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ReadReceiptFromDTO(resp.GetReceipt()), nil
+}
+
+func (c *Client) AddReaction(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+	emoji string,
+) (*model.ReactionUpdate, error) {
+	const op = "client.chat.AddReaction"
+
+	resp, err := c.api.AddReaction(ctx, &chatv1.ReactionRequest{Cid: cid, Mid: mid, Uid: uid, Emoji: emoji})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ReactionUpdateFromDTO(resp.GetUpdate()), nil
+}
+
+func (c *Client) RemoveReaction(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+	emoji string,
+) (*model.ReactionUpdate, error) {
+	const op = "client.chat.RemoveReaction"
+
+	resp, err := c.api.RemoveReaction(ctx, &chatv1.ReactionRequest{Cid: cid, Mid: mid, Uid: uid, Emoji: emoji})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.ReactionUpdateFromDTO(resp.GetUpdate()), nil
+}
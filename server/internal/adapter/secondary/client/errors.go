@@ -0,0 +1,39 @@
+package client
+
+import (
+	"github.com/dvid-messanger/internal/adapter/primary"
+	grpcutil "github.com/dvid-messanger/internal/pkg/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var kinds = map[codes.Code]error{
+	codes.InvalidArgument:    primary.ErrInvalidArgument,
+	codes.NotFound:           primary.ErrNotFound,
+	codes.AlreadyExists:      primary.ErrAlreadyExists,
+	codes.PermissionDenied:   primary.ErrPermissionDenied,
+	codes.FailedPrecondition: primary.ErrFailedPrecondition,
+	codes.Unauthenticated:    primary.ErrUnauthenticated,
+	codes.ResourceExhausted:  primary.ErrThrottled,
+}
+
+// Error translates a domain status error into a *primary.Error, any other
+// error is returned as is.
+func Error(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	kind, ok := kinds[st.Code()]
+	if !ok {
+		return err
+	}
+
+	return &primary.Error{
+		Kind:       kind,
+		Reason:     grpcutil.Reason(st),
+		Desc:       st.Message(),
+		RetryAfter: grpcutil.RetryAfter(st),
+	}
+}
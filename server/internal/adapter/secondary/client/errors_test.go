@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	grpcutil "github.com/dvid-messanger/internal/pkg/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Domain", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("op: %w", Error(grpcutil.Error(codes.PermissionDenied, grpcutil.ReasonNotChatMember, "not a chat member")))
+		assert.ErrorIs(t, err, primary.ErrPermissionDenied)
+
+		var domainErr *primary.Error
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, grpcutil.ReasonNotChatMember, domainErr.Reason)
+		assert.Equal(t, "not a chat member", domainErr.Desc)
+	})
+	t.Run("Throttled", func(t *testing.T) {
+		t.Parallel()
+
+		err := Error(grpcutil.ThrottledError(time.Second, "rate limited"))
+		assert.ErrorIs(t, err, primary.ErrThrottled)
+
+		var domainErr *primary.Error
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, time.Second, domainErr.RetryAfter)
+	})
+	t.Run("FieldError", func(t *testing.T) {
+		t.Parallel()
+
+		err := Error(grpcutil.FieldError("cid", "is bad"))
+		assert.ErrorIs(t, err, primary.ErrInvalidArgument)
+	})
+	t.Run("Other", func(t *testing.T) {
+		t.Parallel()
+
+		internal := status.Error(codes.Internal, "internal error")
+		assert.Equal(t, internal, Error(internal))
+
+		plain := errors.New("plain")
+		assert.Equal(t, plain, Error(plain))
+	})
+}
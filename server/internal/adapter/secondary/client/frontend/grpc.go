@@ -30,7 +30,7 @@ func New(
 	const op = "client.frontend.New"
 
 	retryOpts := []retry.CallOption{
-		retry.WithCodes(codes.NotFound, codes.Aborted, codes.DeadlineExceeded),
+		retry.WithCodes(codes.Unavailable, codes.Aborted, codes.DeadlineExceeded),
 		retry.WithMax(uint(retriesCount)),
 		retry.WithPerRetryTimeout(timeout),
 	}
@@ -74,7 +74,7 @@ func (c *Client) NewMessage(ctx context.Context, message *model.ChatMessage) err
 }
 
 func (c *Client) NewChat(ctx context.Context, chat *model.Chat) error {
-	const op = "client.frontend.NewMessage"
+	const op = "client.frontend.NewChat"
 
 	_, err := c.api.NewChat(ctx, &frontendv1.NewChatRequest{Chat: converter.ChatToDTO(chat)})
 	if err != nil {
@@ -83,3 +83,58 @@ func (c *Client) NewChat(ctx context.Context, chat *model.Chat) error {
 
 	return nil
 }
+
+func (c *Client) ChatUpdated(ctx context.Context, chat *model.Chat) error {
+	const op = "client.frontend.ChatUpdated"
+
+	_, err := c.api.ChatUpdated(ctx, &frontendv1.ChatUpdatedRequest{Chat: converter.ChatToDTO(chat)})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) MessageEdited(ctx context.Context, message *model.ChatMessage) error {
+	const op = "client.frontend.MessageEdited"
+
+	_, err := c.api.MessageEdited(ctx, &frontendv1.MessageEditedRequest{Message: converter.ChatMessageToDTO(message)})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) MessageDeleted(ctx context.Context, message *model.ChatMessage) error {
+	const op = "client.frontend.MessageDeleted"
+
+	_, err := c.api.MessageDeleted(ctx, &frontendv1.MessageDeletedRequest{Message: converter.ChatMessageToDTO(message)})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error {
+	const op = "client.frontend.MessagesRead"
+
+	_, err := c.api.MessagesRead(ctx, &frontendv1.MessagesReadRequest{Receipt: converter.ReadReceiptToDTO(receipt)})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error {
+	const op = "client.frontend.ReactionUpdated"
+
+	_, err := c.api.ReactionUpdated(ctx, &frontendv1.ReactionUpdatedRequest{Update: converter.ReactionUpdateToDTO(update)})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
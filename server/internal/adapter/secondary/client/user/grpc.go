@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"fmt"
+	"github.com/dvid-messanger/internal/adapter/secondary/client"
 	"github.com/dvid-messanger/internal/core/domain/converter"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	userv1 "github.com/dvid-messanger/protos/gen/user"
@@ -15,22 +16,51 @@ import (
 	"time"
 )
 
+// usersByIdsBatch is the most uids the user service takes in one call.
+const usersByIdsBatch = 500
+
 type Client struct {
-	api userv1.UserServiceClient
-	log *slog.Logger
+	api      userv1.UserServiceClient
+	internal userv1.UserInternalServiceClient
+	log      *slog.Logger
 }
 
 func New(
 	ctx context.Context,
 	log *slog.Logger,
 	addr string,
+	internalAddr string,
 	timeout time.Duration,
 	retriesCount int,
 ) (*Client, error) {
 	const op = "client.user.New"
 
+	cc, err := dial(ctx, log, addr, timeout, retriesCount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	internalCc, err := dial(ctx, log, internalAddr, timeout, retriesCount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		api:      userv1.NewUserServiceClient(cc),
+		internal: userv1.NewUserInternalServiceClient(internalCc),
+		log:      log,
+	}, nil
+}
+
+func dial(
+	ctx context.Context,
+	log *slog.Logger,
+	addr string,
+	timeout time.Duration,
+	retriesCount int,
+) (*grpc.ClientConn, error) {
 	retryOpts := []retry.CallOption{
-		retry.WithCodes(codes.NotFound, codes.Aborted, codes.DeadlineExceeded),
+		retry.WithCodes(codes.Unavailable, codes.Aborted, codes.DeadlineExceeded),
 		retry.WithMax(uint(retriesCount)),
 		retry.WithPerRetryTimeout(timeout),
 	}
@@ -39,21 +69,13 @@ func New(
 		logging.WithLogOnEvents(logging.PayloadReceived, logging.PayloadSent),
 	}
 
-	cc, err := grpc.DialContext(ctx, addr,
+	return grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithChainUnaryInterceptor(
 			logging.UnaryClientInterceptor(InterceptorLogger(log), logOpts...),
 			retry.UnaryClientInterceptor(retryOpts...),
 		),
 	)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
-	return &Client{
-		api: userv1.NewUserServiceClient(cc),
-		log: log,
-	}, nil
 }
 
 func InterceptorLogger(l *slog.Logger) logging.Logger {
@@ -67,7 +89,7 @@ func (c *Client) Create(ctx context.Context, email string, bio string) (*model.U
 
 	resp, err := c.api.Create(ctx, &userv1.CreateRequest{Email: email, Bio: bio})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
 	}
 
 	return converter.UserFromDTO(resp.GetUser()), nil
@@ -78,7 +100,7 @@ func (c *Client) User(ctx context.Context, uid []byte) (*model.User, error) {
 
 	resp, err := c.api.User(ctx, &userv1.UserRequest{Uid: uid})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
 	}
 
 	return converter.UserFromDTO(resp.GetUser()), nil
@@ -89,8 +111,72 @@ func (c *Client) Users(ctx context.Context) ([]model.User, error) {
 
 	resp, err := c.api.Users(ctx, &userv1.UsersRequest{})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
 	}
 
 	return converter.UsersFromDTO(resp.GetUsers()), nil
 }
+
+func (c *Client) UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error) {
+	const op = "client.user.UsersByIds"
+
+	users := make([]model.User, 0, len(uids))
+	for start := 0; start < len(uids); start += usersByIdsBatch {
+		batch := uids[start:min(start+usersByIdsBatch, len(uids))]
+		resp, err := c.api.UsersByIds(ctx, &userv1.UsersByIdsRequest{Uids: batch})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+		}
+		users = append(users, converter.UsersFromDTO(resp.GetUsers())...)
+	}
+
+	return users, nil
+}
+
+func (c *Client) Search(
+	ctx context.Context,
+	query string,
+	prefix bool,
+	after []byte,
+	limit int,
+) (*model.UsersPage, error) {
+	const op = "client.user.Search"
+
+	resp, err := c.api.SearchUsers(ctx, &userv1.SearchUsersRequest{
+		Query:  query,
+		Prefix: prefix,
+		After:  after,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return &model.UsersPage{Users: converter.UsersFromDTO(resp.GetUsers()), Next: resp.GetNext()}, nil
+}
+
+func (c *Client) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error) {
+	const op = "client.user.Update"
+
+	resp, err := c.api.Update(ctx, &userv1.UpdateRequest{
+		Uid:         uid,
+		DisplayName: upd.DisplayName,
+		Avatar:      upd.Avatar,
+		Bio:         upd.Bio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return converter.UserFromDTO(resp.GetUser()), nil
+}
+
+func (c *Client) Delete(ctx context.Context, uid []byte) error {
+	const op = "client.user.Delete"
+
+	if _, err := c.internal.Delete(ctx, &userv1.DeleteRequest{Uid: uid}); err != nil {
+		return fmt.Errorf("%s: %w", op, client.Error(err))
+	}
+
+	return nil
+}
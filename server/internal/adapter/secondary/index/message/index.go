@@ -0,0 +1,171 @@
+package message
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/chat"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// snippetRadius is how many runes of context surround the first match.
+const snippetRadius = 32
+
+const cursorLen = 8 + 16 + 16
+
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize splits text into lower cased words of letters and digits, Start
+// and End are byte offsets of the word in text.
+func Tokenize(text string) []Token {
+	tokens := make([]Token, 0)
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		}
+		if !word && start >= 0 {
+			tokens = append(tokens, Token{Term: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Term: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+
+	return tokens
+}
+
+// Terms returns the distinct words of text.
+func Terms(text string) []string {
+	seen := make(map[string]struct{})
+	terms := make([]string, 0)
+	for _, token := range Tokenize(text) {
+		if _, ok := seen[token.Term]; ok {
+			continue
+		}
+		seen[token.Term] = struct{}{}
+		terms = append(terms, token.Term)
+	}
+
+	return terms
+}
+
+// Snippet cuts text around the first word found in terms and highlights every
+// such word within the cut.
+func Snippet(text string, terms []string) (string, []model.Highlight) {
+	matched := make([]Token, 0)
+	for _, token := range Tokenize(text) {
+		for _, term := range terms {
+			if token.Term == term {
+				matched = append(matched, token)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return cut(text, 0, 0), nil
+	}
+
+	start, end := matched[0].Start, matched[0].End
+	for i := 0; i < snippetRadius && start > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	for i := 0; i < snippetRadius && end < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	snippet := cut(text, start, end)
+	shift := 0
+	if start > 0 {
+		shift = len("…")
+	}
+
+	highlights := make([]model.Highlight, 0, len(matched))
+	for _, token := range matched {
+		if token.End > end {
+			break
+		}
+		highlights = append(highlights, model.Highlight{
+			Start: token.Start - start + shift,
+			End:   token.End - start + shift,
+		})
+	}
+
+	return snippet, highlights
+}
+
+// cut returns text[start:end] marking the dropped parts with ellipses, an
+// empty range takes the beginning of text.
+func cut(text string, start int, end int) string {
+	if start == end {
+		end = 0
+		for i := 0; i < 2*snippetRadius && end < len(text); i++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+	}
+
+	snippet := text[start:end]
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+
+	return snippet
+}
+
+// Cursor is the position of a message in search results, newer messages go
+// first.
+type Cursor struct {
+	Timestamp int64
+	Cid       []byte
+	Mid       []byte
+}
+
+func CursorOf(m *model.ChatMessage) Cursor {
+	return Cursor{Timestamp: m.Timestamp, Cid: m.Cid, Mid: m.Id}
+}
+
+func (c Cursor) Compare(o Cursor) int {
+	if c.Timestamp != o.Timestamp {
+		return cmp.Compare(o.Timestamp, c.Timestamp)
+	}
+	if res := bytes.Compare(o.Cid, c.Cid); res != 0 {
+		return res
+	}
+
+	return bytes.Compare(o.Mid, c.Mid)
+}
+
+func (c Cursor) Encode() []byte {
+	buf := make([]byte, 8, cursorLen)
+	binary.BigEndian.PutUint64(buf, uint64(c.Timestamp))
+	buf = append(buf, c.Cid...)
+
+	return append(buf, c.Mid...)
+}
+
+func DecodeCursor(buf []byte) (Cursor, error) {
+	if len(buf) != cursorLen {
+		return Cursor{}, chat.ErrInvalidCursor
+	}
+
+	return Cursor{
+		Timestamp: int64(binary.BigEndian.Uint64(buf[:8])),
+		Cid:       buf[8:24],
+		Mid:       buf[24:],
+	}, nil
+}
@@ -0,0 +1,69 @@
+package message_test
+
+import (
+	"github.com/dvid-messanger/internal/adapter/secondary/index/message"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/chat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"hello", "world", "привет"}, message.Terms("Hello, world! hello ПРИВЕТ"))
+	assert.Empty(t, message.Terms(" ,.! "))
+}
+
+func TestSnippet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ShortText", func(t *testing.T) {
+		t.Parallel()
+
+		snippet, highlights := message.Snippet("Hello world, hello", []string{"hello"})
+		assert.Equal(t, "Hello world, hello", snippet)
+		assert.Equal(t, []model.Highlight{{Start: 0, End: 5}, {Start: 13, End: 18}}, highlights)
+	})
+	t.Run("LongText", func(t *testing.T) {
+		t.Parallel()
+
+		text := strings.Repeat("a ", 50) + "needle" + strings.Repeat(" b", 50)
+		snippet, highlights := message.Snippet(text, []string{"needle"})
+
+		require.Len(t, highlights, 1)
+		assert.True(t, strings.HasPrefix(snippet, "…"), "cut start marked")
+		assert.True(t, strings.HasSuffix(snippet, "…"), "cut end marked")
+		assert.Equal(t, "needle", snippet[highlights[0].Start:highlights[0].End])
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		t.Parallel()
+
+		snippet, highlights := message.Snippet("Hello world", []string{"bye"})
+		assert.Equal(t, "Hello world", snippet)
+		assert.Empty(t, highlights)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	cursor := message.Cursor{
+		Timestamp: 42,
+		Cid:       []byte("0123456789abcdef"),
+		Mid:       []byte("fedcba9876543210"),
+	}
+
+	decoded, err := message.DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	_, err = message.DecodeCursor([]byte("bad"))
+	assert.ErrorIs(t, err, chat.ErrInvalidCursor)
+
+	older := cursor
+	older.Timestamp = 41
+	assert.Negative(t, cursor.Compare(older), "newer message goes first")
+}
@@ -0,0 +1,241 @@
+package inmem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/secondary/index/message"
+	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"slices"
+	"sync"
+)
+
+type docKey [32]byte
+
+func newDocKey(cid []byte, mid []byte) docKey {
+	var key docKey
+	copy(key[:16], cid)
+	copy(key[16:], mid)
+
+	return key
+}
+
+// rebuildBatch is how many messages Rebuild loads at once.
+const rebuildBatch = 500
+
+// MessageProvider loads the messages the index keeps only the ids of.
+type MessageProvider interface {
+	ChatIds(ctx context.Context) ([][]byte, error)
+	Messages(ctx context.Context, cid []byte, before []byte, after []byte, limit int) (model.MessagesPage, error)
+	Message(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error)
+}
+
+type doc struct {
+	timestamp int64
+	terms     []string
+}
+
+// Index is an in-process inverted index of message words. It starts empty,
+// Rebuild fills it from storage. Only the messages saved through its own
+// process get indexed, so it serves a single chat service instance.
+type Index struct {
+	mp    MessageProvider
+	docs  map[docKey]doc
+	terms map[string]map[docKey]struct{}
+	rw    *sync.RWMutex
+}
+
+func New(mp MessageProvider) *Index {
+	return &Index{
+		mp:    mp,
+		docs:  make(map[docKey]doc),
+		terms: make(map[string]map[docKey]struct{}),
+		rw:    &sync.RWMutex{},
+	}
+}
+
+// Rebuild indexes every stored message, it returns how many were indexed.
+func (i *Index) Rebuild(ctx context.Context) (int, error) {
+	const op = "index.inmem.Rebuild"
+
+	cids, err := i.mp.ChatIds(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	indexed := 0
+	for _, cid := range cids {
+		var before []byte
+		for {
+			page, err := i.mp.Messages(ctx, cid, before, nil, rebuildBatch)
+			if err != nil {
+				return indexed, fmt.Errorf("%s: %w", op, err)
+			}
+			for _, m := range page.Messages {
+				if m.Deleted {
+					continue
+				}
+				if err = i.Index(ctx, &m); err != nil {
+					return indexed, fmt.Errorf("%s: %w", op, err)
+				}
+				indexed++
+			}
+			if len(page.Next) == 0 {
+				break
+			}
+			before = page.Next
+		}
+	}
+
+	return indexed, nil
+}
+
+// Index adds the message or replaces its previous text, deleted messages are
+// removed.
+func (i *Index) Index(ctx context.Context, m *model.ChatMessage) error {
+	i.rw.Lock()
+	defer i.rw.Unlock()
+
+	key := newDocKey(m.Cid, m.Id)
+	i.remove(key)
+	if m.Deleted {
+		return nil
+	}
+
+	terms := message.Terms(m.Text)
+	i.docs[key] = doc{timestamp: m.Timestamp, terms: terms}
+	for _, term := range terms {
+		docs, ok := i.terms[term]
+		if !ok {
+			docs = make(map[docKey]struct{})
+			i.terms[term] = docs
+		}
+		docs[key] = struct{}{}
+	}
+
+	return nil
+}
+
+func (i *Index) Remove(ctx context.Context, cid []byte, mid []byte) error {
+	i.rw.Lock()
+	defer i.rw.Unlock()
+
+	i.remove(newDocKey(cid, mid))
+
+	return nil
+}
+
+// Search returns messages of cids containing every word of query, only the
+// messages of the page are loaded.
+func (i *Index) Search(
+	ctx context.Context,
+	cids [][]byte,
+	query string,
+	after []byte,
+	limit int,
+) (model.MessageHitsPage, error) {
+	const op = "index.inmem.Search"
+
+	var cursor *message.Cursor
+	if len(after) != 0 {
+		c, err := message.DecodeCursor(after)
+		if err != nil {
+			return model.MessageHitsPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+		cursor = &c
+	}
+
+	terms := message.Terms(query)
+	if len(terms) == 0 {
+		return model.MessageHitsPage{Hits: []model.MessageHit{}}, nil
+	}
+
+	scope := make(map[string]struct{}, len(cids))
+	for _, cid := range cids {
+		scope[string(cid)] = struct{}{}
+	}
+
+	found := i.find(scope, terms, cursor)
+
+	page := model.MessageHitsPage{Hits: make([]model.MessageHit, 0, min(len(found), limit))}
+	for _, c := range found[:min(len(found), limit)] {
+		// a hit may be gone from storage by now, like a deleted one it is skipped
+		m, err := i.mp.Message(ctx, c.Cid, c.Mid)
+		if errors.Is(err, chat.ErrMessageNotFound) {
+			continue
+		}
+		if err != nil {
+			return model.MessageHitsPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if m.Deleted {
+			continue
+		}
+
+		snippet, highlights := message.Snippet(m.Text, terms)
+		page.Hits = append(page.Hits, model.MessageHit{Message: m, Snippet: snippet, Highlights: highlights})
+	}
+	if len(found) > limit {
+		page.Next = found[limit-1].Encode()
+	}
+
+	return page, nil
+}
+
+// find returns the positions of the messages in scope containing every term
+// past cursor, in search order.
+func (i *Index) find(scope map[string]struct{}, terms []string, cursor *message.Cursor) []message.Cursor {
+	i.rw.RLock()
+	defer i.rw.RUnlock()
+
+	postings := make([]map[docKey]struct{}, 0, len(terms))
+	for _, term := range terms {
+		postings = append(postings, i.terms[term])
+	}
+	slices.SortFunc(postings, func(a, b map[docKey]struct{}) int {
+		return len(a) - len(b)
+	})
+
+	found := make([]message.Cursor, 0)
+	for key := range postings[0] {
+		if _, ok := scope[string(key[:16])]; !ok {
+			continue
+		}
+		c := message.Cursor{Timestamp: i.docs[key].timestamp, Cid: key[:16], Mid: key[16:]}
+		if cursor != nil && cursor.Compare(c) >= 0 {
+			continue
+		}
+		if !containsAll(postings[1:], key) {
+			continue
+		}
+		found = append(found, c)
+	}
+	slices.SortFunc(found, message.Cursor.Compare)
+
+	return found
+}
+
+func (i *Index) remove(key docKey) {
+	d, ok := i.docs[key]
+	if !ok {
+		return
+	}
+
+	delete(i.docs, key)
+	for _, term := range d.terms {
+		delete(i.terms[term], key)
+		if len(i.terms[term]) == 0 {
+			delete(i.terms, term)
+		}
+	}
+}
+
+func containsAll(postings []map[docKey]struct{}, key docKey) bool {
+	for _, docs := range postings {
+		if _, ok := docs[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
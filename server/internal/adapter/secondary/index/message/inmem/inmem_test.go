@@ -0,0 +1,143 @@
+package inmem_test
+
+import (
+	"context"
+	"github.com/dvid-messanger/internal/adapter/secondary/index/message/inmem"
+	storage "github.com/dvid-messanger/internal/adapter/secondary/storage/chat/inmem"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/chat"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	messages := storage.NewMessageStorage()
+	index := inmem.New(messages)
+
+	chat1, chat2, chat3 := newId(), newId(), newId()
+	saved := []model.ChatMessage{
+		save(t, messages, chat1, "Lunch at noon?"),
+		save(t, messages, chat2, "lunch moved to one"),
+		save(t, messages, chat1, "no lunch today"),
+		save(t, messages, chat3, "lunch for strangers"),
+	}
+	for i, m := range saved {
+		// saves within the same millisecond would tie
+		m.Timestamp = int64(i + 1)
+		require.NoError(t, index.Index(context.Background(), &m), "index should not error")
+	}
+
+	page, err := index.Search(context.Background(), [][]byte{chat1, chat2}, "LUNCH", nil, 10)
+	require.NoError(t, err, "search should not error")
+	require.Len(t, page.Hits, 3, "other chats not searched")
+	assert.Equal(t, saved[2].Id, page.Hits[0].Message.Id, "newer messages first")
+	assert.Equal(t, "no lunch today", page.Hits[0].Message.Text, "message loaded")
+	assert.Equal(t, []model.Highlight{{Start: 3, End: 8}}, page.Hits[0].Highlights)
+
+	page, err = index.Search(context.Background(), [][]byte{chat1, chat2}, "lunch noon", nil, 10)
+	require.NoError(t, err, "search should not error")
+	require.Len(t, page.Hits, 1, "every word matched")
+	assert.Equal(t, saved[0].Id, page.Hits[0].Message.Id)
+
+	var found [][]byte
+	var after []byte
+	for {
+		page, err = index.Search(context.Background(), [][]byte{chat1, chat2}, "lunch", after, 1)
+		require.NoError(t, err, "search should not error")
+		for _, hit := range page.Hits {
+			found = append(found, hit.Message.Id)
+		}
+		if len(page.Next) == 0 {
+			break
+		}
+		after = page.Next
+	}
+	assert.Equal(t, [][]byte{saved[2].Id, saved[1].Id, saved[0].Id}, found, "pages cover all hits")
+
+	_, err = index.Search(context.Background(), [][]byte{chat1}, "lunch", []byte("bad"), 1)
+	assert.ErrorIs(t, err, chat.ErrInvalidCursor)
+}
+
+func TestIndex_Update(t *testing.T) {
+	t.Parallel()
+
+	messages := storage.NewMessageStorage()
+	index := inmem.New(messages)
+
+	m := save(t, messages, newId(), "see you tomorrow")
+	require.NoError(t, index.Index(context.Background(), &m), "index should not error")
+
+	m, err := messages.Update(context.Background(), m.Cid, m.Id, "see you today")
+	require.NoError(t, err)
+	require.NoError(t, index.Index(context.Background(), &m), "reindex should not error")
+
+	page, err := index.Search(context.Background(), [][]byte{m.Cid}, "tomorrow", nil, 10)
+	require.NoError(t, err, "search should not error")
+	assert.Empty(t, page.Hits, "old text not found")
+
+	page, err = index.Search(context.Background(), [][]byte{m.Cid}, "today", nil, 10)
+	require.NoError(t, err, "search should not error")
+	assert.Len(t, page.Hits, 1, "new text found")
+
+	require.NoError(t, index.Remove(context.Background(), m.Cid, m.Id), "remove should not error")
+
+	page, err = index.Search(context.Background(), [][]byte{m.Cid}, "today", nil, 10)
+	require.NoError(t, err, "search should not error")
+	assert.Empty(t, page.Hits, "removed message not found")
+}
+
+func TestIndex_SearchMissing(t *testing.T) {
+	t.Parallel()
+
+	messages := storage.NewMessageStorage()
+	index := inmem.New(messages)
+
+	stored := save(t, messages, newId(), "still here")
+	missing := model.ChatMessage{Id: newId(), Cid: stored.Cid, Text: "not here", Timestamp: stored.Timestamp + 1}
+	require.NoError(t, index.Index(context.Background(), &stored), "index should not error")
+	require.NoError(t, index.Index(context.Background(), &missing), "index should not error")
+
+	page, err := index.Search(context.Background(), [][]byte{stored.Cid}, "here", nil, 10)
+	require.NoError(t, err, "missing message should not fail search")
+	require.Len(t, page.Hits, 1, "missing message skipped")
+	assert.Equal(t, stored.Id, page.Hits[0].Message.Id)
+}
+
+func TestIndex_Rebuild(t *testing.T) {
+	t.Parallel()
+
+	messages := storage.NewMessageStorage()
+	cid := newId()
+	for range 3 {
+		save(t, messages, cid, "hello again")
+	}
+	deleted := save(t, messages, cid, "hello, deleted")
+	_, err := messages.Delete(context.Background(), cid, deleted.Id)
+	require.NoError(t, err)
+	save(t, messages, newId(), "hello elsewhere")
+
+	index := inmem.New(messages)
+	indexed, err := index.Rebuild(context.Background())
+	require.NoError(t, err, "rebuild should not error")
+	assert.Equal(t, 4, indexed, "deleted message skipped")
+
+	page, err := index.Search(context.Background(), [][]byte{cid}, "hello", nil, 10)
+	require.NoError(t, err, "search should not error")
+	assert.Len(t, page.Hits, 3, "stored messages found, deleted ones not")
+}
+
+func save(t *testing.T, messages *storage.MessageStorage, cid []byte, text string) model.ChatMessage {
+	m, err := messages.Save(context.Background(), cid, newId(), text, model.MessageRefs{})
+	require.NoError(t, err)
+
+	return m
+}
+
+func newId() []byte {
+	id := uuid.New()
+	return id[:]
+}
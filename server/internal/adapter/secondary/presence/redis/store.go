@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/id"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const keyPrefix = "presence."
+
+// disconnectScript drops the counter together with the last connection, so
+// a counter never stays at zero or below.
+var disconnectScript = redis.NewScript(`
+local n = redis.call("DECR", KEYS[1])
+if n <= 0 then
+	redis.call("DEL", KEYS[1])
+end
+return n
+`)
+
+// Store counts the frontend instances a user is connected to. Counters expire
+// after ttl unless refreshed, so a crashed instance can not keep users online
+// forever.
+type Store struct {
+	log    *slog.Logger
+	client *redis.Client
+	ttl    time.Duration
+
+	// users are the ones this instance has counted and keeps refreshing
+	users map[[16]byte]struct{}
+	mu    *sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func New(ctx context.Context, log *slog.Logger, addr string, ttl time.Duration) (*Store, error) {
+	const op = "presence.redis.New"
+
+	if ttl <= 0 {
+		return nil, fmt.Errorf("%s: presence ttl must be positive", op)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s := &Store{
+		log:    log,
+		client: client,
+		ttl:    ttl,
+		users:  make(map[[16]byte]struct{}),
+		mu:     &sync.Mutex{},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.refresh()
+
+	return s, nil
+}
+
+// Connect counts the instance for uid and tells whether it is the first one.
+func (s *Store) Connect(ctx context.Context, uid []byte) (bool, error) {
+	const op = "presence.redis.Connect"
+
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, key(uid))
+	pipe.PExpire(ctx, key(uid), s.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.Lock()
+	s.users[id.Id(uid)] = struct{}{}
+	s.mu.Unlock()
+
+	return incr.Val() == 1, nil
+}
+
+// Disconnect uncounts the instance for uid and tells whether it was the last
+// one.
+func (s *Store) Disconnect(ctx context.Context, uid []byte) (bool, error) {
+	const op = "presence.redis.Disconnect"
+
+	s.mu.Lock()
+	delete(s.users, id.Id(uid))
+	s.mu.Unlock()
+
+	n, err := disconnectScript.Run(ctx, s.client, []string{key(uid)}).Int64()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n <= 0, nil
+}
+
+func (s *Store) Close() error {
+	const op = "presence.redis.Close"
+
+	close(s.stop)
+	<-s.done
+
+	if err := s.client.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Store) refresh() {
+	const op = "presence.redis.refresh"
+	log := s.log.With(slog.String("op", op))
+
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		keys := make([]string, 0, len(s.users))
+		for uid := range s.users {
+			keys = append(keys, key(uid[:]))
+		}
+		s.mu.Unlock()
+		if len(keys) == 0 {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.ttl/3)
+		pipe := s.client.Pipeline()
+		for _, k := range keys {
+			pipe.PExpire(ctx, k, s.ttl)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Error("failed to refresh presence counters", logger.Err(err))
+		}
+		cancel()
+	}
+}
+
+func key(uid []byte) string {
+	return keyPrefix + id.String(uid)
+}
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/net/context"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestAuthStorage_Save(t *testing.T) {
@@ -66,6 +67,50 @@ func TestAuthStorage_Save(t *testing.T) {
 	})
 }
 
+func TestAuthStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	storage := inmem.New()
+	creds := genCreds("test-mail", "test-password")
+
+	_, err := storage.Save(context.Background(), creds.Id, creds.Email, creds.PassHash)
+	require.NoError(t, err, "save should not error")
+
+	require.NoError(t, storage.Delete(context.Background(), creds.Id), "delete should not error")
+
+	_, err = storage.User(context.Background(), creds.Email)
+	assert.ErrorIs(t, err, auth.ErrUserNotFound, "deleted creds not found")
+
+	err = storage.Delete(context.Background(), creds.Id)
+	assert.ErrorIs(t, err, auth.ErrUserNotFound, "delete missing creds should error")
+
+	_, err = storage.Save(context.Background(), creds.Id, creds.Email, creds.PassHash)
+	assert.NoError(t, err, "email free after delete")
+}
+
+func TestAuthStorage_Revoke(t *testing.T) {
+	t.Parallel()
+
+	storage := inmem.New()
+	expiresAt := time.Now().Add(time.Hour)
+
+	revoked, err := storage.Revoked(context.Background(), "jti")
+	require.NoError(t, err)
+	assert.False(t, revoked, "not revoked yet")
+
+	ok, err := storage.Revoke(context.Background(), "jti", expiresAt)
+	require.NoError(t, err)
+	assert.True(t, ok, "first revoke succeeds")
+
+	ok, err = storage.Revoke(context.Background(), "jti", expiresAt)
+	require.NoError(t, err)
+	assert.False(t, ok, "second revoke reports already revoked")
+
+	revoked, err = storage.Revoked(context.Background(), "jti")
+	require.NoError(t, err)
+	assert.True(t, revoked, "revoked after revoke")
+}
+
 func genCreds(email string, pass string) *model.UserCredentials {
 	uid := [16]byte(uuid.New())
 	return &model.UserCredentials{
@@ -7,17 +7,20 @@ import (
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/auth"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	"sync"
+	"time"
 )
 
 type Storage struct {
-	hash map[string]model.UserCredentials
-	rw   *sync.RWMutex
+	hash    map[string]model.UserCredentials
+	revoked map[string]time.Time
+	rw      *sync.RWMutex
 }
 
 func New() *Storage {
 	return &Storage{
-		hash: make(map[string]model.UserCredentials),
-		rw:   &sync.RWMutex{},
+		hash:    make(map[string]model.UserCredentials),
+		revoked: make(map[string]time.Time),
+		rw:      &sync.RWMutex{},
 	}
 }
 
@@ -55,3 +58,46 @@ func (s *Storage) User(ctx context.Context, email string) (model.UserCredentials
 
 	return user, nil
 }
+
+func (s *Storage) Delete(ctx context.Context, uid []byte) error {
+	const op = "storage.inmem.Delete"
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	for email, userCred := range s.hash {
+		if bytes.Equal(userCred.Id, uid) {
+			delete(s.hash, email)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+}
+
+func (s *Storage) Revoke(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	now := time.Now()
+	for k, exp := range s.revoked {
+		if now.After(exp) {
+			delete(s.revoked, k)
+		}
+	}
+
+	if _, ok := s.revoked[jti]; ok {
+		return false, nil
+	}
+	s.revoked[jti] = expiresAt
+
+	return true, nil
+}
+
+func (s *Storage) Revoked(ctx context.Context, jti string) (bool, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	_, ok := s.revoked[jti]
+	return ok, nil
+}
@@ -11,13 +11,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"log/slog"
+	"time"
 )
 
 const (
-	nameDb              = "db_auth"
-	nameCredsCollection = "creds"
+	nameDb                = "db_auth"
+	nameCredsCollection   = "creds"
+	nameRevokedCollection = "revoked_tokens"
 )
 
+type revokedToken struct {
+	Jti       string    `bson:"_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
 type Storage struct {
 	log *slog.Logger
 	mongodb.MongoDatabase
@@ -82,6 +89,55 @@ func (s *Storage) User(ctx context.Context, email string) (model.UserCredentials
 	return creds, nil
 }
 
+func (s *Storage) Delete(ctx context.Context, uid []byte) error {
+	const op = "mongo.Delete"
+	log := s.log.With(slog.String("op", op))
+
+	res, err := s.credsCollection().DeleteOne(ctx, bson.D{{Key: "_id", Value: uid}})
+	if err != nil {
+		log.Error("failed to delete creds", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, auth.ErrInternal)
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("%s: %w", op, auth.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) Revoke(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	const op = "mongo.Revoke"
+	log := s.log.With(slog.String("op", op))
+
+	if _, err := s.revokedCollection().InsertOne(ctx, revokedToken{Jti: jti, ExpiresAt: expiresAt}); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+
+		log.Error("failed to revoke token", logger.Err(err))
+		return false, fmt.Errorf("%s: %w", op, auth.ErrInternal)
+	}
+
+	return true, nil
+}
+
+func (s *Storage) Revoked(ctx context.Context, jti string) (bool, error) {
+	const op = "mongo.Revoked"
+	log := s.log.With(slog.String("op", op))
+
+	count, err := s.revokedCollection().CountDocuments(ctx, bson.D{{Key: "_id", Value: jti}})
+	if err != nil {
+		log.Error("failed to check revocation", logger.Err(err))
+		return false, fmt.Errorf("%s: %w", op, auth.ErrInternal)
+	}
+
+	return count > 0, nil
+}
+
 func (s *Storage) credsCollection() *mongo.Collection {
 	return s.Client.Database(nameDb).Collection(nameCredsCollection)
 }
+
+func (s *Storage) revokedCollection() *mongo.Collection {
+	return s.Client.Database(nameDb).Collection(nameRevokedCollection)
+}
@@ -61,7 +61,7 @@ func (s *ChatStorage) Save(ctx context.Context, from []byte, to []byte) (model.C
 	s.rw.Lock()
 	defer s.rw.Unlock()
 	for _, c := range s.hash {
-		if containsUser(&c, from) && containsUser(&c, to) {
+		if c.Type == model.CTPersonal && containsUser(&c, from) && containsUser(&c, to) {
 			return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrChatExists)
 		}
 	}
@@ -77,6 +77,57 @@ func (s *ChatStorage) Save(ctx context.Context, from []byte, to []byte) (model.C
 	return c, nil
 }
 
+func (s *ChatStorage) SaveGroup(ctx context.Context, owner []byte, title string, members [][]byte) (model.Chat, error) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	cid := uuid.New()
+	c := *model.NewGroupChat(cid[:], title, owner, members...)
+	s.hash[cid] = c
+
+	return c, nil
+}
+
+func (s *ChatStorage) AddMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	op := "storage.inmem.AddMember"
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	c, ok := s.hash[[16]byte(cid)]
+	if !ok {
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrChatNotFound)
+	}
+	if containsUser(&c, uid) {
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrMemberExists)
+	}
+
+	c.Members = append(slices.Clip(c.Members), model.ChatMember{Uid: uid})
+	s.hash[[16]byte(cid)] = c
+
+	return c, nil
+}
+
+func (s *ChatStorage) RemoveMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	op := "storage.inmem.RemoveMember"
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	c, ok := s.hash[[16]byte(cid)]
+	if !ok {
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrChatNotFound)
+	}
+	if !containsUser(&c, uid) {
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrMemberNotFound)
+	}
+
+	c.Members = slices.DeleteFunc(slices.Clone(c.Members), func(m model.ChatMember) bool {
+		return bytes.Equal(m.Uid, uid)
+	})
+	s.hash[[16]byte(cid)] = c
+
+	return c, nil
+}
+
 func containsUser(c *model.Chat, uid []byte) bool {
 	return slices.ContainsFunc(c.Members, func(m model.ChatMember) bool {
 		return bytes.Equal(m.Uid, uid)
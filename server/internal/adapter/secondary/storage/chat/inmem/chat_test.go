@@ -107,3 +107,76 @@ func TestChatStorage_UserChats(t *testing.T) {
 	chats, err = storage.UserChats(context.Background(), uid1[:])
 	require.Len(t, chats, 2, "user chats len correct")
 }
+
+func TestChatStorage_SaveGroup(t *testing.T) {
+	storage := NewChatStorage()
+
+	owner := [16]byte(uuid.New())
+	member := [16]byte(uuid.New())
+
+	actualR, err := storage.SaveGroup(context.Background(), owner[:], "title", [][]byte{owner[:], member[:]})
+	require.NoError(t, err, "save group should not error")
+
+	assert.NotEmpty(t, actualR.Id, "returned id not empty")
+	assert.Equal(t, model.CTGroup, actualR.Type, "returned type match expected")
+	assert.Equal(t, "title", actualR.Title, "returned title match expected")
+	assert.ElementsMatch(t, owner, actualR.Owner, "returned owner match expected")
+	require.Len(t, actualR.Members, 2, "returned chat have two members")
+
+	_, err = storage.SaveGroup(context.Background(), owner[:], "title", [][]byte{owner[:], member[:]})
+	require.NoError(t, err, "save group with same members should not error")
+
+	_, err = storage.Save(context.Background(), owner[:], member[:])
+	require.NoError(t, err, "save personal chat with group members should not error")
+}
+
+func TestChatStorage_AddMember(t *testing.T) {
+	storage := NewChatStorage()
+
+	owner := [16]byte(uuid.New())
+	member := [16]byte(uuid.New())
+
+	saved, err := storage.SaveGroup(context.Background(), owner[:], "title", [][]byte{owner[:]})
+	require.NoError(t, err, "save group should not error")
+
+	actualR, err := storage.AddMember(context.Background(), saved.Id, member[:])
+	require.NoError(t, err, "add member should not error")
+	require.Len(t, actualR.Members, 2, "returned chat have two members")
+	assert.ElementsMatch(t, member, actualR.Members[1].Uid, "returned member id match expected")
+
+	actualS, err := storage.Chat(context.Background(), saved.Id)
+	require.NoError(t, err, "chat should not error")
+	assert.ElementsMatch(t, actualR.Members, actualS.Members, "saved members match returned")
+
+	_, err = storage.AddMember(context.Background(), saved.Id, member[:])
+	if assert.Error(t, err, "add existing member should error") {
+		assert.ErrorIs(t, err, chat.ErrMemberExists, "error type correct")
+	}
+
+	unknown := [16]byte(uuid.New())
+	_, err = storage.AddMember(context.Background(), unknown[:], member[:])
+	if assert.Error(t, err, "add member to unknown chat should error") {
+		assert.ErrorIs(t, err, chat.ErrChatNotFound, "error type correct")
+	}
+}
+
+func TestChatStorage_RemoveMember(t *testing.T) {
+	storage := NewChatStorage()
+
+	owner := [16]byte(uuid.New())
+	member := [16]byte(uuid.New())
+
+	saved, err := storage.SaveGroup(context.Background(), owner[:], "title", [][]byte{owner[:], member[:]})
+	require.NoError(t, err, "save group should not error")
+
+	actualR, err := storage.RemoveMember(context.Background(), saved.Id, member[:])
+	require.NoError(t, err, "remove member should not error")
+	require.Len(t, actualR.Members, 1, "returned chat have one member")
+	assert.ElementsMatch(t, owner, actualR.Members[0].Uid, "returned member id match expected")
+	assert.Len(t, saved.Members, 2, "previously returned chat is not modified")
+
+	_, err = storage.RemoveMember(context.Background(), saved.Id, member[:])
+	if assert.Error(t, err, "remove missing member should error") {
+		assert.ErrorIs(t, err, chat.ErrMemberNotFound, "error type correct")
+	}
+}
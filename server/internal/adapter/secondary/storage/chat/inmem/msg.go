@@ -1,12 +1,14 @@
 package inmem
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/pkg/cutil"
 	"github.com/google/uuid"
+	"slices"
 	"sync"
 	"time"
 )
@@ -23,28 +25,65 @@ func NewMessageStorage() *MessageStorage {
 	}
 }
 
-func (s *MessageStorage) Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error) {
+func (s *MessageStorage) Messages(
+	ctx context.Context,
+	cid []byte,
+	before []byte,
+	after []byte,
+	limit int,
+) (model.MessagesPage, error) {
 	op := "storage.inmem.Messages"
 
 	s.rw.RLock()
 	defer s.rw.RUnlock()
 	c, ok := s.hash[[16]byte(cid)]
 	if !ok {
-		return nil, fmt.Errorf("%s: %w", op, chat.ErrMessagesNotFound)
+		return model.MessagesPage{}, fmt.Errorf("%s: %w", op, chat.ErrMessagesNotFound)
 	}
 
-	return cutil.Copy(c), nil
+	if len(after) != 0 {
+		from, found := cursorPos(c, after)
+		if found {
+			from++
+		}
+
+		to := min(from+limit, len(c))
+		page := model.MessagesPage{Messages: cutil.Copy(c[from:to])}
+		if to < len(c) {
+			page.Next = c[to-1].Id
+		}
+		return page, nil
+	}
+
+	to := len(c)
+	if len(before) != 0 {
+		to, _ = cursorPos(c, before)
+	}
+
+	from := max(to-limit, 0)
+	page := model.MessagesPage{Messages: cutil.Copy(c[from:to])}
+	if from > 0 {
+		page.Next = c[from].Id
+	}
+	return page, nil
 }
 
-func (s *MessageStorage) Save(ctx context.Context, cid []byte, from []byte, text string) (model.ChatMessage, error) {
+func (s *MessageStorage) Save(
+	ctx context.Context,
+	cid []byte,
+	from []byte,
+	text string,
+	refs model.MessageRefs,
+) (model.ChatMessage, error) {
 	keyCid := [16]byte(cid)
-	mid := uuid.New()
+	mid := uuid.Must(uuid.NewV7())
 	cm := model.ChatMessage{
-		Id:        mid[:],
-		Cid:       cid,
-		Uid:       from,
-		Text:      text,
-		Timestamp: time.Now().UnixMilli(),
+		Id:          mid[:],
+		Cid:         cid,
+		Uid:         from,
+		Text:        text,
+		Timestamp:   time.Now().UnixMilli(),
+		MessageRefs: refs,
 	}
 
 	s.rw.Lock()
@@ -57,3 +96,94 @@ func (s *MessageStorage) Save(ctx context.Context, cid []byte, from []byte, text
 
 	return cm, nil
 }
+
+func (s *MessageStorage) Message(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	op := "storage.inmem.Message"
+
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	idx := cursorIndex(s.hash[[16]byte(cid)], mid)
+	if idx == -1 {
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrMessageNotFound)
+	}
+
+	return s.hash[[16]byte(cid)][idx], nil
+}
+
+// ChatIds returns the ids of the chats having messages.
+func (s *MessageStorage) ChatIds(ctx context.Context) ([][]byte, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	cids := make([][]byte, 0, len(s.hash))
+	for cid := range s.hash {
+		cids = append(cids, cid[:])
+	}
+
+	return cids, nil
+}
+
+func (s *MessageStorage) Update(ctx context.Context, cid []byte, mid []byte, text string) (model.ChatMessage, error) {
+	op := "storage.inmem.Update"
+
+	msg, err := s.update(cid, mid, func(msg *model.ChatMessage) error {
+		if msg.Deleted {
+			return chat.ErrMessageDeleted
+		}
+
+		msg.Text = text
+		msg.EditedAt = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msg, nil
+}
+
+func (s *MessageStorage) Delete(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	op := "storage.inmem.Delete"
+
+	msg, err := s.update(cid, mid, func(msg *model.ChatMessage) error {
+		msg.Text = ""
+		msg.Deleted = true
+		return nil
+	})
+	if err != nil {
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msg, nil
+}
+
+func (s *MessageStorage) update(cid []byte, mid []byte, fn func(msg *model.ChatMessage) error) (model.ChatMessage, error) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	messages := s.hash[[16]byte(cid)]
+	idx := cursorIndex(messages, mid)
+	if idx == -1 {
+		return model.ChatMessage{}, chat.ErrMessageNotFound
+	}
+
+	if err := fn(&messages[idx]); err != nil {
+		return model.ChatMessage{}, err
+	}
+
+	return messages[idx], nil
+}
+
+// cursorPos returns where mid is or would be in messages. Message ids are
+// uuid v7, so their byte order is the order messages were sent in, and a
+// cursor of a message which is not stored still splits the chat by time.
+func cursorPos(messages []model.ChatMessage, mid []byte) (int, bool) {
+	return slices.BinarySearchFunc(messages, mid, func(msg model.ChatMessage, mid []byte) int {
+		return bytes.Compare(msg.Id, mid)
+	})
+}
+
+func cursorIndex(messages []model.ChatMessage, mid []byte) int {
+	return slices.IndexFunc(messages, func(msg model.ChatMessage) bool {
+		return bytes.Equal(msg.Id, mid)
+	})
+}
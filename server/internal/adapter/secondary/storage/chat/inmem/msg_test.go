@@ -3,6 +3,7 @@ package inmem
 import (
 	"bytes"
 	"context"
+	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -22,7 +23,7 @@ func TestMessageStorage_Save(t *testing.T) {
 		text := "test-text" + strconv.Itoa(i)
 
 		for j := 1; j < 5; j++ {
-			actualR, err := storage.Save(context.Background(), cid[:], uid[:], text)
+			actualR, err := storage.Save(context.Background(), cid[:], uid[:], text, model.MessageRefs{})
 			require.NoError(t, err, "save should not error")
 
 			assert.NotEmpty(t, actualR.Id, "returned id not empty")
@@ -53,3 +54,128 @@ func TestMessageStorage_Save(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageStorage_Messages(t *testing.T) {
+	storage := NewMessageStorage()
+
+	cid := [16]byte(uuid.New())
+	uid := [16]byte(uuid.New())
+
+	saved := make([]model.ChatMessage, 0, 5)
+	for i := 0; i < 5; i++ {
+		msg, err := storage.Save(context.Background(), cid[:], uid[:], "test-text"+strconv.Itoa(i), model.MessageRefs{})
+		require.NoError(t, err, "save should not error")
+		saved = append(saved, msg)
+	}
+
+	page, err := storage.Messages(context.Background(), cid[:], nil, nil, 2)
+	require.NoError(t, err, "messages should not error")
+	assert.Equal(t, saved[3:], page.Messages, "latest page match expected")
+	assert.Equal(t, saved[3].Id, page.Next, "latest page next cursor match expected")
+
+	page, err = storage.Messages(context.Background(), cid[:], page.Next, nil, 2)
+	require.NoError(t, err, "messages should not error")
+	assert.Equal(t, saved[1:3], page.Messages, "before page match expected")
+	assert.Equal(t, saved[1].Id, page.Next, "before page next cursor match expected")
+
+	page, err = storage.Messages(context.Background(), cid[:], page.Next, nil, 2)
+	require.NoError(t, err, "messages should not error")
+	assert.Equal(t, saved[:1], page.Messages, "last before page match expected")
+	assert.Empty(t, page.Next, "last before page have no next cursor")
+
+	page, err = storage.Messages(context.Background(), cid[:], nil, saved[0].Id, 3)
+	require.NoError(t, err, "messages should not error")
+	assert.Equal(t, saved[1:4], page.Messages, "after page match expected")
+	assert.Equal(t, saved[3].Id, page.Next, "after page next cursor match expected")
+
+	page, err = storage.Messages(context.Background(), cid[:], nil, page.Next, 3)
+	require.NoError(t, err, "messages should not error")
+	assert.Equal(t, saved[4:], page.Messages, "last after page match expected")
+	assert.Empty(t, page.Next, "last after page have no next cursor")
+
+	newer := uuid.Must(uuid.NewV7())
+	page, err = storage.Messages(context.Background(), cid[:], newer[:], nil, 2)
+	require.NoError(t, err, "messages with unknown cursor should not error")
+	assert.Equal(t, saved[3:], page.Messages, "before unknown newer cursor page match expected")
+
+	page, err = storage.Messages(context.Background(), cid[:], nil, newer[:], 2)
+	require.NoError(t, err, "messages with unknown cursor should not error")
+	assert.Empty(t, page.Messages, "after unknown newer cursor page is empty")
+}
+
+func TestMessageStorage_MessagesUnknownCursor(t *testing.T) {
+	storage := NewMessageStorage()
+
+	cid := [16]byte(uuid.New())
+	uid := [16]byte(uuid.New())
+
+	older := uuid.Must(uuid.NewV7())
+	saved := make([]model.ChatMessage, 0, 3)
+	for i := 0; i < 3; i++ {
+		msg, err := storage.Save(context.Background(), cid[:], uid[:], "test-text"+strconv.Itoa(i), model.MessageRefs{})
+		require.NoError(t, err, "save should not error")
+		saved = append(saved, msg)
+	}
+
+	page, err := storage.Messages(context.Background(), cid[:], nil, older[:], 2)
+	require.NoError(t, err, "messages should not error")
+	assert.Equal(t, saved[:2], page.Messages, "after unknown older cursor page match expected")
+	assert.Equal(t, saved[1].Id, page.Next, "after unknown older cursor next cursor match expected")
+
+	page, err = storage.Messages(context.Background(), cid[:], older[:], nil, 2)
+	require.NoError(t, err, "messages should not error")
+	assert.Empty(t, page.Messages, "before unknown older cursor page is empty")
+}
+
+func TestMessageStorage_SaveRefs(t *testing.T) {
+	storage := NewMessageStorage()
+
+	cid := [16]byte(uuid.New())
+	uid := [16]byte(uuid.New())
+
+	replied, err := storage.Save(context.Background(), cid[:], uid[:], "test-text", model.MessageRefs{})
+	require.NoError(t, err, "save should not error")
+
+	refs := model.MessageRefs{
+		ReplyTo: replied.Id,
+		Forward: &model.ForwardedFrom{Cid: cid[:], Mid: replied.Id, Uid: uid[:]},
+	}
+	msg, err := storage.Save(context.Background(), cid[:], uid[:], "test-text", refs)
+	require.NoError(t, err, "save should not error")
+	assert.Equal(t, refs, msg.MessageRefs, "returned refs match expected")
+
+	found, err := storage.Message(context.Background(), cid[:], msg.Id)
+	require.NoError(t, err, "message should not error")
+	assert.Equal(t, refs, found.MessageRefs, "saved refs match expected")
+}
+
+func TestMessageStorage_UpdateDelete(t *testing.T) {
+	storage := NewMessageStorage()
+
+	cid := [16]byte(uuid.New())
+	uid := [16]byte(uuid.New())
+
+	msg, err := storage.Save(context.Background(), cid[:], uid[:], "test-text", model.MessageRefs{})
+	require.NoError(t, err, "save should not error")
+
+	edited, err := storage.Update(context.Background(), cid[:], msg.Id, "edited-text")
+	require.NoError(t, err, "update should not error")
+	assert.Equal(t, "edited-text", edited.Text, "text match expected")
+	assert.NotZero(t, edited.EditedAt, "edited at is set")
+
+	found, err := storage.Message(context.Background(), cid[:], msg.Id)
+	require.NoError(t, err, "message should not error")
+	assert.Equal(t, edited, found, "stored message match edited")
+
+	deleted, err := storage.Delete(context.Background(), cid[:], msg.Id)
+	require.NoError(t, err, "delete should not error")
+	assert.True(t, deleted.Deleted, "message is marked deleted")
+	assert.Empty(t, deleted.Text, "deleted message text is cleared")
+
+	_, err = storage.Update(context.Background(), cid[:], msg.Id, "restored-text")
+	assert.ErrorIs(t, err, chat.ErrMessageDeleted, "deleted message update should error")
+
+	unknown := [16]byte(uuid.New())
+	_, err = storage.Update(context.Background(), cid[:], unknown[:], "text")
+	assert.ErrorIs(t, err, chat.ErrMessageNotFound, "unknown message update should error")
+}
@@ -0,0 +1,105 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type reactionKey [32]byte
+
+func newReactionKey(cid []byte, mid []byte) reactionKey {
+	var key reactionKey
+	copy(key[:16], cid)
+	copy(key[16:], mid)
+
+	return key
+}
+
+// ReactionStorage keeps the users who reacted to a message by emoji.
+type ReactionStorage struct {
+	hash map[reactionKey]map[string]map[[16]byte]struct{}
+	rw   *sync.RWMutex
+}
+
+func NewReactionStorage() *ReactionStorage {
+	return &ReactionStorage{
+		hash: make(map[reactionKey]map[string]map[[16]byte]struct{}),
+		rw:   &sync.RWMutex{},
+	}
+}
+
+func (s *ReactionStorage) AddReaction(ctx context.Context, reaction *model.Reaction) (int64, error) {
+	const op = "storage.inmem.AddReaction"
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	key := newReactionKey(reaction.Cid, reaction.Mid)
+	emojis, ok := s.hash[key]
+	if !ok {
+		emojis = make(map[string]map[[16]byte]struct{})
+		s.hash[key] = emojis
+	}
+	uids, ok := emojis[reaction.Emoji]
+	if !ok {
+		uids = make(map[[16]byte]struct{})
+		emojis[reaction.Emoji] = uids
+	}
+	if _, ok = uids[[16]byte(reaction.Uid)]; ok {
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrReactionExists)
+	}
+
+	uids[[16]byte(reaction.Uid)] = struct{}{}
+
+	return int64(len(uids)), nil
+}
+
+func (s *ReactionStorage) RemoveReaction(ctx context.Context, reaction *model.Reaction) (int64, error) {
+	const op = "storage.inmem.RemoveReaction"
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	key := newReactionKey(reaction.Cid, reaction.Mid)
+	uids := s.hash[key][reaction.Emoji]
+	if _, ok := uids[[16]byte(reaction.Uid)]; !ok {
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrReactionNotFound)
+	}
+
+	delete(uids, [16]byte(reaction.Uid))
+	if len(uids) == 0 {
+		delete(s.hash[key], reaction.Emoji)
+	}
+	if len(s.hash[key]) == 0 {
+		delete(s.hash, key)
+	}
+
+	return int64(len(uids)), nil
+}
+
+func (s *ReactionStorage) Reactions(ctx context.Context, cid []byte, mids [][]byte) ([]model.MessageReactions, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	res := make([]model.MessageReactions, 0)
+	for _, mid := range mids {
+		emojis, ok := s.hash[newReactionKey(cid, mid)]
+		if !ok {
+			continue
+		}
+
+		counts := make([]model.ReactionCount, 0, len(emojis))
+		for emoji, uids := range emojis {
+			counts = append(counts, model.ReactionCount{Emoji: emoji, Count: int64(len(uids))})
+		}
+		slices.SortFunc(counts, func(a, b model.ReactionCount) int {
+			return strings.Compare(a.Emoji, b.Emoji)
+		})
+
+		res = append(res, model.MessageReactions{Mid: mid, Counts: counts})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,55 @@
+package inmem
+
+import (
+	"context"
+	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReactionStorage_AddRemove(t *testing.T) {
+	storage := NewReactionStorage()
+
+	cid := [16]byte(uuid.New())
+	mid1 := [16]byte(uuid.New())
+	mid2 := [16]byte(uuid.New())
+	mid3 := [16]byte(uuid.New())
+	uid1 := [16]byte(uuid.New())
+	uid2 := [16]byte(uuid.New())
+
+	reactions := []model.Reaction{
+		{Cid: cid[:], Mid: mid1[:], Uid: uid1[:], Emoji: "👍"},
+		{Cid: cid[:], Mid: mid1[:], Uid: uid2[:], Emoji: "👍"},
+		{Cid: cid[:], Mid: mid1[:], Uid: uid1[:], Emoji: "🎉"},
+		{Cid: cid[:], Mid: mid3[:], Uid: uid2[:], Emoji: "❤️"},
+	}
+	for i, count := range []int64{1, 2, 1, 1} {
+		actual, err := storage.AddReaction(context.Background(), &reactions[i])
+		require.NoError(t, err, "add should not error")
+		assert.Equal(t, count, actual, "count match expected")
+	}
+
+	_, err := storage.AddReaction(context.Background(), &reactions[0])
+	assert.ErrorIs(t, err, chat.ErrReactionExists, "same reaction twice should error")
+
+	res, err := storage.Reactions(context.Background(), cid[:], [][]byte{mid1[:], mid2[:], mid3[:]})
+	require.NoError(t, err, "reactions should not error")
+	assert.Equal(t, []model.MessageReactions{
+		{Mid: mid1[:], Counts: []model.ReactionCount{{Emoji: "🎉", Count: 1}, {Emoji: "👍", Count: 2}}},
+		{Mid: mid3[:], Counts: []model.ReactionCount{{Emoji: "❤️", Count: 1}}},
+	}, res, "messages without reactions left out")
+
+	count, err := storage.RemoveReaction(context.Background(), &reactions[3])
+	require.NoError(t, err, "remove should not error")
+	assert.Zero(t, count, "last reaction removed")
+
+	_, err = storage.RemoveReaction(context.Background(), &reactions[3])
+	assert.ErrorIs(t, err, chat.ErrReactionNotFound, "removed reaction should error")
+
+	res, err = storage.Reactions(context.Background(), cid[:], [][]byte{mid3[:]})
+	require.NoError(t, err, "reactions should not error")
+	assert.Empty(t, res, "no reactions left")
+}
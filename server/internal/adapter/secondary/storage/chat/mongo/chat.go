@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"log/slog"
 )
 
@@ -226,6 +227,226 @@ func (s *Storage) Save(ctx context.Context, from []byte, to []byte) (model.Chat,
 	return result.(model.Chat), nil
 }
 
+func (s *Storage) SaveGroup(ctx context.Context, owner []byte, title string, members [][]byte) (model.Chat, error) {
+	const op = "mongo.SaveGroup"
+	log := s.log.With(slog.String("op", op))
+
+	session, err := s.Client.StartSession()
+	if err != nil {
+		log.Error("failed to start session", logger.Err(err))
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	defer session.EndSession(ctx)
+
+	transactionFunc := func(sessionContext mongo.SessionContext) (any, error) {
+		userChats, err := s.UserChatsMany(sessionContext, members)
+		if err != nil {
+			return model.Chat{}, err
+		}
+
+		cid := uuid.New()
+		newChat := model.NewGroupChat(cid[:], title, owner, members...)
+
+		if _, err = s.chatsCollection().InsertOne(sessionContext, newChat); err != nil {
+			log.Error("failed to save chat", logger.Err(err))
+			return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		}
+		for _, uid := range members {
+			memberChats, err := s.getOrInsertUserChats(sessionContext, userChats, uid)
+			if err != nil {
+				return model.Chat{}, err
+			}
+			if err = s.pushUserChat(sessionContext, log, memberChats.Uid, modelutil.AddGroupChat(&memberChats, newChat.Id)); err != nil {
+				return model.Chat{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return *newChat, nil
+	}
+
+	result, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		log.Error("failed to execute transaction", logger.Err(err))
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return result.(model.Chat), nil
+}
+
+func (s *Storage) AddMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	const op = "mongo.AddMember"
+	log := s.log.With(slog.String("op", op))
+
+	session, err := s.Client.StartSession()
+	if err != nil {
+		log.Error("failed to start session", logger.Err(err))
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	defer session.EndSession(ctx)
+
+	transactionFunc := func(sessionContext mongo.SessionContext) (any, error) {
+		updated, err := s.updateChat(
+			sessionContext,
+			bson.M{"_id": cid, "members.uid": bson.M{"$ne": uid}},
+			bson.M{"$push": bson.M{"members": model.ChatMember{Uid: uid}}},
+		)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return model.Chat{}, fmt.Errorf("%s: %w", op, s.missingChatOrMember(sessionContext, cid, chat.ErrMemberExists))
+			}
+
+			log.Error("failed to add chat member", logger.Err(err))
+			return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		}
+
+		userChats, err := s.UserChatsMany(sessionContext, [][]byte{uid})
+		if err != nil {
+			return model.Chat{}, err
+		}
+		memberChats, err := s.getOrInsertUserChats(sessionContext, userChats, uid)
+		if err != nil {
+			return model.Chat{}, err
+		}
+		if err = s.pushUserChat(sessionContext, log, memberChats.Uid, modelutil.AddGroupChat(&memberChats, cid)); err != nil {
+			return model.Chat{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return updated, nil
+	}
+
+	result, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		if errors.Is(err, chat.ErrChatNotFound) || errors.Is(err, chat.ErrMemberExists) {
+			return model.Chat{}, err
+		}
+		log.Error("failed to execute transaction", logger.Err(err))
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return result.(model.Chat), nil
+}
+
+func (s *Storage) RemoveMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	const op = "mongo.RemoveMember"
+	log := s.log.With(slog.String("op", op))
+
+	session, err := s.Client.StartSession()
+	if err != nil {
+		log.Error("failed to start session", logger.Err(err))
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	defer session.EndSession(ctx)
+
+	transactionFunc := func(sessionContext mongo.SessionContext) (any, error) {
+		updated, err := s.updateChat(
+			sessionContext,
+			bson.M{"_id": cid, "members.uid": uid},
+			bson.M{"$pull": bson.M{"members": bson.M{"uid": uid}}},
+		)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return model.Chat{}, fmt.Errorf("%s: %w", op, s.missingChatOrMember(sessionContext, cid, chat.ErrMemberNotFound))
+			}
+
+			log.Error("failed to remove chat member", logger.Err(err))
+			return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		}
+
+		if _, err = s.userChatsCollection().UpdateByID(
+			sessionContext,
+			uid,
+			bson.M{"$pull": bson.M{"chats": bson.M{"cid": cid}}},
+		); err != nil {
+			log.Error("failed to update user chats", logger.Err(err))
+			return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		}
+
+		return updated, nil
+	}
+
+	result, err := session.WithTransaction(ctx, transactionFunc)
+	if err != nil {
+		if errors.Is(err, chat.ErrChatNotFound) || errors.Is(err, chat.ErrMemberNotFound) {
+			return model.Chat{}, err
+		}
+		log.Error("failed to execute transaction", logger.Err(err))
+		return model.Chat{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return result.(model.Chat), nil
+}
+
+func (s *Storage) MarkRead(
+	ctx context.Context,
+	uid []byte,
+	cid []byte,
+	mid []byte,
+	timestamp int64,
+	unread int64,
+) (bool, error) {
+	const op = "mongo.MarkRead"
+	log := s.log.With(slog.String("op", op))
+
+	res, err := s.userChatsCollection().UpdateOne(
+		ctx,
+		bson.M{
+			"_id": uid,
+			"chats": bson.M{"$elemMatch": bson.M{
+				"cid":          cid,
+				"last_read":    bson.M{"$ne": mid},
+				"last_read_at": bson.M{"$not": bson.M{"$gt": timestamp}},
+			}},
+		},
+		bson.M{"$set": bson.M{"chats.$.last_read": mid, "chats.$.last_read_at": timestamp, "chats.$.unread": unread}},
+	)
+	if err != nil {
+		log.Error("failed to update last read message", logger.Err(err))
+		return false, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (s *Storage) IncUnread(ctx context.Context, cid []byte, uids [][]byte) error {
+	const op = "mongo.IncUnread"
+	log := s.log.With(slog.String("op", op))
+
+	if _, err := s.userChatsCollection().UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": uids}, "chats.cid": cid},
+		bson.M{"$inc": bson.M{"chats.$[c].unread": 1}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: []any{bson.M{"c.cid": cid}}}),
+	); err != nil {
+		log.Error("failed to increment unread counters", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return nil
+}
+
+// DecUnread takes back a deleted message from the counters of the users that
+// have not read up to its timestamp yet.
+func (s *Storage) DecUnread(ctx context.Context, cid []byte, uids [][]byte, timestamp int64) error {
+	const op = "mongo.DecUnread"
+	log := s.log.With(slog.String("op", op))
+
+	if _, err := s.userChatsCollection().UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": uids}, "chats.cid": cid},
+		bson.M{"$inc": bson.M{"chats.$[c].unread": -1}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: []any{bson.M{
+			"c.cid":          cid,
+			"c.unread":       bson.M{"$gt": 0},
+			"c.last_read_at": bson.M{"$not": bson.M{"$gte": timestamp}},
+		}}}),
+	); err != nil {
+		log.Error("failed to decrement unread counters", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return nil
+}
+
 func (s *Storage) chatsCollection() *mongo.Collection {
 	return s.Client.Database(nameDb).Collection(nameChatsCollection)
 }
@@ -252,3 +473,47 @@ func (s *Storage) getOrInsertUserChats(
 
 	return res, nil
 }
+
+func (s *Storage) updateChat(ctx context.Context, filter any, update any) (model.Chat, error) {
+	res := s.chatsCollection().FindOneAndUpdate(
+		ctx,
+		filter,
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	if res.Err() != nil {
+		return model.Chat{}, res.Err()
+	}
+
+	var updated model.Chat
+	if err := res.Decode(&updated); err != nil {
+		return model.Chat{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *Storage) missingChatOrMember(ctx context.Context, cid []byte, memberErr error) error {
+	if _, err := s.Chat(ctx, cid); err != nil {
+		return err
+	}
+
+	return memberErr
+}
+
+func (s *Storage) pushUserChat(ctx context.Context, log *slog.Logger, uid []byte, userChat *model.UserChat) error {
+	if res, err := s.userChatsCollection().UpdateByID(
+		ctx,
+		uid,
+		bson.M{"$push": bson.M{"chats": userChat}},
+	); err != nil || res.ModifiedCount < 1 {
+		if err == nil {
+			log.Error("failed to update user chats, nothing modified")
+		} else {
+			log.Error("failed to update user chats", logger.Err(err))
+		}
+		return chat.ErrInternal
+	}
+
+	return nil
+}
@@ -2,19 +2,34 @@ package scylla
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/pkg/database/scylladb"
 	"github.com/gocql/gocql"
-	"github.com/google/uuid"
 	"log/slog"
+	"slices"
+	"time"
 )
 
 const (
-	statementSelectByCid = "SELECT mid, uid, text FROM messages WHERE cid=?"
-	statementInsert      = "INSERT INTO messages(cid, mid, uid, text) VALUES(?,?,?,?)"
+	selectColumns = "SELECT mid, uid, text, toUnixTimestamp(mid), edited_at, deleted, " +
+		"reply_to, fwd_cid, fwd_mid, fwd_uid FROM messages "
+
+	statementSelectLatest = selectColumns + "WHERE cid=? ORDER BY mid DESC LIMIT ?"
+	statementSelectBefore = selectColumns + "WHERE cid=? AND mid<? ORDER BY mid DESC LIMIT ?"
+	statementSelectAfter  = selectColumns + "WHERE cid=? AND mid>? ORDER BY mid ASC LIMIT ?"
+	statementSelectOne    = selectColumns + "WHERE cid=? AND mid=?"
+	statementSelectCids   = "SELECT DISTINCT cid FROM messages"
+	// the edit and delete are lightweight transactions, so an edit racing the
+	// delete can't bring the text back
+	statementUpdate = "UPDATE messages SET text=?, edited_at=? WHERE cid=? AND mid=? IF deleted = null"
+	statementDelete = "UPDATE messages SET text=null, deleted=true WHERE cid=? AND mid=? IF EXISTS"
+
+	statementInsert = "INSERT INTO messages(cid, mid, uid, text, reply_to, fwd_cid, fwd_mid, fwd_uid) " +
+		"VALUES(?,?,?,?,?,?,?,?)"
 )
 
 type Storage struct {
@@ -42,40 +57,81 @@ func (s *Storage) Connect(cluster *gocql.ClusterConfig) error {
 	return nil
 }
 
-func (s *Storage) Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error) {
+func (s *Storage) Messages(
+	ctx context.Context,
+	cid []byte,
+	before []byte,
+	after []byte,
+	limit int,
+) (model.MessagesPage, error) {
 	const op = "scylla.Messages"
 	log := s.log.With(slog.String("op", op))
 
-	iter := s.session.Query(statementSelectByCid, cid).Iter()
+	var query *gocql.Query
+	switch {
+	case len(after) != 0:
+		query = s.session.Query(statementSelectAfter, cid, after, limit+1)
+	case len(before) != 0:
+		query = s.session.Query(statementSelectBefore, cid, before, limit+1)
+	default:
+		query = s.session.Query(statementSelectLatest, cid, limit+1)
+	}
+
+	iter := query.WithContext(ctx).Iter()
 	scanner := iter.Scanner()
 	defer iter.Close()
 
-	var mid, uid = make([]byte, 0), make([]byte, 0)
-	var text string
-
-	res := make([]model.ChatMessage, 0)
+	res := make([]model.ChatMessage, 0, limit+1)
 	for scanner.Next() {
-		if err := scanner.Scan(mid, uid, &text); err != nil {
+		msg, err := scanMessage(scanner.Scan, cid)
+		if err != nil {
 			log.Error("failed to scan", logger.Err(err))
-			return nil, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+			return model.MessagesPage{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
 		}
-		res = append(res, model.ChatMessage{Id: mid, Cid: cid, Uid: uid, Text: text})
+		res = append(res, msg)
 	}
 
 	if scanner.Err() != nil {
 		log.Error("failed to fetch messages", logger.Err(scanner.Err()))
-		return nil, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		return model.MessagesPage{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	page := model.MessagesPage{Messages: res}
+	if len(res) > limit {
+		page.Messages = res[:limit]
+		page.Next = res[limit-1].Id
+	}
+	if len(after) == 0 {
+		slices.Reverse(page.Messages)
 	}
 
-	return res, nil
+	return page, nil
 }
 
-func (s *Storage) Save(ctx context.Context, cid []byte, from []byte, text string) (model.ChatMessage, error) {
+func (s *Storage) Save(
+	ctx context.Context,
+	cid []byte,
+	from []byte,
+	text string,
+	refs model.MessageRefs,
+) (model.ChatMessage, error) {
 	const op = "scylla.Save"
 	log := s.log.With(slog.String("op", op))
 
-	mid := uuid.New()
-	chatMessage := model.ChatMessage{Id: mid[:], Cid: cid, Uid: from, Text: text}
+	mid := gocql.TimeUUID()
+	chatMessage := model.ChatMessage{
+		Id:          mid.Bytes(),
+		Cid:         cid,
+		Uid:         from,
+		Text:        text,
+		Timestamp:   mid.Time().UnixMilli(),
+		MessageRefs: refs,
+	}
+
+	var fwdCid, fwdMid, fwdUid []byte
+	if refs.Forward != nil {
+		fwdCid, fwdMid, fwdUid = refs.Forward.Cid, refs.Forward.Mid, refs.Forward.Uid
+	}
 
 	if err := s.session.Query(
 		statementInsert,
@@ -83,6 +139,10 @@ func (s *Storage) Save(ctx context.Context, cid []byte, from []byte, text string
 		chatMessage.Id,
 		chatMessage.Uid,
 		chatMessage.Text,
+		refs.ReplyTo,
+		fwdCid,
+		fwdMid,
+		fwdUid,
 	).Exec(); err != nil {
 		log.Error("failed to save message", logger.Err(err))
 		return model.ChatMessage{}, chat.ErrInternal
@@ -90,3 +150,104 @@ func (s *Storage) Save(ctx context.Context, cid []byte, from []byte, text string
 
 	return chatMessage, nil
 }
+
+func (s *Storage) Message(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	const op = "scylla.Message"
+	log := s.log.With(slog.String("op", op))
+
+	msg, err := scanMessage(s.session.Query(statementSelectOne, cid, mid).WithContext(ctx).Scan, cid)
+	if err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrMessageNotFound)
+		}
+
+		log.Error("failed to fetch message", logger.Err(err))
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return msg, nil
+}
+
+func (s *Storage) Update(ctx context.Context, cid []byte, mid []byte, text string) (model.ChatMessage, error) {
+	const op = "scylla.Update"
+	log := s.log.With(slog.String("op", op))
+
+	applied, err := s.session.Query(statementUpdate, text, time.Now(), cid, mid).
+		WithContext(ctx).
+		MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		log.Error("failed to update message", logger.Err(err))
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	if !applied {
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrMessageDeleted)
+	}
+
+	return s.Message(ctx, cid, mid)
+}
+
+func (s *Storage) Delete(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	const op = "scylla.Delete"
+	log := s.log.With(slog.String("op", op))
+
+	applied, err := s.session.Query(statementDelete, cid, mid).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		log.Error("failed to delete message", logger.Err(err))
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	if !applied {
+		return model.ChatMessage{}, fmt.Errorf("%s: %w", op, chat.ErrMessageNotFound)
+	}
+
+	return s.Message(ctx, cid, mid)
+}
+
+// ChatIds returns the ids of the chats having messages.
+func (s *Storage) ChatIds(ctx context.Context) ([][]byte, error) {
+	const op = "scylla.ChatIds"
+	log := s.log.With(slog.String("op", op))
+
+	iter := s.session.Query(statementSelectCids).WithContext(ctx).Iter()
+	scanner := iter.Scanner()
+	defer iter.Close()
+
+	cids := make([][]byte, 0)
+	for scanner.Next() {
+		var cid []byte
+		if err := scanner.Scan(&cid); err != nil {
+			log.Error("failed to scan", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		}
+		cids = append(cids, cid)
+	}
+
+	if scanner.Err() != nil {
+		log.Error("failed to fetch chat ids", logger.Err(scanner.Err()))
+		return nil, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return cids, nil
+}
+
+func scanMessage(scan func(dest ...interface{}) error, cid []byte) (model.ChatMessage, error) {
+	var mid, uid, replyTo, fwdCid, fwdMid, fwdUid []byte
+	var text string
+	var timestamp int64
+	var editedAt time.Time
+	var deleted bool
+
+	if err := scan(&mid, &uid, &text, &timestamp, &editedAt, &deleted, &replyTo, &fwdCid, &fwdMid, &fwdUid); err != nil {
+		return model.ChatMessage{}, err
+	}
+
+	msg := model.ChatMessage{Id: mid, Cid: cid, Uid: uid, Text: text, Timestamp: timestamp, Deleted: deleted}
+	if !editedAt.IsZero() {
+		msg.EditedAt = editedAt.UnixMilli()
+	}
+	msg.ReplyTo = replyTo
+	if len(fwdMid) != 0 {
+		msg.Forward = &model.ForwardedFrom{Cid: fwdCid, Mid: fwdMid, Uid: fwdUid}
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,114 @@
+package scylla
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"log/slog"
+)
+
+// Who reacted is kept in the reactions table guarded by lightweight
+// transactions, counts are read from it so they never drift from the set.
+const (
+	statementInsertReaction = "INSERT INTO reactions(cid, mid, emoji, uid) VALUES(?,?,?,?) IF NOT EXISTS"
+	statementDeleteReaction = "DELETE FROM reactions WHERE cid=? AND mid=? AND emoji=? AND uid=? IF EXISTS"
+	statementSelectCount    = "SELECT COUNT(*) FROM reactions WHERE cid=? AND mid=? AND emoji=?"
+	statementSelectCounts   = "SELECT mid, emoji, COUNT(*) FROM reactions WHERE cid=? AND mid IN ? GROUP BY mid, emoji"
+)
+
+func (s *Storage) AddReaction(ctx context.Context, reaction *model.Reaction) (int64, error) {
+	const op = "scylla.AddReaction"
+	log := s.log.With(slog.String("op", op))
+
+	applied, err := s.session.Query(
+		statementInsertReaction,
+		reaction.Cid,
+		reaction.Mid,
+		reaction.Emoji,
+		reaction.Uid,
+	).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		log.Error("failed to save reaction", logger.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	if !applied {
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrReactionExists)
+	}
+
+	return s.count(ctx, log, op, reaction)
+}
+
+func (s *Storage) RemoveReaction(ctx context.Context, reaction *model.Reaction) (int64, error) {
+	const op = "scylla.RemoveReaction"
+	log := s.log.With(slog.String("op", op))
+
+	applied, err := s.session.Query(
+		statementDeleteReaction,
+		reaction.Cid,
+		reaction.Mid,
+		reaction.Emoji,
+		reaction.Uid,
+	).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		log.Error("failed to delete reaction", logger.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+	if !applied {
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrReactionNotFound)
+	}
+
+	return s.count(ctx, log, op, reaction)
+}
+
+func (s *Storage) Reactions(ctx context.Context, cid []byte, mids [][]byte) ([]model.MessageReactions, error) {
+	const op = "scylla.Reactions"
+	log := s.log.With(slog.String("op", op))
+
+	iter := s.session.Query(statementSelectCounts, cid, mids).WithContext(ctx).Iter()
+	scanner := iter.Scanner()
+	defer iter.Close()
+
+	byMid := make(map[[16]byte][]model.ReactionCount)
+	for scanner.Next() {
+		var mid []byte
+		var rc model.ReactionCount
+		if err := scanner.Scan(&mid, &rc.Emoji, &rc.Count); err != nil {
+			log.Error("failed to scan", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+		}
+		byMid[[16]byte(mid)] = append(byMid[[16]byte(mid)], rc)
+	}
+
+	if scanner.Err() != nil {
+		log.Error("failed to fetch reactions", logger.Err(scanner.Err()))
+		return nil, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	res := make([]model.MessageReactions, 0, len(byMid))
+	for _, mid := range mids {
+		if counts, ok := byMid[[16]byte(mid)]; ok {
+			res = append(res, model.MessageReactions{Mid: mid, Counts: counts})
+		}
+	}
+
+	return res, nil
+}
+
+// count returns how many users reacted with the emoji of reaction after the
+// change, read back from the set itself.
+func (s *Storage) count(ctx context.Context, log *slog.Logger, op string, reaction *model.Reaction) (int64, error) {
+	var count int64
+	if err := s.session.Query(
+		statementSelectCount,
+		reaction.Cid,
+		reaction.Mid,
+		reaction.Emoji,
+	).WithContext(ctx).Scan(&count); err != nil {
+		log.Error("failed to count reactions", logger.Err(err))
+		return 0, fmt.Errorf("%s: %w", op, chat.ErrInternal)
+	}
+
+	return count, nil
+}
@@ -6,7 +6,13 @@ var (
 	ErrChatExists        = errors.New("chat already exists")
 	ErrChatNotFound      = errors.New("chat not found")
 	ErrMessagesNotFound  = errors.New("chat messages not found")
+	ErrMessageNotFound   = errors.New("chat message not found")
+	ErrMessageDeleted    = errors.New("chat message deleted")
 	ErrUserChatsExists   = errors.New("user chats already exists")
 	ErrUserChatsNotFound = errors.New("user chats not found")
+	ErrMemberExists      = errors.New("chat member already exists")
+	ErrMemberNotFound    = errors.New("chat member not found")
+	ErrReactionExists    = errors.New("reaction already exists")
+	ErrReactionNotFound  = errors.New("reaction not found")
 	ErrInternal          = errors.New("storage error")
 )
@@ -55,6 +55,100 @@ func TestUserStorage_Save(t *testing.T) {
 	})
 }
 
+func TestUserStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	storage := inmem.New()
+
+	saved, err := storage.Save(context.Background(), "test-mail", "test-bio")
+	require.NoError(t, err, "save should not error")
+
+	require.NoError(t, storage.Delete(context.Background(), saved.Id), "delete should not error")
+
+	_, err = storage.User(context.Background(), saved.Id)
+	assert.ErrorIs(t, err, user.ErrUserNotFound, "deleted user not found")
+
+	err = storage.Delete(context.Background(), saved.Id)
+	assert.ErrorIs(t, err, user.ErrUserNotFound, "delete missing user should error")
+
+	_, err = storage.Save(context.Background(), "test-mail", "test-bio")
+	assert.NoError(t, err, "email free after delete")
+}
+
+func TestUserStorage_Update(t *testing.T) {
+	t.Parallel()
+
+	storage := inmem.New()
+
+	saved, err := storage.Save(context.Background(), "test-mail", "test-bio")
+	require.NoError(t, err, "save should not error")
+
+	name := "test-name"
+	updated, err := storage.Update(context.Background(), saved.Id, model.ProfileUpdate{DisplayName: &name})
+	require.NoError(t, err, "update should not error")
+	assert.Equal(t, name, updated.DisplayName)
+	assert.Equal(t, "test-bio", updated.Bio, "unset fields kept")
+
+	fetched, err := storage.User(context.Background(), saved.Id)
+	require.NoError(t, err, "user should not error")
+	assert.Equal(t, updated, fetched)
+
+	_, err = storage.Update(context.Background(), genUser("missing", "").Id, model.ProfileUpdate{DisplayName: &name})
+	assert.ErrorIs(t, err, user.ErrUserNotFound, "update missing user should error")
+}
+
+func TestUserStorage_UsersByIds(t *testing.T) {
+	t.Parallel()
+
+	storage := inmem.New()
+
+	user1, err := storage.Save(context.Background(), "test-mail1", "")
+	require.NoError(t, err, "save should not error")
+	user2, err := storage.Save(context.Background(), "test-mail2", "")
+	require.NoError(t, err, "save should not error")
+	_, err = storage.Save(context.Background(), "test-mail3", "")
+	require.NoError(t, err, "save should not error")
+
+	users, err := storage.UsersByIds(context.Background(), [][]byte{user1.Id, genUser("missing", "").Id, user2.Id})
+	require.NoError(t, err, "users by ids should not error")
+	assert.ElementsMatch(t, []model.User{user1, user2}, users, "only requested known users returned")
+}
+
+func TestUserStorage_Search(t *testing.T) {
+	t.Parallel()
+
+	storage := inmem.New()
+
+	for _, email := range []string{"alice@example.com", "bob@example.com", "Alicia@test.org", "carol@test.org"} {
+		_, err := storage.Save(context.Background(), email, "")
+		require.NoError(t, err, "save should not error")
+	}
+
+	page, err := storage.Search(context.Background(), "ALI", true, nil, 10)
+	require.NoError(t, err, "search should not error")
+	assert.Len(t, page.Users, 2, "prefix match is case insensitive")
+	assert.Empty(t, page.Next, "no next page")
+
+	page, err = storage.Search(context.Background(), "test.org", true, nil, 10)
+	require.NoError(t, err, "search should not error")
+	assert.Empty(t, page.Users, "prefix does not match the middle")
+
+	var found []string
+	var after []byte
+	for {
+		page, err = storage.Search(context.Background(), "example", false, after, 1)
+		require.NoError(t, err, "search should not error")
+		for _, usr := range page.Users {
+			found = append(found, usr.Email)
+		}
+		if len(page.Next) == 0 {
+			break
+		}
+		after = page.Next
+	}
+	assert.ElementsMatch(t, []string{"alice@example.com", "bob@example.com"}, found, "pages cover all matches")
+}
+
 func genUser(email string, bio string) *model.User {
 	uid := [16]byte(uuid.New())
 	return &model.User{
@@ -1,6 +1,7 @@
 package inmem
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/user"
@@ -8,6 +9,8 @@ import (
 	"github.com/dvid-messanger/pkg/id"
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
+	"slices"
+	"strings"
 	"sync"
 )
 
@@ -56,8 +59,96 @@ func (s *Storage) User(ctx context.Context, uid []byte) (model.User, error) {
 }
 
 func (s *Storage) Users(ctx context.Context) ([]model.User, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	users := s.sorted()
+	return users[:min(len(users), user.MaxUsers)], nil
+}
+
+func (s *Storage) UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	users := make([]model.User, 0, len(uids))
+	for _, uid := range uids {
+		if usr, ok := s.hash[id.Id(uid)]; ok {
+			users = append(users, usr)
+		}
+	}
+
+	return users, nil
+}
+
+func (s *Storage) Search(
+	ctx context.Context,
+	query string,
+	prefix bool,
+	after []byte,
+	limit int,
+) (model.UsersPage, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	match := strings.Contains
+	if prefix {
+		match = strings.HasPrefix
+	}
+	query = user.SearchKey(query)
+
+	page := model.UsersPage{Users: make([]model.User, 0, limit)}
+	for _, usr := range s.sorted() {
+		if len(after) != 0 && bytes.Compare(usr.Id, after) <= 0 {
+			continue
+		}
+		if !match(user.SearchKey(usr.Email), query) && !match(user.SearchKey(usr.DisplayName), query) {
+			continue
+		}
+		if len(page.Users) == limit {
+			page.Next = page.Users[limit-1].Id
+			break
+		}
+		page.Users = append(page.Users, usr)
+	}
+
+	return page, nil
+}
+
+func (s *Storage) sorted() []model.User {
+	users := maps.Values(s.hash)
+	slices.SortFunc(users, func(a, b model.User) int {
+		return bytes.Compare(a.Id, b.Id)
+	})
+
+	return users
+}
+
+func (s *Storage) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (model.User, error) {
+	const op = "storage.inmem.Update"
+
 	s.rw.Lock()
 	defer s.rw.Unlock()
 
-	return maps.Values(s.hash), nil
+	usr, ok := s.hash[id.Id(uid)]
+	if !ok {
+		return model.User{}, fmt.Errorf("%s: %w", op, user.ErrUserNotFound)
+	}
+	upd.Apply(&usr)
+	s.hash[id.Id(uid)] = usr
+
+	return usr, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, uid []byte) error {
+	const op = "storage.inmem.Delete"
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	if _, ok := s.hash[id.Id(uid)]; !ok {
+		return fmt.Errorf("%s: %w", op, user.ErrUserNotFound)
+	}
+	delete(s.hash, id.Id(uid))
+
+	return nil
 }
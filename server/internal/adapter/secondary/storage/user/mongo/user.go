@@ -11,7 +11,9 @@ import (
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"log/slog"
+	"regexp"
 )
 
 const (
@@ -19,6 +21,19 @@ const (
 	nameUsersCollection = "users"
 )
 
+// userDoc stores lower cased copies of the searched fields. A prefix search
+// selects its candidates through the email_key and name_key indexes and sorts
+// them by _id in memory, a substring search scans the whole collection.
+type userDoc struct {
+	model.User `bson:",inline"`
+	EmailKey   string `bson:"email_key"`
+	NameKey    string `bson:"name_key"`
+}
+
+func newUserDoc(usr model.User) userDoc {
+	return userDoc{User: usr, EmailKey: user.SearchKey(usr.Email), NameKey: user.SearchKey(usr.DisplayName)}
+}
+
 type Storage struct {
 	log *slog.Logger
 	mongodb.MongoDatabase
@@ -70,7 +85,8 @@ func (s *Storage) Users(ctx context.Context) ([]model.User, error) {
 	const op = "mongo.Users"
 	log := s.log.With(slog.String("op", op))
 
-	cursor, err := s.usersCollection().Find(ctx, bson.M{}, nil)
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(user.MaxUsers)
+	cursor, err := s.usersCollection().Find(ctx, bson.M{}, opts)
 	if err != nil {
 		log.Error("failed to fetch users", logger.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, user.ErrInternal)
@@ -85,6 +101,74 @@ func (s *Storage) Users(ctx context.Context) ([]model.User, error) {
 	return users, nil
 }
 
+func (s *Storage) UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error) {
+	const op = "mongo.UsersByIds"
+	log := s.log.With(slog.String("op", op))
+
+	cursor, err := s.usersCollection().Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: uids}}}})
+	if err != nil {
+		log.Error("failed to fetch users", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+
+	users := make([]model.User, 0, len(uids))
+	if err = cursor.All(ctx, &users); err != nil {
+		log.Error("failed to fetch users", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+
+	return users, nil
+}
+
+func (s *Storage) Search(
+	ctx context.Context,
+	query string,
+	prefix bool,
+	after []byte,
+	limit int,
+) (model.UsersPage, error) {
+	const op = "mongo.Search"
+	log := s.log.With(slog.String("op", op))
+
+	filter := bson.D{}
+	if query != "" {
+		pattern := regexp.QuoteMeta(user.SearchKey(query))
+		if prefix {
+			pattern = "^" + pattern
+		}
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "email_key", Value: bson.D{{Key: "$regex", Value: pattern}}}},
+			bson.D{{Key: "name_key", Value: bson.D{{Key: "$regex", Value: pattern}}}},
+		}})
+	}
+	if len(after) != 0 {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: after}}})
+	}
+
+	// Pages follow _id order, so the cursor stays valid whatever the query
+	// matched. One extra user tells whether there is a next page.
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit + 1))
+	cursor, err := s.usersCollection().Find(ctx, filter, opts)
+	if err != nil {
+		log.Error("failed to search users", logger.Err(err))
+		return model.UsersPage{}, fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+
+	users := make([]model.User, 0, limit+1)
+	if err = cursor.All(ctx, &users); err != nil {
+		log.Error("failed to search users", logger.Err(err))
+		return model.UsersPage{}, fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+
+	page := model.UsersPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.Next = users[limit-1].Id
+	}
+
+	return page, nil
+}
+
 func (s *Storage) Save(ctx context.Context, email string, bio string) (model.User, error) {
 	const op = "mongo.Save"
 	log := s.log.With(slog.String("op", op))
@@ -92,7 +176,7 @@ func (s *Storage) Save(ctx context.Context, email string, bio string) (model.Use
 	uid := uuid.New()
 	usr := model.User{Id: uid[:], Email: email, Bio: bio}
 
-	if _, err := s.usersCollection().InsertOne(ctx, usr); err != nil {
+	if _, err := s.usersCollection().InsertOne(ctx, newUserDoc(usr)); err != nil {
 		if mongo.IsDuplicateKeyError(err) {
 			return model.User{}, fmt.Errorf("%s: %w", op, user.ErrUserExists)
 		}
@@ -104,6 +188,67 @@ func (s *Storage) Save(ctx context.Context, email string, bio string) (model.Use
 	return usr, nil
 }
 
+func (s *Storage) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (model.User, error) {
+	const op = "mongo.Update"
+	log := s.log.With(slog.String("op", op))
+
+	set := bson.D{}
+	if upd.DisplayName != nil {
+		set = append(set,
+			bson.E{Key: "display_name", Value: *upd.DisplayName},
+			bson.E{Key: "name_key", Value: user.SearchKey(*upd.DisplayName)},
+		)
+	}
+	if upd.Avatar != nil {
+		set = append(set, bson.E{Key: "avatar", Value: *upd.Avatar})
+	}
+	if upd.Bio != nil {
+		set = append(set, bson.E{Key: "bio", Value: *upd.Bio})
+	}
+	if len(set) == 0 {
+		return s.User(ctx, uid)
+	}
+
+	var usr model.User
+	res := s.usersCollection().FindOneAndUpdate(
+		ctx,
+		bson.D{{Key: "_id", Value: uid}},
+		bson.D{{Key: "$set", Value: set}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return usr, fmt.Errorf("%s: %w", op, user.ErrUserNotFound)
+		}
+
+		log.Error("failed to update user", logger.Err(res.Err()))
+		return usr, fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+
+	if err := res.Decode(&usr); err != nil {
+		log.Error("failed to decode user", logger.Err(err))
+		return usr, fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+
+	return usr, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, uid []byte) error {
+	const op = "mongo.Delete"
+	log := s.log.With(slog.String("op", op))
+
+	res, err := s.usersCollection().DeleteOne(ctx, bson.D{{Key: "_id", Value: uid}})
+	if err != nil {
+		log.Error("failed to delete user", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, user.ErrInternal)
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("%s: %w", op, user.ErrUserNotFound)
+	}
+
+	return nil
+}
+
 func (s *Storage) usersCollection() *mongo.Collection {
 	return s.Client.Database(nameDb).Collection(nameUsersCollection)
 }
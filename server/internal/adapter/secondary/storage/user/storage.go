@@ -1,9 +1,21 @@
 package user
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
+
+// MaxUsers caps the users returned at once, larger directories are paged
+// through search.
+const MaxUsers = 100
 
 var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
 	ErrInternal     = errors.New("storage error")
 )
+
+// SearchKey normalizes emails, display names and queries so search ignores case.
+func SearchKey(s string) string {
+	return strings.ToLower(s)
+}
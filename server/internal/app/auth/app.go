@@ -2,52 +2,119 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/auth/mongo"
 	"github.com/dvid-messanger/internal/app/auth/grpc"
+	"github.com/dvid-messanger/internal/app/auth/http"
 	"github.com/dvid-messanger/internal/config"
 	"github.com/dvid-messanger/internal/core/service/auth"
 	"github.com/dvid-messanger/internal/pkg/jwt"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/pkg/database/mongodb"
+	"github.com/google/uuid"
 	"log/slog"
 	"sync"
 	"time"
 )
 
-const defaultStopTimeout = 10 * time.Second
+const (
+	defaultStopTimeout = 10 * time.Second
+)
 
 type App struct {
 	log     *slog.Logger
 	grpcApp *grpc.App
+	httpApp *http.App
 	storage *mongo.Storage
 }
 
-func New(log *slog.Logger, cfg *config.AuthConfig) *App {
+func New(log *slog.Logger, cfg *config.AuthConfig) (*App, error) {
+	const op = "auth.New"
+
+	keys, err := loadKeys(log, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	storage := mongo.New(log, mongodb.Timeout(cfg.Storage.Timeout), mongodb.URI(cfg.Storage.ConnectUri))
-	tokenMaker := jwt.NewTokenizer([]byte(cfg.Secret))
-	authService := auth.NewService(log, storage, storage, tokenMaker, cfg.TokenTTL)
+	tokenizer := jwt.NewTokenizer(keys, storage)
+	authService := auth.NewService(log, auth.Deps{
+		UserSaver:          storage,
+		UserProvider:       storage,
+		TokenMaker:         tokenizer,
+		TokenVerifier:      tokenizer,
+		TokenRevoker:       storage,
+		RevocationProvider: storage,
+		KeyProvider:        keys,
+	}, cfg.TokenTTL, cfg.RefreshTokenTTL)
 
-	grpcApp := grpc.New(log, authService, cfg.Port)
+	grpcApp := grpc.New(log, authService, cfg.Port, cfg.InternalPort)
+
+	var httpApp *http.App
+	if cfg.JwksPort != 0 {
+		httpApp = http.New(log, authService, cfg.JwksPort)
+	}
 
 	return &App{
 		log:     log,
 		grpcApp: grpcApp,
+		httpApp: httpApp,
 		storage: storage,
+	}, nil
+}
+
+func loadKeys(log *slog.Logger, cfg *config.AuthConfig) (*jwt.KeySet, error) {
+	keys := jwt.NewKeySet()
+
+	if len(cfg.Keys) == 0 {
+		// every start and replica gets its own kid, so verifiers caching
+		// keys by kid never mix up generated keys
+		kid := uuid.NewString()
+		log.Warn("no signing keys configured, generating ephemeral key", slog.String("kid", kid))
+		if err := keys.Generate(kid); err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+
+	for _, key := range cfg.Keys {
+		if err := keys.AddFile(key.Id, key.Path); err != nil {
+			return nil, fmt.Errorf("key %s: %w", key.Id, err)
+		}
 	}
+	if cfg.SigningKey != "" {
+		if err := keys.Rotate(cfg.SigningKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
 }
 
 func (app *App) MustRun() {
-	if err := app.storage.Connect(context.TODO()); err != nil {
+	if err := app.Run(); err != nil {
 		panic(err)
 	}
-	app.grpcApp.MustRun()
 }
 
 func (app *App) Run() error {
 	if err := app.storage.Connect(context.TODO()); err != nil {
 		return err
 	}
-	return app.grpcApp.Run()
+
+	if app.httpApp == nil {
+		return app.grpcApp.Run()
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- app.grpcApp.Run()
+	}()
+	go func() {
+		errs <- app.httpApp.Run()
+	}()
+
+	return <-errs
 }
 
 func (app *App) Stop() {
@@ -63,6 +130,9 @@ func (app *App) Stop() {
 	go func() {
 		defer wg.Done()
 		app.grpcApp.Stop()
+		if app.httpApp != nil {
+			app.httpApp.Stop()
+		}
 	}()
 	go func() {
 		defer wg.Done()
@@ -10,19 +10,28 @@ import (
 )
 
 type App struct {
-	log        *slog.Logger
-	gRpcServer *grpc.Server
-	port       int
+	log            *slog.Logger
+	gRpcServer     *grpc.Server
+	internalServer *grpc.Server
+	port           int
+	internalPort   int
 }
 
-func New(log *slog.Logger, handler primary.Auth, port int) *App {
+// New serves handler on port and its internal calls, meant for other services
+// only, on internalPort.
+func New(log *slog.Logger, handler primary.Auth, port int, internalPort int) *App {
 	gRpcServer := grpc.NewServer()
 	auth.Register(gRpcServer, handler)
 
+	internalServer := grpc.NewServer()
+	auth.RegisterInternal(internalServer, handler)
+
 	return &App{
-		log:        log,
-		gRpcServer: gRpcServer,
-		port:       port,
+		log:            log,
+		gRpcServer:     gRpcServer,
+		internalServer: internalServer,
+		port:           port,
+		internalPort:   internalPort,
 	}
 }
 
@@ -42,9 +51,24 @@ func (a *App) Run() error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("grpc server running", slog.String("addr", l.Addr().String()))
+	il, err := net.Listen("tcp", fmt.Sprintf(":%d", a.internalPort))
+	if err != nil {
+		l.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("grpc server running",
+		slog.String("addr", l.Addr().String()),
+		slog.String("internal_addr", il.Addr().String()),
+	)
+
+	errs := make(chan error, 2)
+	go func() { errs <- a.internalServer.Serve(il) }()
+	go func() { errs <- a.gRpcServer.Serve(l) }()
 
-	if err = a.gRpcServer.Serve(l); err != nil {
+	// either server failing stops the other one too
+	if err = <-errs; err != nil {
+		a.Stop()
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
@@ -56,4 +80,5 @@ func (a *App) Stop() {
 	a.log.With(slog.String("op", op)).
 		Info("stopping grpc server")
 	a.gRpcServer.GracefulStop()
+	a.internalServer.GracefulStop()
 }
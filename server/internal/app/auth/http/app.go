@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/adapter/primary/auth"
+	"github.com/gorilla/mux"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+type App struct {
+	log        *slog.Logger
+	port       int
+	handler    http.Handler
+	httpServer *http.Server
+}
+
+func New(log *slog.Logger, handler primary.Auth, port int) *App {
+	return &App{
+		log:     log,
+		port:    port,
+		handler: auth.NewJWKSHandler(log, handler),
+	}
+}
+
+func (a *App) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run() error {
+	const op = "http.Run"
+	log := a.log.With(slog.String("op", op))
+
+	router := mux.NewRouter()
+	router.Handle(auth.JWKSPath, a.handler).Methods(http.MethodGet)
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", a.port))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	a.httpServer = &http.Server{Addr: l.Addr().String(), Handler: router}
+
+	log.Info("http server running", slog.String("addr", l.Addr().String()))
+
+	if err = a.httpServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *App) Stop() {
+	const op = "http.Stop"
+	a.log.With(slog.String("op", op)).Info("stopping http server")
+
+	if a.httpServer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = a.httpServer.Shutdown(ctx)
+}
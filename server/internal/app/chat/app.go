@@ -3,7 +3,9 @@ package chat
 import (
 	"context"
 	"fmt"
+	brokerfe "github.com/dvid-messanger/internal/adapter/secondary/broker/frontend"
 	"github.com/dvid-messanger/internal/adapter/secondary/client/frontend"
+	"github.com/dvid-messanger/internal/adapter/secondary/index/message/inmem"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat/mongo"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat/scylla"
 	"github.com/dvid-messanger/internal/app/chat/grpc"
@@ -12,24 +14,32 @@ import (
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/pkg/database/mongodb"
 	"github.com/dvid-messanger/pkg/database/scylladb"
+	"github.com/dvid-messanger/pkg/pubsub"
+	"github.com/dvid-messanger/pkg/pubsub/redis"
 	"github.com/gocql/gocql"
 	"log/slog"
 	"sync"
 	"time"
 )
 
-const defaultStopTimeout = 10 * time.Second
+const (
+	defaultStopTimeout  = 10 * time.Second
+	rebuildIndexTimeout = 5 * time.Minute
+)
 
 type App struct {
 	log         *slog.Logger
 	grpcApp     *grpc.App
 	chatStorage *mongo.Storage
+	index       *inmem.Index
+	broker      pubsub.Broker
 }
 
 func New(
 	log *slog.Logger,
 	cfg *config.ChatConfig,
 	feClientCfg *config.ClientConfig,
+	notifierCfg *config.NotifierConfig,
 ) (*App, error) {
 	const op = "chat.app.New"
 
@@ -45,12 +55,23 @@ func New(
 		log.Error("failed to connect to message storage", slog.String("op", op))
 		return nil, err
 	}
-	notifier, err := frontend.New(context.TODO(), log, feClientCfg.Address, feClientCfg.Timeout, feClientCfg.RetriesCount)
+	notifier, broker, err := newNotifier(log, feClientCfg, notifierCfg)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	chatService := chat.NewService(log, chatStorage, chatStorage, notifier, chatStorage, messageStorage, messageStorage)
+	index := inmem.New(messageStorage)
+	chatService := chat.NewService(log, chat.Deps{
+		ChatProvider:     chatStorage,
+		ChatSaver:        chatStorage,
+		ChatNotifier:     notifier,
+		UserChatProvider: chatStorage,
+		MessageProvider:  messageStorage,
+		MessageSaver:     messageStorage,
+		MessageIndexer:   index,
+		ReactionProvider: messageStorage,
+		ReactionSaver:    messageStorage,
+	})
 
 	grpcApp := grpc.New(log, chatService, cfg.Port)
 
@@ -58,13 +79,40 @@ func New(
 		log:         log,
 		grpcApp:     grpcApp,
 		chatStorage: chatStorage,
+		index:       index,
+		broker:      broker,
 	}, nil
 }
 
+func newNotifier(
+	log *slog.Logger,
+	feClientCfg *config.ClientConfig,
+	notifierCfg *config.NotifierConfig,
+) (chat.ChatNotifier, pubsub.Broker, error) {
+	if notifierCfg.Backend == config.NotifierBackendRedis {
+		broker, err := redis.New(context.TODO(), notifierCfg.BrokerAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return brokerfe.New(log, broker), broker, nil
+	}
+
+	notifier, err := frontend.New(context.TODO(), log, feClientCfg.Address, feClientCfg.Timeout, feClientCfg.RetriesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return notifier, nil, nil
+}
+
 func (app *App) MustRun() {
 	if err := app.chatStorage.Connect(context.TODO()); err != nil {
 		panic(err)
 	}
+	if err := app.rebuildIndex(); err != nil {
+		panic(err)
+	}
 	app.grpcApp.MustRun()
 }
 
@@ -72,15 +120,37 @@ func (app *App) Run() error {
 	if err := app.chatStorage.Connect(context.TODO()); err != nil {
 		return err
 	}
+	if err := app.rebuildIndex(); err != nil {
+		return err
+	}
 	return app.grpcApp.Run()
 }
 
+// rebuildIndex fills the in-process search index from storage before serving,
+// it is empty on every start. Messages saved by other instances never reach
+// it, so the chat service must run as a single instance.
+func (app *App) rebuildIndex() error {
+	const op = "app.rebuildIndex"
+	log := app.log.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(context.Background(), rebuildIndexTimeout)
+	defer cancel()
+
+	indexed, err := app.index.Rebuild(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("search index rebuilt", slog.Int("messages", indexed))
+	return nil
+}
+
 func (app *App) Stop() {
 	const op = "app.Stop"
 	log := app.log.With(slog.String("op", op))
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
 
 	ctx, cancel := context.WithTimeout(context.Background(), defaultStopTimeout)
 	defer cancel()
@@ -95,6 +165,15 @@ func (app *App) Stop() {
 			log.Error("storage closed with error", logger.Err(err))
 		}
 	}()
+	go func() {
+		defer wg.Done()
+		if app.broker == nil {
+			return
+		}
+		if err := app.broker.Close(); err != nil {
+			log.Error("broker closed with error", logger.Err(err))
+		}
+	}()
 
 	wg.Wait()
 }
@@ -3,17 +3,26 @@ package frontend
 import (
 	"context"
 	"fmt"
+	brokerfe "github.com/dvid-messanger/internal/adapter/primary/frontend/broker"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/handler"
 	"github.com/dvid-messanger/internal/adapter/primary/frontend/ws/route/middleware"
+	brokerpub "github.com/dvid-messanger/internal/adapter/secondary/broker/frontend"
 	"github.com/dvid-messanger/internal/adapter/secondary/client/auth"
 	"github.com/dvid-messanger/internal/adapter/secondary/client/chat"
 	"github.com/dvid-messanger/internal/adapter/secondary/client/user"
+	presence "github.com/dvid-messanger/internal/adapter/secondary/presence/redis"
 	"github.com/dvid-messanger/internal/app/frontend/grpc"
 	"github.com/dvid-messanger/internal/app/frontend/http"
+	"github.com/dvid-messanger/internal/config"
 	"github.com/dvid-messanger/internal/core/service/frontend"
 	"github.com/dvid-messanger/internal/pkg/jwt"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/internal/pkg/ratelimit"
+	"github.com/dvid-messanger/pkg/pubsub"
+	"github.com/dvid-messanger/pkg/pubsub/redis"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"log/slog"
 	"sync"
 	"time"
@@ -22,38 +31,69 @@ import (
 type App struct {
 	HttpApp *http.App
 	GrpcApp *grpc.App
+
+	log          *slog.Logger
+	wsServer     *ws.Server
+	drainTimeout time.Duration
+	stopStats    chan struct{}
+	subscriber   *brokerfe.Subscriber
+	broker       pubsub.Broker
+	presence     *presence.Store
 }
 
 func New(
 	log *slog.Logger,
 	grpcPort int,
-	secret []byte,
+	keysTtl time.Duration,
 
 	wsPort int,
 	wsPath string,
 	sendBuffSize int,
+	sendPolicy string,
+	sendStatsEvery time.Duration,
 	rBuffSize int,
 	wBuffSize int,
 	hsTimeout time.Duration,
 	msgLimit int64,
 	writeWait time.Duration,
 	pongWait time.Duration,
+	drainTimeout time.Duration,
+	typingThrottle time.Duration,
+	revocationTtl time.Duration,
+	presenceTtl time.Duration,
+	concurrency int,
+	rateLimit config.RateLimitConfig,
 	userClientAddr string,
+	userClientInternalAddr string,
 	userClientTimeout time.Duration,
 	userClientRetriesCount int,
 	authClientAddr string,
+	authClientInternalAddr string,
 	authClientTimeout time.Duration,
 	authClientRetriesCount int,
 	chatClientAddr string,
 	chatClientTimeout time.Duration,
 	chatClientRetriesCount int,
+	notifierBackend string,
+	brokerAddr string,
 ) (*App, error) {
 	const op = "frontend.New"
 
+	policy, err := ws.ParseSendPolicy(sendPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	limits, err := upstreamLimits(rateLimit.Upstream)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	userClient, err := user.New(
 		context.TODO(),
 		log,
 		userClientAddr,
+		userClientInternalAddr,
 		userClientTimeout,
 		userClientRetriesCount,
 	)
@@ -65,6 +105,7 @@ func New(
 		context.TODO(),
 		log,
 		authClientAddr,
+		authClientInternalAddr,
 		authClientTimeout,
 		authClientRetriesCount,
 	)
@@ -83,22 +124,70 @@ func New(
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	verifier := jwt.NewTokenizer(secret)
-	registry := frontend.NewClientRegistry(log)
+	var broker pubsub.Broker
+	var subscriber *brokerfe.Subscriber
+	var chatWatcher frontend.ChatWatcher
+	var ephemeralNotifier frontend.EphemeralNotifier
+	var profileNotifier frontend.ProfileNotifier
+	var presenceStore *presence.Store
+	var sharedPresence frontend.PresenceStore
+	if notifierBackend == config.NotifierBackendRedis {
+		broker, err = redis.New(context.TODO(), brokerAddr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		presenceStore, err = presence.New(context.TODO(), log, brokerAddr, presenceTtl)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sharedPresence = presenceStore
 
-	router := route.NewRouter(log)
+		subscriber = brokerfe.New(log, broker)
+		chatWatcher = subscriber
+		publisher := brokerpub.New(log, broker)
+		ephemeralNotifier = publisher
+		profileNotifier = publisher
+	}
+
+	verifier := jwt.NewVerifier(
+		jwt.NewRemoteKeySet(authClient, keysTtl),
+		jwt.NewRevocationCache(authClient, revocationTtl),
+	)
+	registry := frontend.NewClientRegistry(log, chatWatcher, sharedPresence)
+	notifier := frontend.NewNotifier(log, registry, registry)
+	if ephemeralNotifier == nil {
+		ephemeralNotifier = notifier
+		profileNotifier = notifier
+	}
+	ephemeral := frontend.NewEphemeral(log, registry, ephemeralNotifier, typingThrottle)
+	registry.WatchPresence(ephemeral)
+
+	registration := frontend.NewRegistration(log, userClient, authClient)
+	profile := frontend.NewProfile(log, userClient, chatClient, profileNotifier)
+
+	router := route.NewRouter(log, concurrency)
 	authMw := middleware.NewAuthMiddleware(log, registry, verifier)
-	handler.RegisterSystemHandler(log, router)
-	handler.RegisterChatHandler(log, router, chatClient, authMw)
-	handler.RegisterAuthHandler(log, router, registry, authClient, authMw)
-	handler.RegisterUserHandler(log, router, userClient, authClient, authMw)
-	handler.RegisterInfoHandler(log, router, registry, chatClient, userClient, authMw)
+	rateLimitMw := middleware.NewRateLimitMiddleware(
+		log,
+		limits,
+		ratelimit.PerWindow(rateLimit.Strikes, rateLimit.StrikeWindow),
+	)
+	handler.RegisterSystemHandler(log, router, rateLimitMw)
+	handler.RegisterChatHandler(log, router, chatClient, authMw, rateLimitMw)
+	handler.RegisterAuthHandler(log, router, registry, authClient, verifier, authMw, rateLimitMw)
+	handler.RegisterUserHandler(log, router, userClient, registration, profile, authMw, rateLimitMw)
+	handler.RegisterInfoHandler(log, router, registry, chatClient, userClient, authMw, rateLimitMw)
+	handler.RegisterEphemeralHandler(log, router, ephemeral, authMw, rateLimitMw)
 
 	wsServer := ws.NewWsServer(
 		log,
 		registry,
+		verifier,
+		userClient,
+		chatClient,
 		router,
 		sendBuffSize,
+		policy,
 		rBuffSize,
 		wBuffSize,
 		hsTimeout,
@@ -109,15 +198,46 @@ func New(
 
 	httpApp := http.New(log, wsServer, wsPort, wsPath)
 
-	notifier := frontend.NewNotifier(log, registry, registry)
 	grpcApp := grpc.New(log, notifier, grpcPort)
 
+	if subscriber != nil {
+		if err = subscriber.Start(notifier); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	stopStats := make(chan struct{})
+	go wsServer.ReportStats(sendStatsEvery, stopStats)
+
 	return &App{
-		HttpApp: httpApp,
-		GrpcApp: grpcApp,
+		HttpApp:      httpApp,
+		GrpcApp:      grpcApp,
+		log:          log,
+		wsServer:     wsServer,
+		drainTimeout: drainTimeout,
+		stopStats:    stopStats,
+		subscriber:   subscriber,
+		broker:       broker,
+		presence:     presenceStore,
 	}, nil
 }
 
+func upstreamLimits(cfg map[string]config.LimitConfig) (map[frontendv1.UpstreamType]ratelimit.Limit, error) {
+	limits := make(map[frontendv1.UpstreamType]ratelimit.Limit, len(cfg))
+	for name, limit := range cfg {
+		ut, ok := frontendv1.UpstreamType_value[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown upstream type %s in rate limits", name)
+		}
+		if limit.Rate <= 0 || limit.Burst < 1 {
+			return nil, fmt.Errorf("rate limit of %s needs a positive rate and burst", name)
+		}
+		limits[frontendv1.UpstreamType(ut)] = ratelimit.Limit{Rate: limit.Rate, Burst: limit.Burst}
+	}
+
+	return limits, nil
+}
+
 func (app *App) MustRun() {
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -147,6 +267,12 @@ func (app *App) Run() error {
 }
 
 func (app *App) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), app.drainTimeout)
+	if err := app.wsServer.Shutdown(ctx); err != nil {
+		app.log.Error("websocket drain incomplete", logger.Err(err))
+	}
+	cancel()
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 
@@ -160,4 +286,21 @@ func (app *App) Stop() {
 	}()
 
 	wg.Wait()
+
+	close(app.stopStats)
+	app.log.Info("websocket send stats", slog.Any("stats", app.wsServer.Stats()))
+
+	if app.subscriber != nil {
+		app.subscriber.Stop()
+	}
+	if app.broker != nil {
+		if err := app.broker.Close(); err != nil {
+			app.log.Error("broker closed with error", logger.Err(err))
+		}
+	}
+	if app.presence != nil {
+		if err := app.presence.Close(); err != nil {
+			app.log.Error("presence store closed with error", logger.Err(err))
+		}
+	}
 }
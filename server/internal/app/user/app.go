@@ -24,7 +24,7 @@ type App struct {
 func New(log *slog.Logger, cfg *config.UserConfig) *App {
 	storage := mongo.New(log, mongodb.Timeout(cfg.Storage.Timeout), mongodb.URI(cfg.Storage.ConnectUri))
 	userService := user.NewUser(log, storage, storage)
-	grpcApp := grpc.New(log, userService, cfg.Port)
+	grpcApp := grpc.New(log, userService, cfg.Port, cfg.InternalPort)
 
 	return &App{
 		log:     log,
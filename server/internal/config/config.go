@@ -10,8 +10,9 @@ import (
 type Config struct {
 	Clients  `yaml:"clients"`
 	Services `yaml:"services" env-required:"true"`
-	Env      string `yaml:"env" env-default:"local"`
-	LogLevel string `yaml:"log_level" env-default:"info"`
+	Notifier NotifierConfig `yaml:"notifier"`
+	Env      string         `yaml:"env" env-default:"local"`
+	LogLevel string         `yaml:"log_level" env-default:"info"`
 }
 
 type GrpcConfig struct {
@@ -27,15 +28,25 @@ type Services struct {
 }
 
 type AuthConfig struct {
-	GrpcConfig `yaml:"grpc"`
-	Storage    MongoConfig   `yaml:"storage"`
-	TokenTTL   time.Duration `yaml:"token_ttl" env-default:"1h"`
-	Secret     string        `yaml:"secret" env-default:"supa-dupa-secret"`
+	GrpcConfig      `yaml:"grpc"`
+	Storage         MongoConfig        `yaml:"storage"`
+	TokenTTL        time.Duration      `yaml:"token_ttl" env-default:"15m"`
+	RefreshTokenTTL time.Duration      `yaml:"refresh_token_ttl" env-default:"720h"`
+	InternalPort    int                `yaml:"internal_port"`
+	JwksPort        int                `yaml:"jwks_port"`
+	Keys            []SigningKeyConfig `yaml:"keys"`
+	SigningKey      string             `yaml:"signing_key"`
+}
+
+type SigningKeyConfig struct {
+	Id   string `yaml:"kid"`
+	Path string `yaml:"path"`
 }
 
 type UserConfig struct {
-	GrpcConfig `yaml:"grpc"`
-	Storage    MongoConfig `yaml:"storage"`
+	GrpcConfig   `yaml:"grpc"`
+	InternalPort int         `yaml:"internal_port"`
+	Storage      MongoConfig `yaml:"storage"`
 }
 
 type ChatConfig struct {
@@ -55,16 +66,37 @@ type ScyllaConfig struct {
 }
 
 type FrontendConfig struct {
-	GrpcConfig   `yaml:"grpc"`
-	WsPort       int           `yaml:"ws_port"`
-	WsBasePath   string        `yaml:"ws_base_path" env-default:"ws"`
-	SendBuffSize int           `yaml:"send_buff_size" env-default:"128"`
-	RBuffSize    int           `yaml:"read_buff_size" env-default:"4096"`
-	WBuffSize    int           `yaml:"write_buff_size" env-default:"4096"`
-	HsTimeout    time.Duration `yaml:"hs_timeout" env-default:"30s"`
-	MsgLimit     int64         `yaml:"msg_limit" env-default:"4096"`
-	WriteWait    time.Duration `yaml:"write_wait" env-default:"5s"`
-	PongWait     time.Duration `yaml:"pong_wait" env-default:"5s"`
+	GrpcConfig     `yaml:"grpc"`
+	WsPort         int             `yaml:"ws_port"`
+	WsBasePath     string          `yaml:"ws_base_path" env-default:"ws"`
+	SendBuffSize   int             `yaml:"send_buff_size" env-default:"128"`
+	SendPolicy     string          `yaml:"send_policy" env-default:"disconnect"`
+	SendStatsEvery time.Duration   `yaml:"send_stats_interval" env-default:"1m"`
+	RBuffSize      int             `yaml:"read_buff_size" env-default:"4096"`
+	WBuffSize      int             `yaml:"write_buff_size" env-default:"4096"`
+	HsTimeout      time.Duration   `yaml:"hs_timeout" env-default:"30s"`
+	MsgLimit       int64           `yaml:"msg_limit" env-default:"4096"`
+	WriteWait      time.Duration   `yaml:"write_wait" env-default:"5s"`
+	PongWait       time.Duration   `yaml:"pong_wait" env-default:"5s"`
+	DrainTimeout   time.Duration   `yaml:"drain_timeout" env-default:"10s"`
+	TypingThrottle time.Duration   `yaml:"typing_throttle" env-default:"2s"`
+	RevocationTTL  time.Duration   `yaml:"revocation_ttl" env-default:"5s"`
+	KeysTTL        time.Duration   `yaml:"keys_ttl" env-default:"10m"`
+	PresenceTTL    time.Duration   `yaml:"presence_ttl" env-default:"1m"`
+	Concurrency    int             `yaml:"request_concurrency" env-default:"8"`
+	RateLimit      RateLimitConfig `yaml:"rate_limit"`
+}
+
+type RateLimitConfig struct {
+	// Upstream limits requests per upstream type name, e.g. U_SEND_MESSAGE
+	Upstream     map[string]LimitConfig `yaml:"upstream"`
+	Strikes      int                    `yaml:"strikes" env-default:"20"`
+	StrikeWindow time.Duration          `yaml:"strike_window" env-default:"1m"`
+}
+
+type LimitConfig struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
 }
 
 type Clients struct {
@@ -74,10 +106,21 @@ type Clients struct {
 	Frontend ClientConfig `yaml:"frontend"`
 }
 
+type NotifierConfig struct {
+	Backend       string `yaml:"backend" env-default:"grpc"`
+	BrokerAddress string `yaml:"broker_address"`
+}
+
+const (
+	NotifierBackendGrpc  = "grpc"
+	NotifierBackendRedis = "redis"
+)
+
 type ClientConfig struct {
-	Address      string        `yaml:"address"`
-	Timeout      time.Duration `yaml:"timeout"`
-	RetriesCount int           `yaml:"retries_count"`
+	Address         string        `yaml:"address"`
+	InternalAddress string        `yaml:"internal_address"`
+	Timeout         time.Duration `yaml:"timeout"`
+	RetriesCount    int           `yaml:"retries_count"`
 }
 
 func MustLoad() *Config {
@@ -11,6 +11,8 @@ func ChatToDTO(c *model.Chat) *protocolv1.Chat {
 		Id:          c.Id,
 		Type:        protocolv1.ChatType(c.Type),
 		ChatMembers: make([]*protocolv1.ChatMember, 0, len(c.Members)),
+		Title:       c.Title,
+		OwnerUid:    c.Owner,
 	}
 
 	for _, member := range c.Members {
@@ -26,6 +28,8 @@ func ChatFromDTO(c *protocolv1.Chat) *model.Chat {
 		Id:      c.GetId(),
 		Type:    model.ChatType(c.GetType()),
 		Members: make([]model.ChatMember, 0, len(c.GetChatMembers())),
+		Title:   c.GetTitle(),
+		Owner:   c.GetOwnerUid(),
 	}
 
 	for _, member := range c.GetChatMembers() {
@@ -56,23 +60,39 @@ func ChatsFromDTO(chatsDTO []*protocolv1.Chat) []model.Chat {
 }
 
 func ChatMessageToDTO(c *model.ChatMessage) *protocolv1.ChatMessage {
-	return &protocolv1.ChatMessage{
-		Id:        c.Id,
-		Cid:       c.Cid,
-		Uid:       c.Uid,
-		Text:      c.Text,
-		Timestamp: c.Timestamp,
+	proto := protocolv1.ChatMessage{
+		Id:         c.Id,
+		Cid:        c.Cid,
+		Uid:        c.Uid,
+		Text:       c.Text,
+		Timestamp:  c.Timestamp,
+		EditedAt:   c.EditedAt,
+		Deleted:    c.Deleted,
+		ReplyToMid: c.ReplyTo,
 	}
+	if c.Forward != nil {
+		proto.ForwardedFrom = &protocolv1.ForwardedFrom{Cid: c.Forward.Cid, Mid: c.Forward.Mid, Uid: c.Forward.Uid}
+	}
+
+	return &proto
 }
 
 func ChatMessageFromDTO(c *protocolv1.ChatMessage) *model.ChatMessage {
-	return &model.ChatMessage{
+	msg := model.ChatMessage{
 		Id:        c.GetId(),
 		Cid:       c.GetCid(),
 		Uid:       c.GetUid(),
 		Text:      c.GetText(),
 		Timestamp: c.GetTimestamp(),
+		EditedAt:  c.GetEditedAt(),
+		Deleted:   c.GetDeleted(),
+	}
+	msg.ReplyTo = c.GetReplyToMid()
+	if fwd := c.GetForwardedFrom(); fwd != nil {
+		msg.Forward = &model.ForwardedFrom{Cid: fwd.GetCid(), Mid: fwd.GetMid(), Uid: fwd.GetUid()}
 	}
+
+	return &msg
 }
 
 func ChatMessagesToDTO(c []model.ChatMessage) []*protocolv1.ChatMessage {
@@ -86,3 +106,135 @@ func ChatMessagesFromDTO(c []*protocolv1.ChatMessage) []model.ChatMessage {
 		return *ChatMessageFromDTO(msg)
 	})
 }
+
+func MessageHitToDTO(h *model.MessageHit) *protocolv1.MessageHit {
+	return &protocolv1.MessageHit{
+		Message: ChatMessageToDTO(&h.Message),
+		Snippet: h.Snippet,
+		Highlights: cutil.Map(h.Highlights, func(hl model.Highlight) *protocolv1.Highlight {
+			return &protocolv1.Highlight{Start: int32(hl.Start), End: int32(hl.End)}
+		}),
+	}
+}
+
+func MessageHitFromDTO(h *protocolv1.MessageHit) *model.MessageHit {
+	return &model.MessageHit{
+		Message: *ChatMessageFromDTO(h.GetMessage()),
+		Snippet: h.GetSnippet(),
+		Highlights: cutil.Map(h.GetHighlights(), func(hl *protocolv1.Highlight) model.Highlight {
+			return model.Highlight{Start: int(hl.GetStart()), End: int(hl.GetEnd())}
+		}),
+	}
+}
+
+func MessageHitsToDTO(h []model.MessageHit) []*protocolv1.MessageHit {
+	return cutil.Map(h, func(hit model.MessageHit) *protocolv1.MessageHit {
+		return MessageHitToDTO(&hit)
+	})
+}
+
+func MessageHitsFromDTO(h []*protocolv1.MessageHit) []model.MessageHit {
+	return cutil.Map(h, func(hit *protocolv1.MessageHit) model.MessageHit {
+		return *MessageHitFromDTO(hit)
+	})
+}
+
+func ChatStateToDTO(c *model.ChatState) *protocolv1.ChatState {
+	proto := protocolv1.ChatState{
+		Cid:         c.Cid,
+		LastReadMid: c.LastRead,
+		Unread:      c.Unread,
+	}
+	if c.LastMessage != nil {
+		proto.LastMessage = ChatMessageToDTO(c.LastMessage)
+	}
+
+	return &proto
+}
+
+func ChatStateFromDTO(c *protocolv1.ChatState) *model.ChatState {
+	state := model.ChatState{
+		Cid:      c.GetCid(),
+		LastRead: c.GetLastReadMid(),
+		Unread:   c.GetUnread(),
+	}
+	if c.GetLastMessage() != nil {
+		state.LastMessage = ChatMessageFromDTO(c.GetLastMessage())
+	}
+
+	return &state
+}
+
+func ChatStatesToDTO(c []model.ChatState) []*protocolv1.ChatState {
+	return cutil.Map(c, func(state model.ChatState) *protocolv1.ChatState {
+		return ChatStateToDTO(&state)
+	})
+}
+
+func ChatStatesFromDTO(c []*protocolv1.ChatState) []model.ChatState {
+	return cutil.Map(c, func(state *protocolv1.ChatState) model.ChatState {
+		return *ChatStateFromDTO(state)
+	})
+}
+
+func ReadReceiptToDTO(r *model.ReadReceipt) *protocolv1.ReadReceipt {
+	return &protocolv1.ReadReceipt{
+		Cid: r.Cid,
+		Uid: r.Uid,
+		Mid: r.Mid,
+	}
+}
+
+func ReadReceiptFromDTO(r *protocolv1.ReadReceipt) *model.ReadReceipt {
+	return &model.ReadReceipt{
+		Cid: r.GetCid(),
+		Uid: r.GetUid(),
+		Mid: r.GetMid(),
+	}
+}
+
+func ReactionUpdateToDTO(u *model.ReactionUpdate) *protocolv1.ReactionUpdate {
+	return &protocolv1.ReactionUpdate{
+		Cid:   u.Cid,
+		Mid:   u.Mid,
+		Uid:   u.Uid,
+		Emoji: u.Emoji,
+		Added: u.Added,
+		Count: u.Count,
+	}
+}
+
+func ReactionUpdateFromDTO(u *protocolv1.ReactionUpdate) *model.ReactionUpdate {
+	return &model.ReactionUpdate{
+		Reaction: model.Reaction{
+			Cid:   u.GetCid(),
+			Mid:   u.GetMid(),
+			Uid:   u.GetUid(),
+			Emoji: u.GetEmoji(),
+		},
+		Added: u.GetAdded(),
+		Count: u.GetCount(),
+	}
+}
+
+func MessageReactionsToDTO(r []model.MessageReactions) []*protocolv1.MessageReactions {
+	return cutil.Map(r, func(mr model.MessageReactions) *protocolv1.MessageReactions {
+		return &protocolv1.MessageReactions{
+			Mid: mr.Mid,
+			Counts: cutil.Map(mr.Counts, func(rc model.ReactionCount) *protocolv1.ReactionCount {
+				return &protocolv1.ReactionCount{Emoji: rc.Emoji, Count: rc.Count}
+			}),
+		}
+	})
+}
+
+func MessageReactionsFromDTO(r []*protocolv1.MessageReactions) []model.MessageReactions {
+	return cutil.Map(r, func(mr *protocolv1.MessageReactions) model.MessageReactions {
+		return model.MessageReactions{
+			Mid: mr.GetMid(),
+			Counts: cutil.Map(mr.GetCounts(), func(rc *protocolv1.ReactionCount) model.ReactionCount {
+				return model.ReactionCount{Emoji: rc.GetEmoji(), Count: rc.GetCount()}
+			}),
+		}
+	})
+}
@@ -8,17 +8,21 @@ import (
 
 func UserToDTO(usr *model.User) *protocolv1.User {
 	return &protocolv1.User{
-		Id:    usr.Id,
-		Email: usr.Email,
-		Bio:   usr.Bio,
+		Id:          usr.Id,
+		Email:       usr.Email,
+		Bio:         usr.Bio,
+		DisplayName: usr.DisplayName,
+		Avatar:      usr.Avatar,
 	}
 }
 
 func UserFromDTO(usr *protocolv1.User) *model.User {
 	return &model.User{
-		Id:    usr.Id,
-		Email: usr.Email,
-		Bio:   usr.Bio,
+		Id:          usr.GetId(),
+		Email:       usr.GetEmail(),
+		Bio:         usr.GetBio(),
+		DisplayName: usr.GetDisplayName(),
+		Avatar:      usr.GetAvatar(),
 	}
 }
 
@@ -9,12 +9,15 @@ type ChatType int32
 const (
 	CTUnknown ChatType = iota
 	CTPersonal
+	CTGroup
 )
 
 type Chat struct {
 	Id      []byte       `bson:"_id"`
 	Type    ChatType     `bson:"type"`
 	Members []ChatMember `bson:"members,omitempty"`
+	Title   string       `bson:"title,omitempty"`
+	Owner   []byte       `bson:"owner,omitempty"`
 }
 
 func NewPersonalChat(cid []byte, uids ...[]byte) *Chat {
@@ -29,6 +32,20 @@ func NewPersonalChat(cid []byte, uids ...[]byte) *Chat {
 	}
 }
 
+func NewGroupChat(cid []byte, title string, owner []byte, uids ...[]byte) *Chat {
+	return &Chat{
+		Id:    cid,
+		Type:  CTGroup,
+		Title: title,
+		Owner: owner,
+		Members: cutil.Map(uids, func(uid []byte) ChatMember {
+			return ChatMember{
+				Uid: uid,
+			}
+		}),
+	}
+}
+
 type ChatMember struct {
 	Uid []byte `bson:"uid"`
 }
@@ -41,7 +58,11 @@ type UserChats struct {
 type UserChat struct {
 	Cid  []byte   `bson:"cid"`
 	Type ChatType `bson:"type"`
-	Uid  []byte   `bson:"uid"`
+	Uid  []byte   `bson:"uid,omitempty"`
+
+	LastRead   []byte `bson:"last_read,omitempty"`
+	LastReadAt int64  `bson:"last_read_at,omitempty"`
+	Unread     int64  `bson:"unread,omitempty"`
 }
 
 type ChatMessage struct {
@@ -50,4 +71,84 @@ type ChatMessage struct {
 	Uid       []byte
 	Text      string
 	Timestamp int64
+	EditedAt  int64
+	Deleted   bool
+	MessageRefs
+}
+
+// MessageRefs links a message to the message of the same chat it replies to
+// or to the original it forwards.
+type MessageRefs struct {
+	ReplyTo []byte
+	Forward *ForwardedFrom
+}
+
+type ForwardedFrom struct {
+	Cid []byte
+	Mid []byte
+	Uid []byte
+}
+
+// MessagesPage carries the reaction counts of its messages, messages without
+// reactions are left out of Reactions.
+type MessagesPage struct {
+	Messages  []ChatMessage
+	Next      []byte
+	Reactions []MessageReactions
+}
+
+// MessageHit is a message matching a search with a snippet of its text.
+// Highlights are byte ranges of the matched words within Snippet.
+type MessageHit struct {
+	Message    ChatMessage
+	Snippet    string
+	Highlights []Highlight
+}
+
+type Highlight struct {
+	Start int
+	End   int
+}
+
+type MessageHitsPage struct {
+	Hits []MessageHit
+	Next []byte
+}
+
+type ChatState struct {
+	Cid         []byte
+	LastRead    []byte
+	Unread      int64
+	LastMessage *ChatMessage
+}
+
+type ReadReceipt struct {
+	Cid []byte
+	Uid []byte
+	Mid []byte
+}
+
+type Reaction struct {
+	Cid   []byte
+	Mid   []byte
+	Uid   []byte
+	Emoji string
+}
+
+// ReactionUpdate is a reaction added or removed, Count is the resulting number
+// of reactions with its emoji on the message.
+type ReactionUpdate struct {
+	Reaction
+	Added bool
+	Count int64
+}
+
+type ReactionCount struct {
+	Emoji string
+	Count int64
+}
+
+type MessageReactions struct {
+	Mid    []byte
+	Counts []ReactionCount
 }
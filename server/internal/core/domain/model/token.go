@@ -0,0 +1,30 @@
+package model
+
+import (
+	"crypto"
+	"time"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+type TokenPair struct {
+	Access  string
+	Refresh string
+}
+
+type TokenClaims struct {
+	Id        string
+	Uid       []byte
+	Email     string
+	Type      string
+	ExpiresAt time.Time
+}
+
+type PublicKey struct {
+	Id  string
+	Alg string
+	Key crypto.PublicKey
+}
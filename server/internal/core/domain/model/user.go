@@ -1,9 +1,38 @@
 package model
 
 type User struct {
-	Id    []byte `bson:"_id"`
-	Email string `bson:"email"`
-	Bio   string `bson:"bio"`
+	Id          []byte `bson:"_id"`
+	Email       string `bson:"email"`
+	Bio         string `bson:"bio"`
+	DisplayName string `bson:"display_name,omitempty"`
+	Avatar      string `bson:"avatar,omitempty"`
+}
+
+// UsersPage is a page of users ordered by id, Next is the cursor of the
+// following page and is empty on the last one.
+type UsersPage struct {
+	Users []User
+	Next  []byte
+}
+
+// ProfileUpdate holds the profile fields to change, nil ones are kept.
+type ProfileUpdate struct {
+	DisplayName *string
+	Avatar      *string
+	Bio         *string
+}
+
+// Apply sets the fields of usr that are set in upd.
+func (upd ProfileUpdate) Apply(usr *User) {
+	if upd.DisplayName != nil {
+		usr.DisplayName = *upd.DisplayName
+	}
+	if upd.Avatar != nil {
+		usr.Avatar = *upd.Avatar
+	}
+	if upd.Bio != nil {
+		usr.Bio = *upd.Bio
+	}
 }
 
 type UserCredentials struct {
@@ -0,0 +1,29 @@
+package modelutil
+
+import (
+	"bytes"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"slices"
+)
+
+func IsMember(chat *model.Chat, uid []byte) bool {
+	return slices.ContainsFunc(chat.Members, func(member model.ChatMember) bool {
+		return bytes.Equal(member.Uid, uid)
+	})
+}
+
+// OtherMembers returns uids of the chat members except uid.
+func OtherMembers(chat *model.Chat, uid []byte) [][]byte {
+	uids := make([][]byte, 0, len(chat.Members))
+	for _, member := range chat.Members {
+		if !bytes.Equal(member.Uid, uid) {
+			uids = append(uids, member.Uid)
+		}
+	}
+
+	return uids
+}
+
+func IsOwner(chat *model.Chat, uid []byte) bool {
+	return len(chat.Owner) != 0 && bytes.Equal(chat.Owner, uid)
+}
@@ -8,7 +8,7 @@ import (
 
 func HaveChatWith(userChats *model.UserChats, uid []byte) bool {
 	return slices.ContainsFunc(userChats.Chats, func(userChat model.UserChat) bool {
-		return bytes.Equal(userChat.Uid, uid)
+		return userChat.Type == model.CTPersonal && bytes.Equal(userChat.Uid, uid)
 	})
 }
 
@@ -18,3 +18,10 @@ func AddPersonalChat(userChats *model.UserChats, cid []byte, uid []byte) *model.
 
 	return userChat
 }
+
+func AddGroupChat(userChats *model.UserChats, cid []byte) *model.UserChat {
+	userChat := &model.UserChat{Cid: cid, Type: model.CTGroup}
+	userChats.Chats = append(userChats.Chats, *userChat)
+
+	return userChat
+}
@@ -0,0 +1,168 @@
+package validate
+
+import (
+	"errors"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxEmailLen       = 254
+	minPasswordLen    = 8
+	maxPasswordLen    = 72 // bcrypt limit
+	minPasswordGroups = 2
+	maxDisplayNameLen = 64  // runes
+	maxBioLen         = 500 // runes
+	maxAvatarLen      = 2048
+	maxEmojiLen       = 32
+
+	zeroWidthJoiner = '\u200d'
+)
+
+var (
+	ErrInvalidEmail = errors.New("invalid email")
+	ErrWeakPassword = errors.New("weak password")
+	ErrDisplayName  = errors.New("invalid display name")
+	ErrBio          = errors.New("invalid bio")
+	ErrAvatar       = errors.New("invalid avatar")
+	ErrEmoji        = errors.New("invalid emoji")
+)
+
+// Email accepts a bare address, no display name or surrounding spaces.
+func Email(email string) error {
+	if len(email) > maxEmailLen {
+		return ErrInvalidEmail
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+	if at := strings.LastIndexByte(email, '@'); !strings.Contains(email[at+1:], ".") {
+		return ErrInvalidEmail
+	}
+
+	return nil
+}
+
+// NormalizeEmail lower cases email, accounts are unique regardless of its case.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(email)
+}
+
+// Password requires 8 to 72 bytes mixing at least two of lower case, upper
+// case, digits and other characters.
+func Password(password string) error {
+	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
+		return ErrWeakPassword
+	}
+
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	groups := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			groups++
+		}
+	}
+	if groups < minPasswordGroups {
+		return ErrWeakPassword
+	}
+
+	return nil
+}
+
+// DisplayName accepts up to 64 printable characters without surrounding
+// spaces, empty clears the name.
+func DisplayName(name string) error {
+	if utf8.RuneCountInString(name) > maxDisplayNameLen || strings.TrimSpace(name) != name {
+		return ErrDisplayName
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return ErrDisplayName
+		}
+	}
+
+	return nil
+}
+
+func Bio(bio string) error {
+	if !utf8.ValidString(bio) || utf8.RuneCountInString(bio) > maxBioLen {
+		return ErrBio
+	}
+
+	return nil
+}
+
+// Avatar accepts an absolute http or https url, empty clears the avatar.
+func Avatar(avatar string) error {
+	if avatar == "" {
+		return nil
+	}
+	if len(avatar) > maxAvatarLen {
+		return ErrAvatar
+	}
+
+	u, err := url.Parse(avatar)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrAvatar
+	}
+
+	return nil
+}
+
+// Emoji accepts up to 32 bytes of graphic non ASCII characters without
+// letters or digits, joined sequences and flags included.
+func Emoji(emoji string) error {
+	if emoji == "" || len(emoji) > maxEmojiLen || !utf8.ValidString(emoji) {
+		return ErrEmoji
+	}
+	for _, r := range emoji {
+		if r == zeroWidthJoiner {
+			continue
+		}
+		if r <= unicode.MaxASCII || unicode.IsLetter(r) || unicode.IsDigit(r) || !unicode.IsGraphic(r) {
+			return ErrEmoji
+		}
+	}
+
+	return nil
+}
+
+// Profile validates the fields set in upd.
+func Profile(upd model.ProfileUpdate) error {
+	if upd.DisplayName != nil {
+		if err := DisplayName(*upd.DisplayName); err != nil {
+			return err
+		}
+	}
+	if upd.Avatar != nil {
+		if err := Avatar(*upd.Avatar); err != nil {
+			return err
+		}
+	}
+	if upd.Bio != nil {
+		if err := Bio(*upd.Bio); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEmail(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"test@example.com", "first.last+tag@sub.example.org"}
+	invalid := []string{
+		"",
+		"test",
+		"test@",
+		"@example.com",
+		"test@localhost",
+		"Test <test@example.com>",
+		" test@example.com",
+		strings.Repeat("a", 250) + "@example.com",
+	}
+
+	for _, email := range valid {
+		assert.NoError(t, Email(email), email)
+	}
+	for _, email := range invalid {
+		assert.ErrorIs(t, Email(email), ErrInvalidEmail, email)
+	}
+}
+
+func TestPassword(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"passw0rd", "Password", "pass word", "пароль123"}
+	invalid := []string{"", "short1", "password", "12345678", strings.Repeat("a1", 40)}
+
+	for _, password := range valid {
+		assert.NoError(t, Password(password), password)
+	}
+	for _, password := range invalid {
+		assert.ErrorIs(t, Password(password), ErrWeakPassword, password)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) *string { return &s }
+
+	assert.NoError(t, Profile(model.ProfileUpdate{}))
+	assert.NoError(t, Profile(model.ProfileUpdate{
+		DisplayName: str("Alice Smith"),
+		Avatar:      str("https://cdn.example.com/a.png"),
+		Bio:         str("hi"),
+	}))
+	assert.NoError(t, Profile(model.ProfileUpdate{DisplayName: str(""), Avatar: str(""), Bio: str("")}), "empty clears")
+
+	for _, name := range []string{" Alice", "Alice\n", strings.Repeat("a", 65)} {
+		assert.ErrorIs(t, Profile(model.ProfileUpdate{DisplayName: str(name)}), ErrDisplayName, name)
+	}
+	for _, avatar := range []string{"a.png", "ftp://example.com/a.png", "https://"} {
+		assert.ErrorIs(t, Profile(model.ProfileUpdate{Avatar: str(avatar)}), ErrAvatar, avatar)
+	}
+	assert.ErrorIs(t, Profile(model.ProfileUpdate{Bio: str(strings.Repeat("a", 501))}), ErrBio)
+}
+
+func TestEmoji(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"👍", "❤️", "👍🏽", "👨‍👩‍👧", "🇺🇦"}
+	invalid := []string{"", "+1", ":)", "ok", "я", "👍 ", "\u200b", strings.Repeat("👍", 9)}
+
+	for _, emoji := range valid {
+		assert.NoError(t, Emoji(emoji), emoji)
+	}
+	for _, emoji := range invalid {
+		assert.ErrorIs(t, Emoji(emoji), ErrEmoji, emoji)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/auth"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
+	"github.com/dvid-messanger/internal/pkg/jwt"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"golang.org/x/crypto/bcrypt"
 	"log/slog"
@@ -13,15 +15,21 @@ import (
 )
 
 type Service struct {
-	log      *slog.Logger
-	us       UserSaver
-	up       UserProvider
-	tm       TokenMaker
-	tokenTtl time.Duration
+	log        *slog.Logger
+	us         UserSaver
+	up         UserProvider
+	tm         TokenMaker
+	tv         TokenVerifier
+	tr         TokenRevoker
+	rp         RevocationProvider
+	kp         KeyProvider
+	accessTtl  time.Duration
+	refreshTtl time.Duration
 }
 
 type UserSaver interface {
 	Save(ctx context.Context, uid []byte, email string, passHash []byte) (model.UserCredentials, error)
+	Delete(ctx context.Context, uid []byte) error
 }
 
 type UserProvider interface {
@@ -29,58 +37,217 @@ type UserProvider interface {
 }
 
 type TokenMaker interface {
-	MakeToken(user model.UserCredentials, duration time.Duration) (string, error)
+	MakeToken(user model.UserCredentials, typ string, duration time.Duration) (string, error)
+}
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*model.TokenClaims, error)
+}
+
+type TokenRevoker interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) (bool, error)
+}
+
+type RevocationProvider interface {
+	Revoked(ctx context.Context, jti string) (bool, error)
+}
+
+type KeyProvider interface {
+	PublicKeys() []model.PublicKey
 }
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrUserExists         = errors.New("user already exists")
+	ErrInvalidToken       = errors.New("invalid token")
 )
 
-func NewService(log *slog.Logger, us UserSaver, up UserProvider, tm TokenMaker, tokenTtl time.Duration) *Service {
+// Deps are the ports the service works through, the ones the calls in use
+// never reach may be left nil.
+type Deps struct {
+	UserSaver          UserSaver
+	UserProvider       UserProvider
+	TokenMaker         TokenMaker
+	TokenVerifier      TokenVerifier
+	TokenRevoker       TokenRevoker
+	RevocationProvider RevocationProvider
+	KeyProvider        KeyProvider
+}
+
+func NewService(log *slog.Logger, deps Deps, accessTtl time.Duration, refreshTtl time.Duration) *Service {
 	return &Service{
-		log:      log,
-		us:       us,
-		up:       up,
-		tm:       tm,
-		tokenTtl: tokenTtl,
+		log:        log,
+		us:         deps.UserSaver,
+		up:         deps.UserProvider,
+		tm:         deps.TokenMaker,
+		tv:         deps.TokenVerifier,
+		tr:         deps.TokenRevoker,
+		rp:         deps.RevocationProvider,
+		kp:         deps.KeyProvider,
+		accessTtl:  accessTtl,
+		refreshTtl: refreshTtl,
 	}
 }
 
-func (a *Service) Login(ctx context.Context, email string, password string) (string, error) {
+func (a *Service) Login(ctx context.Context, email string, password string) (*model.TokenPair, error) {
 	const op = "auth.Login"
 	log := a.log.With(slog.String("op", op))
 
 	log.Debug("attempt to login user")
 
-	user, err := a.up.User(ctx, email)
+	user, err := a.up.User(ctx, validate.NormalizeEmail(email))
 	if err != nil {
 		if errors.Is(err, auth.ErrUserNotFound) {
 			a.log.Debug("user not found", logger.Err(err))
 
-			return "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
 		}
 
 		a.log.Error("failed to get user", logger.Err(err))
-		return "", fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if err = bcrypt.CompareHashAndPassword(user.PassHash, []byte(password)); err != nil {
 		a.log.Debug("invalid credentials", logger.Err(err))
 
-		return "", fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidCredentials)
 	}
 
 	log.Debug("user logged in successfully")
 
-	token, err := a.tm.MakeToken(user, a.tokenTtl)
+	pair, err := a.makePair(user)
+	if err != nil {
+		a.log.Error("failed to make tokens", logger.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pair, nil
+}
+
+func (a *Service) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	const op = "auth.Refresh"
+	log := a.log.With(slog.String("op", op))
+
+	log.Debug("attempt to refresh tokens")
+
+	claims, err := a.verify(ctx, refreshToken)
+	if err != nil {
+		log.Debug("failed to verify refresh token", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if claims.Type != model.TokenTypeRefresh {
+		log.Debug("not a refresh token", slog.String("typ", claims.Type))
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	revoked, err := a.tr.Revoke(ctx, claims.Id, claims.ExpiresAt)
+	if err != nil {
+		log.Error("failed to revoke refresh token", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !revoked {
+		log.Debug("refresh token already used")
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	user, err := a.up.User(ctx, claims.Email)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			log.Debug("user not found", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to get user", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pair, err := a.makePair(user)
+	if err != nil {
+		log.Error("failed to make tokens", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("tokens refreshed")
+
+	return pair, nil
+}
+
+func (a *Service) Logout(ctx context.Context, accessToken string, refreshToken string) error {
+	const op = "auth.Logout"
+	log := a.log.With(slog.String("op", op))
+
+	log.Debug("revoking tokens")
+
+	for _, token := range []string{accessToken, refreshToken} {
+		if token == "" {
+			continue
+		}
+
+		claims, err := a.verify(ctx, token)
+		if err != nil {
+			if errors.Is(err, ErrInvalidToken) {
+				log.Debug("skipping unusable token", logger.Err(err))
+				continue
+			}
+
+			log.Error("failed to verify token", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if _, err = a.tr.Revoke(ctx, claims.Id, claims.ExpiresAt); err != nil {
+			log.Error("failed to revoke token", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Debug("tokens revoked")
+
+	return nil
+}
+
+func (a *Service) Revoked(ctx context.Context, jti string) (bool, error) {
+	const op = "auth.Revoked"
+	log := a.log.With(slog.String("op", op))
+
+	revoked, err := a.rp.Revoked(ctx, jti)
+	if err != nil {
+		log.Error("failed to check revocation", logger.Err(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revoked, nil
+}
+
+func (a *Service) PublicKeys(_ context.Context) ([]model.PublicKey, error) {
+	return a.kp.PublicKeys(), nil
+}
+
+func (a *Service) verify(ctx context.Context, token string) (*model.TokenClaims, error) {
+	claims, err := a.tv.Verify(ctx, token)
 	if err != nil {
-		a.log.Error("failed to make token", logger.Err(err))
+		if errors.Is(err, jwt.ErrTokenInvalid) || errors.Is(err, jwt.ErrTokenRevoked) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
 
-		return "", fmt.Errorf("%s: %w", op, err)
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (a *Service) makePair(user model.UserCredentials) (*model.TokenPair, error) {
+	access, err := a.tm.MakeToken(user, model.TokenTypeAccess, a.accessTtl)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := a.tm.MakeToken(user, model.TokenTypeRefresh, a.refreshTtl)
+	if err != nil {
+		return nil, err
 	}
 
-	return token, nil
+	return &model.TokenPair{Access: access, Refresh: refresh}, nil
 }
 
 func (a *Service) Create(ctx context.Context, uid []byte, email string, password string) ([]byte, error) {
@@ -112,3 +279,25 @@ func (a *Service) Create(ctx context.Context, uid []byte, email string, password
 
 	return userCred.Id, nil
 }
+
+// Delete removes the credentials of uid, missing credentials are not an error.
+func (a *Service) Delete(ctx context.Context, uid []byte) error {
+	const op = "auth.Delete"
+	log := a.log.With(slog.String("op", op))
+
+	log.Debug("deleting")
+
+	if err := a.us.Delete(ctx, uid); err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			log.Debug("already deleted")
+			return nil
+		}
+
+		log.Error("failed to delete user", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("deleted")
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/auth"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/jwt"
 	"github.com/dvid-messanger/test/mocks/mock_auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,7 +28,7 @@ func TestLogin(t *testing.T) {
 		require.NoError(t, err)
 
 		mockUserProvider := &mock_auth.MockUserProvider{}
-		mockUserProvider.On("User", mock.Anything, mock.Anything).Return(
+		mockUserProvider.On("User", mock.Anything, "test@example.com").Return(
 			model.UserCredentials{
 				Id:       []byte("mockUserID"),
 				Email:    "test@example.com",
@@ -35,13 +36,18 @@ func TestLogin(t *testing.T) {
 			}, nil)
 
 		mockTokenMaker := &mock_auth.MockTokenMaker{}
-		mockTokenMaker.On("MakeToken", mock.Anything, mock.Anything).Return("mockToken", nil)
+		mockTokenMaker.On("MakeToken", mock.Anything, model.TokenTypeAccess, time.Hour).Return("mockToken", nil)
+		mockTokenMaker.On("MakeToken", mock.Anything, model.TokenTypeRefresh, 24*time.Hour).Return("mockRefresh", nil)
 
-		service := NewService(log, nil, mockUserProvider, mockTokenMaker, time.Hour)
+		service := NewService(log, Deps{
+			UserProvider: mockUserProvider,
+			TokenMaker:   mockTokenMaker,
+		}, time.Hour, 24*time.Hour)
 
-		token, err := service.Login(context.Background(), "test@example.com", "password")
+		pair, err := service.Login(context.Background(), "Test@Example.com", "password")
 		require.NoError(t, err)
-		assert.Equal(t, "mockToken", token)
+		assert.Equal(t, "mockToken", pair.Access)
+		assert.Equal(t, "mockRefresh", pair.Refresh)
 	})
 	t.Run("InvalidCredentialsError", func(t *testing.T) {
 		t.Parallel()
@@ -50,7 +56,7 @@ func TestLogin(t *testing.T) {
 		mockUserProvider.On("User", mock.Anything, "test@example.com").Return(
 			model.UserCredentials{}, auth.ErrUserNotFound)
 
-		service := NewService(log, nil, mockUserProvider, nil, time.Hour)
+		service := NewService(log, Deps{UserProvider: mockUserProvider}, time.Hour, time.Hour)
 
 		_, err := service.Login(context.Background(), "test@example.com", "wrongpassword")
 		assert.ErrorIs(t, err, ErrInvalidCredentials)
@@ -74,10 +80,10 @@ func TestCreate(t *testing.T) {
 				PassHash: passHash, // password: "password"
 			}, nil)
 
-		service := NewService(log, mockUserSaver, nil, nil, time.Hour)
+		service := NewService(log, Deps{UserSaver: mockUserSaver}, time.Hour, time.Hour)
 
 		uid := []byte("mockUserID")
-		userID, err := service.Create(context.Background(), uid, "newuser@example.com", "password")
+		userID, err := service.Create(context.Background(), uid, "newuser@example.com", "passw0rd")
 		require.NoError(t, err)
 		assert.Equal(t, uid, userID)
 	})
@@ -88,10 +94,119 @@ func TestCreate(t *testing.T) {
 		mockUserSaver.On("Save", mock.Anything, mock.Anything, "test@example.com", mock.Anything).Return(
 			model.UserCredentials{}, auth.ErrUserExists)
 
-		service := NewService(log, mockUserSaver, nil, nil, time.Hour)
+		service := NewService(log, Deps{UserSaver: mockUserSaver}, time.Hour, time.Hour)
 
 		uid := []byte("mockUserID")
-		_, err := service.Create(context.Background(), uid, "test@example.com", "password")
+		_, err := service.Create(context.Background(), uid, "test@example.com", "passw0rd")
 		assert.ErrorIs(t, err, ErrUserExists)
 	})
 }
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour)
+	refreshClaims := &model.TokenClaims{
+		Id:        "jti",
+		Uid:       []byte("mockUserID"),
+		Email:     "test@example.com",
+		Type:      model.TokenTypeRefresh,
+		ExpiresAt: expiresAt,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockTokenVerifier := &mock_auth.MockTokenVerifier{}
+		mockTokenVerifier.On("Verify", mock.Anything, "refresh").Return(refreshClaims, nil)
+
+		mockTokenRevoker := &mock_auth.MockTokenRevoker{}
+		mockTokenRevoker.On("Revoke", mock.Anything, "jti", expiresAt).Return(true, nil)
+
+		mockUserProvider := &mock_auth.MockUserProvider{}
+		mockUserProvider.On("User", mock.Anything, "test@example.com").Return(
+			model.UserCredentials{Id: []byte("mockUserID"), Email: "test@example.com"}, nil)
+
+		mockTokenMaker := &mock_auth.MockTokenMaker{}
+		mockTokenMaker.On("MakeToken", mock.Anything, model.TokenTypeAccess, mock.Anything).Return("newToken", nil)
+		mockTokenMaker.On("MakeToken", mock.Anything, model.TokenTypeRefresh, mock.Anything).Return("newRefresh", nil)
+
+		service := NewService(log, Deps{
+			UserProvider:  mockUserProvider,
+			TokenMaker:    mockTokenMaker,
+			TokenVerifier: mockTokenVerifier,
+			TokenRevoker:  mockTokenRevoker,
+		}, time.Hour, time.Hour)
+
+		pair, err := service.Refresh(context.Background(), "refresh")
+		require.NoError(t, err)
+		assert.Equal(t, &model.TokenPair{Access: "newToken", Refresh: "newRefresh"}, pair)
+		mockTokenRevoker.AssertExpectations(t)
+	})
+	t.Run("InvalidTokenError", func(t *testing.T) {
+		t.Parallel()
+
+		mockTokenVerifier := &mock_auth.MockTokenVerifier{}
+		mockTokenVerifier.On("Verify", mock.Anything, "refresh").Return(nil, jwt.ErrTokenRevoked)
+
+		service := NewService(log, Deps{TokenVerifier: mockTokenVerifier}, time.Hour, time.Hour)
+
+		_, err := service.Refresh(context.Background(), "refresh")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+	t.Run("AccessTokenError", func(t *testing.T) {
+		t.Parallel()
+
+		accessClaims := *refreshClaims
+		accessClaims.Type = model.TokenTypeAccess
+
+		mockTokenVerifier := &mock_auth.MockTokenVerifier{}
+		mockTokenVerifier.On("Verify", mock.Anything, "access").Return(&accessClaims, nil)
+
+		service := NewService(log, Deps{TokenVerifier: mockTokenVerifier}, time.Hour, time.Hour)
+
+		_, err := service.Refresh(context.Background(), "access")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+	t.Run("ReusedTokenError", func(t *testing.T) {
+		t.Parallel()
+
+		mockTokenVerifier := &mock_auth.MockTokenVerifier{}
+		mockTokenVerifier.On("Verify", mock.Anything, "refresh").Return(refreshClaims, nil)
+
+		mockTokenRevoker := &mock_auth.MockTokenRevoker{}
+		mockTokenRevoker.On("Revoke", mock.Anything, "jti", expiresAt).Return(false, nil)
+
+		service := NewService(log, Deps{
+			TokenVerifier: mockTokenVerifier,
+			TokenRevoker:  mockTokenRevoker,
+		}, time.Hour, time.Hour)
+
+		_, err := service.Refresh(context.Background(), "refresh")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenVerifier := &mock_auth.MockTokenVerifier{}
+	mockTokenVerifier.On("Verify", mock.Anything, "access").Return(
+		&model.TokenClaims{Id: "access-jti", Type: model.TokenTypeAccess, ExpiresAt: expiresAt}, nil)
+	mockTokenVerifier.On("Verify", mock.Anything, "refresh").Return(nil, jwt.ErrTokenInvalid)
+
+	mockTokenRevoker := &mock_auth.MockTokenRevoker{}
+	mockTokenRevoker.On("Revoke", mock.Anything, "access-jti", expiresAt).Return(true, nil)
+
+	service := NewService(log, Deps{
+		TokenVerifier: mockTokenVerifier,
+		TokenRevoker:  mockTokenRevoker,
+	}, time.Hour, time.Hour)
+
+	err := service.Logout(context.Background(), "access", "refresh")
+	require.NoError(t, err)
+	mockTokenRevoker.AssertExpectations(t)
+	mockTokenRevoker.AssertNumberOfCalls(t, "Revoke", 1)
+}
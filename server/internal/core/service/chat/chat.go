@@ -1,14 +1,18 @@
 package chat
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/modelutil"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/pkg/cutil"
 	"log/slog"
+	"slices"
 )
 
 type ChatService struct {
@@ -21,6 +25,10 @@ type ChatService struct {
 
 	mp MessageProvider
 	ms MessageSaver
+	mi MessageIndexer
+
+	rp ReactionProvider
+	rs ReactionSaver
 }
 
 type ChatProvider interface {
@@ -34,46 +42,112 @@ type UserChatProvider interface {
 
 type ChatSaver interface {
 	Save(ctx context.Context, from []byte, to []byte) (model.Chat, error)
+	SaveGroup(ctx context.Context, owner []byte, title string, members [][]byte) (model.Chat, error)
+	AddMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error)
+	RemoveMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error)
+	// MarkRead moves the read pointer forward only, unread is the count of the
+	// messages left after it.
+	MarkRead(ctx context.Context, uid []byte, cid []byte, mid []byte, timestamp int64, unread int64) (bool, error)
+	IncUnread(ctx context.Context, cid []byte, uids [][]byte) error
+	DecUnread(ctx context.Context, cid []byte, uids [][]byte, timestamp int64) error
 }
 
 type MessageProvider interface {
-	Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error)
+	Messages(ctx context.Context, cid []byte, before []byte, after []byte, limit int) (model.MessagesPage, error)
+	Message(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error)
 }
 
 type MessageSaver interface {
-	Save(ctx context.Context, cid []byte, from []byte, text string) (model.ChatMessage, error)
+	Save(ctx context.Context, cid []byte, from []byte, text string, refs model.MessageRefs) (model.ChatMessage, error)
+	Update(ctx context.Context, cid []byte, mid []byte, text string) (model.ChatMessage, error)
+	Delete(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error)
+}
+
+// MessageIndexer keeps message texts searchable, it is fed on every save.
+// Search returns ErrInvalidCursor for an after it did not issue.
+type MessageIndexer interface {
+	Index(ctx context.Context, message *model.ChatMessage) error
+	Remove(ctx context.Context, cid []byte, mid []byte) error
+	Search(ctx context.Context, cids [][]byte, query string, after []byte, limit int) (model.MessageHitsPage, error)
+}
+
+// ReactionProvider returns the counts of mids in their order, emojis sorted.
+type ReactionProvider interface {
+	Reactions(ctx context.Context, cid []byte, mids [][]byte) ([]model.MessageReactions, error)
+}
+
+// ReactionSaver returns the resulting count of the reaction emoji on the
+// message.
+type ReactionSaver interface {
+	AddReaction(ctx context.Context, reaction *model.Reaction) (int64, error)
+	RemoveReaction(ctx context.Context, reaction *model.Reaction) (int64, error)
 }
 
 type ChatNotifier interface {
 	NewMessage(ctx context.Context, message *model.ChatMessage) error
 	NewChat(ctx context.Context, chat *model.Chat) error
+	ChatUpdated(ctx context.Context, chat *model.Chat) error
+	MessageEdited(ctx context.Context, message *model.ChatMessage) error
+	MessageDeleted(ctx context.Context, message *model.ChatMessage) error
+	MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error
+	ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error
 }
 
+const (
+	defaultMessagesLimit = 50
+	maxMessagesLimit     = 100
+
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
 var (
 	ErrChatExists        = errors.New("chat already exists")
 	ErrUserChatsNotFound = errors.New("user chats not found")
 	ErrChatNotFound      = errors.New("chat not found")
+	ErrNotGroupChat      = errors.New("not a group chat")
+	ErrNotChatOwner      = errors.New("not a chat owner")
+	ErrNotChatMember     = errors.New("not a chat member")
+	ErrMemberExists      = errors.New("chat member already exists")
+	ErrMemberNotFound    = errors.New("chat member not found")
+	ErrOwnerCannotLeave  = errors.New("chat owner can not leave chat")
 
 	ErrMessagesNotFound = errors.New("chat messages not found")
+	ErrMessageNotFound  = errors.New("chat message not found")
+	ErrNotMessageAuthor = errors.New("not a message author")
+	ErrMessageDeleted   = errors.New("chat message deleted")
+	ErrInvalidCursor    = errors.New("invalid search cursor")
+
+	ErrReactionExists   = errors.New("reaction already exists")
+	ErrReactionNotFound = errors.New("reaction not found")
 )
 
-func NewService(
-	log *slog.Logger,
-	cp ChatProvider,
-	cs ChatSaver,
-	cn ChatNotifier,
-	ucp UserChatProvider,
-	mp MessageProvider,
-	ms MessageSaver,
-) *ChatService {
+// Deps are the ports the service works through, the ones the calls in use
+// never reach may be left nil.
+type Deps struct {
+	ChatProvider     ChatProvider
+	ChatSaver        ChatSaver
+	ChatNotifier     ChatNotifier
+	UserChatProvider UserChatProvider
+	MessageProvider  MessageProvider
+	MessageSaver     MessageSaver
+	MessageIndexer   MessageIndexer
+	ReactionProvider ReactionProvider
+	ReactionSaver    ReactionSaver
+}
+
+func NewService(log *slog.Logger, deps Deps) *ChatService {
 	return &ChatService{
 		log: log,
-		cp:  cp,
-		cs:  cs,
-		cn:  cn,
-		ucp: ucp,
-		mp:  mp,
-		ms:  ms,
+		cp:  deps.ChatProvider,
+		cs:  deps.ChatSaver,
+		cn:  deps.ChatNotifier,
+		ucp: deps.UserChatProvider,
+		mp:  deps.MessageProvider,
+		ms:  deps.MessageSaver,
+		mi:  deps.MessageIndexer,
+		rp:  deps.ReactionProvider,
+		rs:  deps.ReactionSaver,
 	}
 }
 
@@ -103,19 +177,139 @@ func (s *ChatService) Create(ctx context.Context, from []byte, to []byte) (*mode
 	return &c, nil
 }
 
-func (s *ChatService) Chat(ctx context.Context, cid []byte) (*model.Chat, error) {
-	const op = "chat.Create"
+func (s *ChatService) CreateGroup(
+	ctx context.Context,
+	owner []byte,
+	title string,
+	members [][]byte,
+) (*model.Chat, error) {
+	const op = "chat.CreateGroup"
 	log := s.log.With(slog.String("op", op))
 
-	log.Debug("getting chat")
+	log.Debug("creating group chat")
 
-	c, err := s.cp.Chat(ctx, cid)
+	uids := [][]byte{owner}
+	for _, member := range members {
+		if !slices.ContainsFunc(uids, func(uid []byte) bool { return bytes.Equal(uid, member) }) {
+			uids = append(uids, member)
+		}
+	}
+
+	c, err := s.cs.SaveGroup(ctx, owner, title, uids)
 	if err != nil {
-		if errors.Is(err, chat.ErrChatNotFound) {
-			return nil, fmt.Errorf("%s: %w", op, ErrChatNotFound)
+		log.Error("failed to save group chat", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("group chat created")
+
+	err = s.cn.NewChat(ctx, &c)
+	if err != nil {
+		log.Error("failed to notify new chat", logger.Err(err))
+	}
+
+	return &c, nil
+}
+
+func (s *ChatService) AddMember(ctx context.Context, cid []byte, uid []byte, member []byte) (*model.Chat, error) {
+	const op = "chat.AddMember"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("adding chat member")
+
+	c, err := s.groupChat(ctx, cid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !modelutil.IsOwner(&c, uid) {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotChatOwner)
+	}
+	if modelutil.IsMember(&c, member) {
+		return nil, fmt.Errorf("%s: %w", op, ErrMemberExists)
+	}
+
+	c, err = s.cs.AddMember(ctx, cid, member)
+	if err != nil {
+		if errors.Is(err, chat.ErrMemberExists) {
+			return nil, fmt.Errorf("%s: %w", op, ErrMemberExists)
 		}
 
-		log.Error("failed to get chat", logger.Err(err))
+		log.Error("failed to add chat member", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("chat member added")
+
+	s.notifyChatUpdated(ctx, log, &c)
+
+	return &c, nil
+}
+
+func (s *ChatService) RemoveMember(ctx context.Context, cid []byte, uid []byte, member []byte) (*model.Chat, error) {
+	const op = "chat.RemoveMember"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("removing chat member")
+
+	c, err := s.groupChat(ctx, cid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !modelutil.IsOwner(&c, uid) {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotChatOwner)
+	}
+	if modelutil.IsOwner(&c, member) {
+		return nil, fmt.Errorf("%s: %w", op, ErrOwnerCannotLeave)
+	}
+	if !modelutil.IsMember(&c, member) {
+		return nil, fmt.Errorf("%s: %w", op, ErrMemberNotFound)
+	}
+
+	c, err = s.removeMember(ctx, log, cid, member)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("chat member removed")
+
+	return &c, nil
+}
+
+func (s *ChatService) Leave(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error) {
+	const op = "chat.Leave"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("leaving chat")
+
+	c, err := s.groupChat(ctx, cid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if modelutil.IsOwner(&c, uid) {
+		return nil, fmt.Errorf("%s: %w", op, ErrOwnerCannotLeave)
+	}
+	if !modelutil.IsMember(&c, uid) {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotChatMember)
+	}
+
+	c, err = s.removeMember(ctx, log, cid, uid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("chat left")
+
+	return &c, nil
+}
+
+func (s *ChatService) Chat(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error) {
+	const op = "chat.Chat"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("getting chat")
+
+	c, err := s.memberChat(ctx, cid, uid)
+	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -153,13 +347,94 @@ func (s *ChatService) UserChats(ctx context.Context, uid []byte) ([]model.Chat,
 	return chats, nil
 }
 
-func (s *ChatService) Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error) {
+func (s *ChatService) ChatStates(ctx context.Context, uid []byte) ([]model.ChatState, error) {
+	const op = "chat.ChatStates"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("getting chat states")
+
+	userChats, err := s.ucp.UserChats(ctx, uid)
+	if err != nil {
+		if errors.Is(err, chat.ErrUserChatsNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrUserChatsNotFound)
+		}
+
+		log.Error("failed to get user chats", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	states := make([]model.ChatState, 0, len(userChats.Chats))
+	for _, userChat := range userChats.Chats {
+		state, err := s.chatState(ctx, &userChat)
+		if err != nil {
+			log.Error("failed to get chat state", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		states = append(states, state)
+	}
+
+	log.Debug("chat states fetched")
+	return states, nil
+}
+
+// LastMessages returns the latest message of every chat of the user, chats
+// without messages are skipped.
+func (s *ChatService) LastMessages(ctx context.Context, uid []byte) ([]model.ChatMessage, error) {
+	const op = "chat.LastMessages"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("getting last messages")
+
+	userChats, err := s.ucp.UserChats(ctx, uid)
+	if err != nil {
+		if errors.Is(err, chat.ErrUserChatsNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrUserChatsNotFound)
+		}
+
+		log.Error("failed to get user chats", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	messages := make([]model.ChatMessage, 0, len(userChats.Chats))
+	for _, userChat := range userChats.Chats {
+		m, err := s.lastMessage(ctx, userChat.Cid)
+		if err != nil {
+			log.Error("failed to get last message", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if m != nil {
+			messages = append(messages, *m)
+		}
+	}
+
+	log.Debug("last messages fetched")
+	return messages, nil
+}
+
+func (s *ChatService) Messages(
+	ctx context.Context,
+	cid []byte,
+	uid []byte,
+	before []byte,
+	after []byte,
+	limit int,
+) (*model.MessagesPage, error) {
 	const op = "chat.Messages"
 	log := s.log.With(slog.String("op", op))
 
 	log.Debug("getting messages")
 
-	messages, err := s.mp.Messages(ctx, cid)
+	if _, err := s.memberChat(ctx, cid, uid); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if limit <= 0 {
+		limit = defaultMessagesLimit
+	}
+	limit = min(limit, maxMessagesLimit)
+
+	page, err := s.mp.Messages(ctx, cid, before, after, limit)
 	if err != nil {
 		if errors.Is(err, chat.ErrMessagesNotFound) {
 			return nil, fmt.Errorf("%s: %w", op, ErrMessagesNotFound)
@@ -169,17 +444,64 @@ func (s *ChatService) Messages(ctx context.Context, cid []byte) ([]model.ChatMes
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if len(page.Messages) != 0 {
+		mids := cutil.Map(page.Messages, func(m model.ChatMessage) []byte {
+			return m.Id
+		})
+		// the messages are still worth showing, reactions come with the next page
+		// load or update
+		page.Reactions, err = s.rp.Reactions(ctx, cid, mids)
+		if err != nil {
+			log.Error("failed to get reactions, returning messages without them", logger.Err(err))
+			page.Reactions = nil
+		}
+	}
+
 	log.Debug("messages fetched")
-	return messages, nil
+	return &page, nil
 }
 
-func (s *ChatService) SendMessage(ctx context.Context, cid []byte, from []byte, text string) (*model.ChatMessage, error) {
+// SendMessage saves a message to the chat. A reply must point at a visible
+// message of the same chat, a forward at a visible message of any chat of the
+// sender and takes its text and original author.
+func (s *ChatService) SendMessage(
+	ctx context.Context,
+	cid []byte,
+	from []byte,
+	text string,
+	refs model.MessageRefs,
+) (*model.ChatMessage, error) {
 	const op = "chat.SendMessage"
 	log := s.log.With(slog.String("op", op))
 
 	log.Debug("saving message")
 
-	m, err := s.ms.Save(ctx, cid, from, text)
+	c, err := s.memberChat(ctx, cid, from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(refs.ReplyTo) != 0 {
+		if _, err := s.visibleMessage(ctx, cid, refs.ReplyTo); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if refs.Forward != nil {
+		if _, err := s.memberChat(ctx, refs.Forward.Cid, from); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		orig, err := s.visibleMessage(ctx, refs.Forward.Cid, refs.Forward.Mid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		text = orig.Text
+		refs.Forward = orig.Forward
+		if refs.Forward == nil {
+			refs.Forward = &model.ForwardedFrom{Cid: orig.Cid, Mid: orig.Id, Uid: orig.Uid}
+		}
+	}
+
+	m, err := s.ms.Save(ctx, cid, from, text, refs)
 	if err != nil {
 		log.Error("failed to save chat", logger.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -187,6 +509,15 @@ func (s *ChatService) SendMessage(ctx context.Context, cid []byte, from []byte,
 
 	log.Debug("message saved")
 
+	s.index(ctx, log, &m)
+
+	if _, err = s.cs.MarkRead(ctx, from, cid, m.Id, m.Timestamp, 0); err != nil {
+		log.Error("failed to mark own message read", logger.Err(err))
+	}
+	if err = s.cs.IncUnread(ctx, cid, modelutil.OtherMembers(&c, from)); err != nil {
+		log.Error("failed to increment unread counters", logger.Err(err))
+	}
+
 	err = s.cn.NewMessage(ctx, &m)
 	if err != nil {
 		log.Error("failed to notify new message", logger.Err(err))
@@ -194,3 +525,427 @@ func (s *ChatService) SendMessage(ctx context.Context, cid []byte, from []byte,
 
 	return &m, nil
 }
+
+func (s *ChatService) EditMessage(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+	text string,
+) (*model.ChatMessage, error) {
+	const op = "chat.EditMessage"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("editing message")
+
+	if _, _, err := s.authorMessage(ctx, cid, mid, uid); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m, err := s.ms.Update(ctx, cid, mid, text)
+	if err != nil {
+		// deleted since it was checked
+		if errors.Is(err, chat.ErrMessageDeleted) {
+			return nil, fmt.Errorf("%s: %w", op, ErrMessageDeleted)
+		}
+
+		log.Error("failed to update message", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("message edited")
+
+	s.index(ctx, log, &m)
+
+	err = s.cn.MessageEdited(ctx, &m)
+	if err != nil {
+		log.Error("failed to notify message edited", logger.Err(err))
+	}
+
+	return &m, nil
+}
+
+func (s *ChatService) DeleteMessage(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ChatMessage, error) {
+	const op = "chat.DeleteMessage"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("deleting message")
+
+	c, orig, err := s.authorMessage(ctx, cid, mid, uid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m, err := s.ms.Delete(ctx, cid, mid)
+	if err != nil {
+		log.Error("failed to delete message", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("message deleted")
+
+	if err = s.cs.DecUnread(ctx, cid, modelutil.OtherMembers(&c, uid), orig.Timestamp); err != nil {
+		log.Error("failed to decrement unread counters", logger.Err(err))
+	}
+
+	if err = s.mi.Remove(ctx, cid, mid); err != nil {
+		log.Error("failed to remove message from index", logger.Err(err))
+	}
+
+	err = s.cn.MessageDeleted(ctx, &m)
+	if err != nil {
+		log.Error("failed to notify message deleted", logger.Err(err))
+	}
+
+	return &m, nil
+}
+
+// SearchMessages finds messages containing every word of query in the chat
+// cid, or in all chats of the user when cid is empty. Newer messages go first.
+func (s *ChatService) SearchMessages(
+	ctx context.Context,
+	uid []byte,
+	cid []byte,
+	query string,
+	after []byte,
+	limit int,
+) (*model.MessageHitsPage, error) {
+	const op = "chat.SearchMessages"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("searching messages")
+
+	var cids [][]byte
+	if len(cid) != 0 {
+		if _, err := s.memberChat(ctx, cid, uid); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		cids = [][]byte{cid}
+	} else {
+		userChats, err := s.ucp.UserChats(ctx, uid)
+		if err != nil {
+			if errors.Is(err, chat.ErrUserChatsNotFound) {
+				return &model.MessageHitsPage{Hits: []model.MessageHit{}}, nil
+			}
+
+			log.Error("failed to get user chats", logger.Err(err))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		cids = cutil.Map(userChats.Chats, func(userChat model.UserChat) []byte {
+			return userChat.Cid
+		})
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	page, err := s.mi.Search(ctx, cids, query, after, limit)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCursor) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		log.Error("failed to search messages", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("messages searched")
+	return &page, nil
+}
+
+func (s *ChatService) MarkRead(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ReadReceipt, error) {
+	const op = "chat.MarkRead"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("marking messages read")
+
+	if _, err := s.memberChat(ctx, cid, uid); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	m, err := s.mp.Message(ctx, cid, mid)
+	if err != nil {
+		if errors.Is(err, chat.ErrMessageNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrMessageNotFound)
+		}
+
+		log.Error("failed to get message", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	unread, err := s.unreadAfter(ctx, cid, mid)
+	if err != nil {
+		log.Error("failed to count unread messages", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	advanced, err := s.cs.MarkRead(ctx, uid, cid, mid, m.Timestamp, unread)
+	if err != nil {
+		log.Error("failed to mark messages read", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	receipt := model.ReadReceipt{Cid: cid, Uid: uid, Mid: mid}
+	if !advanced {
+		log.Debug("read pointer not advanced")
+		return &receipt, nil
+	}
+
+	log.Debug("messages marked read")
+
+	err = s.cn.MessagesRead(ctx, &receipt)
+	if err != nil {
+		log.Error("failed to notify messages read", logger.Err(err))
+	}
+
+	return &receipt, nil
+}
+
+// unreadAfter counts the visible messages newer than mid. They were all unread
+// before, so it pages through no more than the unread counter.
+func (s *ChatService) unreadAfter(ctx context.Context, cid []byte, mid []byte) (int64, error) {
+	var unread int64
+	for after := mid; after != nil; {
+		page, err := s.mp.Messages(ctx, cid, nil, after, maxMessagesLimit)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, m := range page.Messages {
+			if !m.Deleted {
+				unread++
+			}
+		}
+		after = page.Next
+	}
+
+	return unread, nil
+}
+
+// AddReaction puts emoji on a visible message, a user reacts with each emoji
+// at most once.
+func (s *ChatService) AddReaction(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+	emoji string,
+) (*model.ReactionUpdate, error) {
+	const op = "chat.AddReaction"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("adding reaction")
+
+	if err := validate.Emoji(emoji); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.memberChat(ctx, cid, uid); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.visibleMessage(ctx, cid, mid); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	update := model.ReactionUpdate{
+		Reaction: model.Reaction{Cid: cid, Mid: mid, Uid: uid, Emoji: emoji},
+		Added:    true,
+	}
+	count, err := s.rs.AddReaction(ctx, &update.Reaction)
+	if err != nil {
+		if errors.Is(err, chat.ErrReactionExists) {
+			return nil, fmt.Errorf("%s: %w", op, ErrReactionExists)
+		}
+
+		log.Error("failed to add reaction", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	update.Count = count
+
+	log.Debug("reaction added")
+
+	s.notifyReactionUpdated(ctx, log, &update)
+
+	return &update, nil
+}
+
+func (s *ChatService) RemoveReaction(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+	emoji string,
+) (*model.ReactionUpdate, error) {
+	const op = "chat.RemoveReaction"
+	log := s.log.With(slog.String("op", op))
+
+	log.Debug("removing reaction")
+
+	if _, err := s.memberChat(ctx, cid, uid); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	update := model.ReactionUpdate{Reaction: model.Reaction{Cid: cid, Mid: mid, Uid: uid, Emoji: emoji}}
+	count, err := s.rs.RemoveReaction(ctx, &update.Reaction)
+	if err != nil {
+		if errors.Is(err, chat.ErrReactionNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrReactionNotFound)
+		}
+
+		log.Error("failed to remove reaction", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	update.Count = count
+
+	log.Debug("reaction removed")
+
+	s.notifyReactionUpdated(ctx, log, &update)
+
+	return &update, nil
+}
+
+func (s *ChatService) groupChat(ctx context.Context, cid []byte) (model.Chat, error) {
+	c, err := s.fetchChat(ctx, cid)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	if c.Type != model.CTGroup {
+		return model.Chat{}, ErrNotGroupChat
+	}
+
+	return c, nil
+}
+
+func (s *ChatService) memberChat(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	c, err := s.fetchChat(ctx, cid)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	if !modelutil.IsMember(&c, uid) {
+		return model.Chat{}, ErrNotChatMember
+	}
+
+	return c, nil
+}
+
+func (s *ChatService) authorMessage(
+	ctx context.Context,
+	cid []byte,
+	mid []byte,
+	uid []byte,
+) (model.Chat, model.ChatMessage, error) {
+	c, err := s.memberChat(ctx, cid, uid)
+	if err != nil {
+		return model.Chat{}, model.ChatMessage{}, err
+	}
+
+	m, err := s.mp.Message(ctx, cid, mid)
+	if err != nil {
+		if errors.Is(err, chat.ErrMessageNotFound) {
+			return model.Chat{}, model.ChatMessage{}, ErrMessageNotFound
+		}
+
+		s.log.Error("failed to get message", logger.Err(err))
+		return model.Chat{}, model.ChatMessage{}, err
+	}
+	if !bytes.Equal(m.Uid, uid) {
+		return model.Chat{}, model.ChatMessage{}, ErrNotMessageAuthor
+	}
+	if m.Deleted {
+		return model.Chat{}, model.ChatMessage{}, ErrMessageDeleted
+	}
+
+	return c, m, nil
+}
+
+func (s *ChatService) visibleMessage(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	m, err := s.mp.Message(ctx, cid, mid)
+	if err != nil {
+		if errors.Is(err, chat.ErrMessageNotFound) {
+			return model.ChatMessage{}, ErrMessageNotFound
+		}
+
+		s.log.Error("failed to get message", logger.Err(err))
+		return model.ChatMessage{}, err
+	}
+	if m.Deleted {
+		return model.ChatMessage{}, ErrMessageDeleted
+	}
+
+	return m, nil
+}
+
+func (s *ChatService) fetchChat(ctx context.Context, cid []byte) (model.Chat, error) {
+	c, err := s.cp.Chat(ctx, cid)
+	if err != nil {
+		if errors.Is(err, chat.ErrChatNotFound) {
+			return model.Chat{}, ErrChatNotFound
+		}
+
+		s.log.Error("failed to get chat", logger.Err(err))
+		return model.Chat{}, err
+	}
+
+	return c, nil
+}
+
+func (s *ChatService) chatState(ctx context.Context, userChat *model.UserChat) (model.ChatState, error) {
+	m, err := s.lastMessage(ctx, userChat.Cid)
+	if err != nil {
+		return model.ChatState{}, err
+	}
+
+	return model.ChatState{Cid: userChat.Cid, LastRead: userChat.LastRead, Unread: userChat.Unread, LastMessage: m}, nil
+}
+
+// lastMessage returns nil for a chat without messages.
+func (s *ChatService) lastMessage(ctx context.Context, cid []byte) (*model.ChatMessage, error) {
+	page, err := s.mp.Messages(ctx, cid, nil, nil, 1)
+	if err != nil {
+		if errors.Is(err, chat.ErrMessagesNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	if len(page.Messages) == 0 {
+		return nil, nil
+	}
+
+	return &page.Messages[len(page.Messages)-1], nil
+}
+
+func (s *ChatService) removeMember(ctx context.Context, log *slog.Logger, cid []byte, uid []byte) (model.Chat, error) {
+	c, err := s.cs.RemoveMember(ctx, cid, uid)
+	if err != nil {
+		if errors.Is(err, chat.ErrMemberNotFound) {
+			return model.Chat{}, ErrMemberNotFound
+		}
+
+		log.Error("failed to remove chat member", logger.Err(err))
+		return model.Chat{}, err
+	}
+
+	s.notifyChatUpdated(ctx, log, &c)
+
+	return c, nil
+}
+
+func (s *ChatService) index(ctx context.Context, log *slog.Logger, m *model.ChatMessage) {
+	if err := s.mi.Index(ctx, m); err != nil {
+		log.Error("failed to index message", logger.Err(err))
+	}
+}
+
+func (s *ChatService) notifyChatUpdated(ctx context.Context, log *slog.Logger, c *model.Chat) {
+	if err := s.cn.ChatUpdated(ctx, c); err != nil {
+		log.Error("failed to notify chat updated", logger.Err(err))
+	}
+}
+
+func (s *ChatService) notifyReactionUpdated(ctx context.Context, log *slog.Logger, update *model.ReactionUpdate) {
+	if err := s.cn.ReactionUpdated(ctx, update); err != nil {
+		log.Error("failed to notify reaction updated", logger.Err(err))
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/chat"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/test/mocks/mock_chat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"log/slog"
+	"slices"
 	"testing"
 	"time"
 )
@@ -33,7 +35,10 @@ func TestCreateChat(t *testing.T) {
 		mockChatSaver.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
 		mockChatNotifier.On("NewChat", mock.Anything, mock.Anything).Return(nil)
 
-		service := NewService(log, nil, mockChatSaver, mockChatNotifier, nil, nil, nil)
+		service := NewService(log, Deps{
+			ChatSaver:    mockChatSaver,
+			ChatNotifier: mockChatNotifier,
+		})
 
 		createdChat, err := service.Create(context.Background(), []byte("from"), []byte("to"))
 		require.NoError(t, err)
@@ -48,13 +53,251 @@ func TestCreateChat(t *testing.T) {
 		mockChatSaver.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(
 			model.Chat{}, chat.ErrChatExists)
 
-		service := NewService(log, nil, mockChatSaver, mockChatNotifier, nil, nil, nil)
+		service := NewService(log, Deps{
+			ChatSaver:    mockChatSaver,
+			ChatNotifier: mockChatNotifier,
+		})
 
 		_, err := service.Create(context.Background(), []byte("from"), []byte("to"))
 		assert.ErrorIs(t, err, ErrChatExists)
 	})
 }
 
+func TestCreateGroupChat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		expected := model.Chat{
+			Id:      []byte("mockChatId"),
+			Type:    model.CTGroup,
+			Title:   "title",
+			Owner:   []byte("owner"),
+			Members: []model.ChatMember{{Uid: []byte("owner")}, {Uid: []byte("member")}},
+		}
+		mockChatSaver.On("SaveGroup", mock.Anything, []byte("owner"), "title",
+			[][]byte{[]byte("owner"), []byte("member")}).Return(expected, nil)
+		mockChatNotifier.On("NewChat", mock.Anything, mock.Anything).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatSaver:    mockChatSaver,
+			ChatNotifier: mockChatNotifier,
+		})
+
+		createdChat, err := service.CreateGroup(context.Background(), []byte("owner"), "title",
+			[][]byte{[]byte("member"), []byte("owner"), []byte("member")})
+		require.NoError(t, err)
+		assert.Equal(t, expected, *createdChat)
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	t.Parallel()
+
+	groupChat := model.Chat{
+		Id:      []byte("mockChatId"),
+		Type:    model.CTGroup,
+		Owner:   []byte("owner"),
+		Members: []model.ChatMember{{Uid: []byte("owner")}, {Uid: []byte("member")}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		expected := groupChat
+		expected.Members = append(slices.Clone(groupChat.Members), model.ChatMember{Uid: []byte("new")})
+
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+		mockChatSaver.On("AddMember", mock.Anything, groupChat.Id, []byte("new")).Return(expected, nil)
+		mockChatNotifier.On("ChatUpdated", mock.Anything, &expected).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider: mockChatProvider,
+			ChatSaver:    mockChatSaver,
+			ChatNotifier: mockChatNotifier,
+		})
+
+		res, err := service.AddMember(context.Background(), groupChat.Id, []byte("owner"), []byte("new"))
+		require.NoError(t, err)
+		assert.Equal(t, expected, *res)
+		mockChatNotifier.AssertExpectations(t)
+	})
+	t.Run("NotOwnerError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.AddMember(context.Background(), groupChat.Id, []byte("member"), []byte("new"))
+		assert.ErrorIs(t, err, ErrNotChatOwner)
+	})
+	t.Run("MemberExistsError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.AddMember(context.Background(), groupChat.Id, []byte("owner"), []byte("member"))
+		assert.ErrorIs(t, err, ErrMemberExists)
+	})
+	t.Run("NotGroupChatError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		personalChat := model.Chat{
+			Id:      []byte("mockChatId"),
+			Type:    model.CTPersonal,
+			Members: []model.ChatMember{{Uid: []byte("from")}, {Uid: []byte("to")}},
+		}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(personalChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.AddMember(context.Background(), personalChat.Id, []byte("from"), []byte("new"))
+		assert.ErrorIs(t, err, ErrNotGroupChat)
+	})
+	t.Run("NotFoundError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(model.Chat{}, chat.ErrChatNotFound)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.AddMember(context.Background(), []byte("nonexistentChatId"), []byte("owner"), []byte("new"))
+		assert.ErrorIs(t, err, ErrChatNotFound)
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	t.Parallel()
+
+	groupChat := model.Chat{
+		Id:      []byte("mockChatId"),
+		Type:    model.CTGroup,
+		Owner:   []byte("owner"),
+		Members: []model.ChatMember{{Uid: []byte("owner")}, {Uid: []byte("member")}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		expected := groupChat
+		expected.Members = []model.ChatMember{{Uid: []byte("owner")}}
+
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+		mockChatSaver.On("RemoveMember", mock.Anything, groupChat.Id, []byte("member")).Return(expected, nil)
+		mockChatNotifier.On("ChatUpdated", mock.Anything, &expected).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider: mockChatProvider,
+			ChatSaver:    mockChatSaver,
+			ChatNotifier: mockChatNotifier,
+		})
+
+		res, err := service.RemoveMember(context.Background(), groupChat.Id, []byte("owner"), []byte("member"))
+		require.NoError(t, err)
+		assert.Equal(t, expected, *res)
+		mockChatNotifier.AssertExpectations(t)
+	})
+	t.Run("RemoveOwnerError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.RemoveMember(context.Background(), groupChat.Id, []byte("owner"), []byte("owner"))
+		assert.ErrorIs(t, err, ErrOwnerCannotLeave)
+	})
+	t.Run("MemberNotFoundError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.RemoveMember(context.Background(), groupChat.Id, []byte("owner"), []byte("stranger"))
+		assert.ErrorIs(t, err, ErrMemberNotFound)
+	})
+}
+
+func TestLeave(t *testing.T) {
+	t.Parallel()
+
+	groupChat := model.Chat{
+		Id:      []byte("mockChatId"),
+		Type:    model.CTGroup,
+		Owner:   []byte("owner"),
+		Members: []model.ChatMember{{Uid: []byte("owner")}, {Uid: []byte("member")}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		expected := groupChat
+		expected.Members = []model.ChatMember{{Uid: []byte("owner")}}
+
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+		mockChatSaver.On("RemoveMember", mock.Anything, groupChat.Id, []byte("member")).Return(expected, nil)
+		mockChatNotifier.On("ChatUpdated", mock.Anything, mock.Anything).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider: mockChatProvider,
+			ChatSaver:    mockChatSaver,
+			ChatNotifier: mockChatNotifier,
+		})
+
+		res, err := service.Leave(context.Background(), groupChat.Id, []byte("member"))
+		require.NoError(t, err)
+		assert.Equal(t, expected, *res)
+	})
+	t.Run("OwnerLeaveError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.Leave(context.Background(), groupChat.Id, []byte("owner"))
+		assert.ErrorIs(t, err, ErrOwnerCannotLeave)
+	})
+	t.Run("NotMemberError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(groupChat, nil)
+
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
+
+		_, err := service.Leave(context.Background(), groupChat.Id, []byte("stranger"))
+		assert.ErrorIs(t, err, ErrNotChatMember)
+	})
+}
+
 func TestGetChat(t *testing.T) {
 	t.Parallel()
 
@@ -68,9 +311,9 @@ func TestGetChat(t *testing.T) {
 		}
 		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(expected, nil)
 
-		service := NewService(log, mockChatProvider, nil, nil, nil, nil, nil)
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
 
-		res, err := service.Chat(context.Background(), []byte("mockChatId"))
+		res, err := service.Chat(context.Background(), []byte("mockChatId"), []byte("from"))
 		require.NoError(t, err)
 		assert.Equal(t, expected, *res)
 	})
@@ -82,9 +325,9 @@ func TestGetChat(t *testing.T) {
 		mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(
 			model.Chat{}, chat.ErrChatNotFound)
 
-		service := NewService(log, mockChatProvider, nil, nil, nil, nil, nil)
+		service := NewService(log, Deps{ChatProvider: mockChatProvider})
 
-		_, err := service.Chat(context.Background(), []byte("nonexistentChatId"))
+		_, err := service.Chat(context.Background(), []byte("nonexistentChatId"), []byte("from"))
 		assert.ErrorIs(t, err, ErrChatNotFound)
 	})
 }
@@ -113,7 +356,10 @@ func TestUserChats(t *testing.T) {
 		mockUserChatProvider.On("UserChats", mock.Anything, mock.Anything).Return(expectedUserChats, nil)
 		mockChatProvider.On("Chats", mock.Anything, mock.Anything).Return(expectedChats, nil)
 
-		service := NewService(log, mockChatProvider, nil, nil, mockUserChatProvider, nil, nil)
+		service := NewService(log, Deps{
+			ChatProvider:     mockChatProvider,
+			UserChatProvider: mockUserChatProvider,
+		})
 
 		chats, err := service.UserChats(context.Background(), []byte("user1"))
 		require.NoError(t, err)
@@ -126,7 +372,7 @@ func TestUserChats(t *testing.T) {
 
 		mockUserChatProvider.On("UserChats", mock.Anything, mock.Anything).Return(model.UserChats{}, chat.ErrUserChatsNotFound)
 
-		service := NewService(log, nil, nil, nil, mockUserChatProvider, nil, nil)
+		service := NewService(log, Deps{UserChatProvider: mockUserChatProvider})
 
 		_, err := service.UserChats(context.Background(), []byte("nonexistentUser"))
 		assert.ErrorIs(t, err, ErrUserChatsNotFound)
@@ -141,29 +387,104 @@ func TestMessages(t *testing.T) {
 
 		mockMessageProvider := &mock_chat.MockMessageProvider{}
 
-		expectedMessages := []model.ChatMessage{
-			{Id: []byte("msg1"), Text: "Hello", Uid: []byte("user1"), Cid: []byte("chat1"), Timestamp: time.Now().UnixMilli()},
-			{Id: []byte("msg2"), Text: "Hi", Uid: []byte("user2"), Cid: []byte("chat1"), Timestamp: time.Now().UnixMilli()},
+		expectedPage := model.MessagesPage{
+			Messages: []model.ChatMessage{
+				{Id: []byte("msg1"), Text: "Hello", Uid: []byte("user1"), Cid: []byte("chat1"), Timestamp: time.Now().UnixMilli()},
+				{Id: []byte("msg2"), Text: "Hi", Uid: []byte("user2"), Cid: []byte("chat1"), Timestamp: time.Now().UnixMilli()},
+			},
+			Next: []byte("msg1"),
 		}
 
-		mockMessageProvider.On("Messages", mock.Anything, mock.Anything).Return(expectedMessages, nil)
+		mockMessageProvider.On("Messages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expectedPage, nil)
 
-		service := NewService(log, nil, nil, nil, nil, mockMessageProvider, nil)
+		reactions := []model.MessageReactions{
+			{Mid: []byte("msg2"), Counts: []model.ReactionCount{{Emoji: "👍", Count: 2}}},
+		}
+		mockReactionProvider := &mock_chat.MockReactionProvider{}
+		mockReactionProvider.On("Reactions", mock.Anything, []byte("chatID"), [][]byte{[]byte("msg1"), []byte("msg2")}).
+			Return(reactions, nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:     memberChatProvider([]byte("user1")),
+			MessageProvider:  mockMessageProvider,
+			ReactionProvider: mockReactionProvider,
+		})
 
-		messages, err := service.Messages(context.Background(), []byte("chatID"))
+		page, err := service.Messages(context.Background(), []byte("chatID"), []byte("user1"), nil, nil, 2)
 		require.NoError(t, err)
-		assert.Equal(t, expectedMessages, messages)
+		assert.Equal(t, expectedPage.Messages, page.Messages)
+		assert.Equal(t, reactions, page.Reactions, "reactions attached")
+	})
+	t.Run("ReactionsError", func(t *testing.T) {
+		t.Parallel()
+
+		expectedPage := model.MessagesPage{Messages: []model.ChatMessage{{Id: []byte("msg1"), Text: "Hello"}}}
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageProvider.On("Messages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expectedPage, nil)
+		mockReactionProvider := &mock_chat.MockReactionProvider{}
+		mockReactionProvider.On("Reactions", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("failed"))
+
+		service := NewService(log, Deps{
+			ChatProvider:     memberChatProvider([]byte("user1")),
+			MessageProvider:  mockMessageProvider,
+			ReactionProvider: mockReactionProvider,
+		})
+
+		page, err := service.Messages(context.Background(), []byte("chatID"), []byte("user1"), nil, nil, 1)
+		require.NoError(t, err, "messages returned without reactions")
+		assert.Equal(t, expectedPage.Messages, page.Messages)
+		assert.Empty(t, page.Reactions)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name     string
+			limit    int
+			expected int
+		}{
+			{name: "Default", limit: 0, expected: defaultMessagesLimit},
+			{name: "Negative", limit: -1, expected: defaultMessagesLimit},
+			{name: "InRange", limit: 10, expected: 10},
+			{name: "Capped", limit: maxMessagesLimit + 1, expected: maxMessagesLimit},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				mockMessageProvider := &mock_chat.MockMessageProvider{}
+				mockMessageProvider.On("Messages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, tt.expected).
+					Return(model.MessagesPage{}, nil)
+
+				service := NewService(log, Deps{
+					ChatProvider:    memberChatProvider([]byte("user1")),
+					MessageProvider: mockMessageProvider,
+				})
+
+				_, err := service.Messages(
+					context.Background(), []byte("chatID"), []byte("user1"), []byte("before"), nil, tt.limit)
+				require.NoError(t, err)
+				mockMessageProvider.AssertExpectations(t)
+			})
+		}
 	})
 	t.Run("NotFoundError", func(t *testing.T) {
 		t.Parallel()
 
 		mockMessageProvider := &mock_chat.MockMessageProvider{}
-		mockMessageProvider.On("Messages", mock.Anything, mock.Anything).
-			Return(nil, chat.ErrMessagesNotFound)
+		mockMessageProvider.On("Messages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(model.MessagesPage{}, chat.ErrMessagesNotFound)
 
-		service := NewService(log, nil, nil, nil, nil, mockMessageProvider, nil)
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider([]byte("user1")),
+			MessageProvider: mockMessageProvider,
+		})
 
-		_, err := service.Messages(context.Background(), []byte("nonexistentChatID"))
+		_, err := service.Messages(context.Background(), []byte("nonexistentChatID"), []byte("user1"), nil, nil, 0)
 		assert.ErrorIs(t, err, ErrMessagesNotFound)
 	})
 }
@@ -185,15 +506,37 @@ func TestSendMessage(t *testing.T) {
 			Timestamp: time.Now().UnixMilli(),
 		}
 
-		mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mockChatSaver := &mock_chat.MockChatSaver{}
+
+		mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedMessage, nil)
+		mockChatSaver.On(
+			"MarkRead",
+			mock.Anything,
+			expectedMessage.Uid,
+			expectedMessage.Cid,
+			expectedMessage.Id,
+			expectedMessage.Timestamp,
+			int64(0),
+		).Return(true, nil)
+		mockChatSaver.On("IncUnread", mock.Anything, expectedMessage.Cid, [][]byte{[]byte("other")}).Return(nil)
 		mockChatNotifier.On("NewMessage", mock.Anything, mock.Anything).Return(nil)
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+		mockMessageIndexer.On("Index", mock.Anything, &expectedMessage).Return(nil)
 
-		service := NewService(log, nil, nil, mockChatNotifier, nil, nil, mockMessageSaver)
+		service := NewService(log, Deps{
+			ChatProvider:   memberChatProvider([]byte("user"), []byte("other")),
+			ChatSaver:      mockChatSaver,
+			ChatNotifier:   mockChatNotifier,
+			MessageSaver:   mockMessageSaver,
+			MessageIndexer: mockMessageIndexer,
+		})
 
-		message, err := service.SendMessage(context.Background(), []byte("chat"), []byte("user"), "Hello")
+		message, err := service.SendMessage(context.Background(), []byte("chat"), []byte("user"), "Hello", model.MessageRefs{})
 		require.NoError(t, err)
 		assert.Equal(t, expectedMessage, *message)
+		mockChatSaver.AssertExpectations(t)
+		mockMessageIndexer.AssertExpectations(t)
 	})
 	t.Run("MessageSaveFailedError", func(t *testing.T) {
 		t.Parallel()
@@ -201,12 +544,16 @@ func TestSendMessage(t *testing.T) {
 		mockMessageSaver := &mock_chat.MockMessageSaver{}
 		mockChatNotifier := &mock_chat.MockChatNotifier{}
 
-		mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(model.ChatMessage{}, errors.New("failed to save message"))
 
-		service := NewService(log, nil, nil, mockChatNotifier, nil, nil, mockMessageSaver)
+		service := NewService(log, Deps{
+			ChatProvider: memberChatProvider([]byte("user")),
+			ChatNotifier: mockChatNotifier,
+			MessageSaver: mockMessageSaver,
+		})
 
-		_, err := service.SendMessage(context.Background(), []byte("chat"), []byte("user"), "Hello")
+		_, err := service.SendMessage(context.Background(), []byte("chat"), []byte("user"), "Hello", model.MessageRefs{})
 		assert.Error(t, err)
 	})
 	t.Run("NotifyFailedNoError", func(t *testing.T) {
@@ -223,15 +570,848 @@ func TestSendMessage(t *testing.T) {
 			Timestamp: time.Now().UnixMilli(),
 		}
 
-		mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedMessage, nil)
 		mockChatNotifier.On("NewMessage", mock.Anything, mock.Anything).
 			Return(errors.New("failed to notify new message"))
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatSaver.On("MarkRead", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(false, errors.New("failed to mark read"))
+		mockChatSaver.On("IncUnread", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("failed to increment unread"))
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+		mockMessageIndexer.On("Index", mock.Anything, mock.Anything).Return(errors.New("failed to index"))
 
-		service := NewService(log, nil, nil, mockChatNotifier, nil, nil, mockMessageSaver)
+		service := NewService(log, Deps{
+			ChatProvider:   memberChatProvider([]byte("user")),
+			ChatSaver:      mockChatSaver,
+			ChatNotifier:   mockChatNotifier,
+			MessageSaver:   mockMessageSaver,
+			MessageIndexer: mockMessageIndexer,
+		})
 
-		message, err := service.SendMessage(context.Background(), []byte("chat"), []byte("user"), "Hello")
+		message, err := service.SendMessage(context.Background(), []byte("chat"), []byte("user"), "Hello", model.MessageRefs{})
 		assert.NoError(t, err)
 		assert.Equal(t, expectedMessage, *message)
 	})
 }
+
+func TestSendMessageRefs(t *testing.T) {
+	t.Parallel()
+
+	sender := []byte("sender")
+	author := []byte("author")
+	original := model.ChatMessage{Id: []byte("origMid"), Cid: []byte("other"), Uid: author, Text: "Original"}
+
+	t.Run("Reply", func(t *testing.T) {
+		t.Parallel()
+
+		refs := model.MessageRefs{ReplyTo: original.Id}
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageProvider.On("Message", mock.Anything, []byte("chat"), original.Id).Return(original, nil)
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockMessageSaver.On("Save", mock.Anything, []byte("chat"), sender, "Reply", refs).
+			Return(model.ChatMessage{Text: "Reply", MessageRefs: refs}, nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(sender),
+			ChatSaver:       noopChatSaver(),
+			ChatNotifier:    noopChatNotifier(),
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+			MessageIndexer:  noopMessageIndexer(),
+		})
+
+		m, err := service.SendMessage(context.Background(), []byte("chat"), sender, "Reply", refs)
+		require.NoError(t, err)
+		assert.Equal(t, original.Id, m.ReplyTo)
+		mockMessageSaver.AssertExpectations(t)
+	})
+	t.Run("ReplyToDeletedError", func(t *testing.T) {
+		t.Parallel()
+
+		deleted := original
+		deleted.Deleted = true
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).Return(deleted, nil)
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(sender),
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+		})
+
+		_, err := service.SendMessage(
+			context.Background(), []byte("chat"), sender, "Reply", model.MessageRefs{ReplyTo: original.Id})
+		assert.ErrorIs(t, err, ErrMessageDeleted)
+		mockMessageSaver.AssertNotCalled(t, "Save")
+	})
+	t.Run("Forward", func(t *testing.T) {
+		t.Parallel()
+
+		expected := model.MessageRefs{Forward: &model.ForwardedFrom{Cid: original.Cid, Mid: original.Id, Uid: author}}
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageProvider.On("Message", mock.Anything, original.Cid, original.Id).Return(original, nil)
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockMessageSaver.On("Save", mock.Anything, []byte("chat"), sender, original.Text, expected).
+			Return(model.ChatMessage{Text: original.Text, MessageRefs: expected}, nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(sender),
+			ChatSaver:       noopChatSaver(),
+			ChatNotifier:    noopChatNotifier(),
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+			MessageIndexer:  noopMessageIndexer(),
+		})
+
+		m, err := service.SendMessage(context.Background(), []byte("chat"), sender, "", model.MessageRefs{
+			Forward: &model.ForwardedFrom{Cid: original.Cid, Mid: original.Id},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, original.Text, m.Text, "original text taken")
+		mockMessageSaver.AssertExpectations(t)
+	})
+	t.Run("ForwardKeepsOriginal", func(t *testing.T) {
+		t.Parallel()
+
+		first := &model.ForwardedFrom{Cid: []byte("first"), Mid: []byte("firstMid"), Uid: []byte("first author")}
+		forwarded := original
+		forwarded.Forward = first
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageProvider.On("Message", mock.Anything, original.Cid, original.Id).Return(forwarded, nil)
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockMessageSaver.On("Save", mock.Anything, []byte("chat"), sender, original.Text, model.MessageRefs{Forward: first}).
+			Return(model.ChatMessage{}, nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(sender),
+			ChatSaver:       noopChatSaver(),
+			ChatNotifier:    noopChatNotifier(),
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+			MessageIndexer:  noopMessageIndexer(),
+		})
+
+		_, err := service.SendMessage(context.Background(), []byte("chat"), sender, "", model.MessageRefs{
+			Forward: &model.ForwardedFrom{Cid: original.Cid, Mid: original.Id},
+		})
+		require.NoError(t, err)
+		mockMessageSaver.AssertExpectations(t)
+	})
+	t.Run("ForwardNotMemberError", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatProvider := &mock_chat.MockChatProvider{}
+		mockChatProvider.On("Chat", mock.Anything, []byte("chat")).
+			Return(model.Chat{Members: []model.ChatMember{{Uid: sender}}}, nil)
+		mockChatProvider.On("Chat", mock.Anything, original.Cid).
+			Return(model.Chat{Members: []model.ChatMember{{Uid: author}}}, nil)
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+
+		service := NewService(log, Deps{
+			ChatProvider:    mockChatProvider,
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+		})
+
+		_, err := service.SendMessage(context.Background(), []byte("chat"), sender, "", model.MessageRefs{
+			Forward: &model.ForwardedFrom{Cid: original.Cid, Mid: original.Id},
+		})
+		assert.ErrorIs(t, err, ErrNotChatMember)
+		mockMessageProvider.AssertNotCalled(t, "Message")
+		mockMessageSaver.AssertNotCalled(t, "Save")
+	})
+}
+
+func noopChatSaver() *mock_chat.MockChatSaver {
+	mockChatSaver := &mock_chat.MockChatSaver{}
+	mockChatSaver.On("MarkRead", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(true, nil)
+	mockChatSaver.On("IncUnread", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return mockChatSaver
+}
+
+func noopChatNotifier() *mock_chat.MockChatNotifier {
+	mockChatNotifier := &mock_chat.MockChatNotifier{}
+	mockChatNotifier.On("NewMessage", mock.Anything, mock.Anything).Return(nil)
+
+	return mockChatNotifier
+}
+
+func noopMessageIndexer() *mock_chat.MockMessageIndexer {
+	mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+	mockMessageIndexer.On("Index", mock.Anything, mock.Anything).Return(nil)
+
+	return mockMessageIndexer
+}
+
+func TestChatAccess(t *testing.T) {
+	t.Parallel()
+
+	member := []byte("member")
+	stranger := []byte("stranger")
+
+	calls := []struct {
+		name string
+		call func(s *ChatService, uid []byte) error
+	}{
+		{name: "Chat", call: func(s *ChatService, uid []byte) error {
+			_, err := s.Chat(context.Background(), []byte("chat"), uid)
+			return err
+		}},
+		{name: "Messages", call: func(s *ChatService, uid []byte) error {
+			_, err := s.Messages(context.Background(), []byte("chat"), uid, nil, nil, 0)
+			return err
+		}},
+		{name: "SendMessage", call: func(s *ChatService, uid []byte) error {
+			_, err := s.SendMessage(context.Background(), []byte("chat"), uid, "Hello", model.MessageRefs{})
+			return err
+		}},
+		{name: "MarkRead", call: func(s *ChatService, uid []byte) error {
+			_, err := s.MarkRead(context.Background(), []byte("chat"), []byte("mid"), uid)
+			return err
+		}},
+		{name: "SearchMessages", call: func(s *ChatService, uid []byte) error {
+			_, err := s.SearchMessages(context.Background(), uid, []byte("chat"), "Hello", nil, 0)
+			return err
+		}},
+		{name: "AddReaction", call: func(s *ChatService, uid []byte) error {
+			_, err := s.AddReaction(context.Background(), []byte("chat"), []byte("mid"), uid, "👍")
+			return err
+		}},
+	}
+	tests := []struct {
+		name     string
+		uid      []byte
+		chatErr  error
+		expected error
+	}{
+		{name: "Member", uid: member},
+		{name: "NotMember", uid: stranger, expected: ErrNotChatMember},
+		{name: "ChatNotFound", uid: member, chatErr: chat.ErrChatNotFound, expected: ErrChatNotFound},
+	}
+
+	for _, c := range calls {
+		for _, tt := range tests {
+			t.Run(c.name+"/"+tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				mockChatProvider := &mock_chat.MockChatProvider{}
+				mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(
+					model.Chat{Id: []byte("chat"), Members: []model.ChatMember{{Uid: member}}}, tt.chatErr)
+
+				mockMessageProvider := &mock_chat.MockMessageProvider{}
+				mockMessageProvider.On("Messages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(model.MessagesPage{}, nil)
+				mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ChatMessage{}, nil)
+
+				mockChatSaver := &mock_chat.MockChatSaver{}
+				mockChatSaver.On("MarkRead", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(false, nil)
+				mockChatSaver.On("IncUnread", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+				mockMessageSaver := &mock_chat.MockMessageSaver{}
+				mockMessageSaver.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ChatMessage{}, nil)
+
+				mockChatNotifier := &mock_chat.MockChatNotifier{}
+				mockChatNotifier.On("NewMessage", mock.Anything, mock.Anything).Return(nil)
+				mockChatNotifier.On("ReactionUpdated", mock.Anything, mock.Anything).Return(nil)
+
+				mockReactionSaver := &mock_chat.MockReactionSaver{}
+				mockReactionSaver.On("AddReaction", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+				mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+				mockMessageIndexer.On("Index", mock.Anything, mock.Anything).Return(nil)
+				mockMessageIndexer.On("Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(model.MessageHitsPage{}, nil)
+
+				service := NewService(log, Deps{
+					ChatProvider:    mockChatProvider,
+					ChatSaver:       mockChatSaver,
+					ChatNotifier:    mockChatNotifier,
+					MessageProvider: mockMessageProvider,
+					MessageSaver:    mockMessageSaver,
+					MessageIndexer:  mockMessageIndexer,
+					ReactionSaver:   mockReactionSaver,
+				})
+
+				err := c.call(service, tt.uid)
+				if tt.expected == nil {
+					require.NoError(t, err)
+					return
+				}
+
+				assert.ErrorIs(t, err, tt.expected)
+				mockMessageProvider.AssertNotCalled(t, "Messages")
+				mockMessageSaver.AssertNotCalled(t, "Save")
+				mockChatSaver.AssertNotCalled(t, "MarkRead")
+				mockMessageIndexer.AssertNotCalled(t, "Search")
+				mockReactionSaver.AssertNotCalled(t, "AddReaction")
+			})
+		}
+	}
+}
+
+func memberChatProvider(uids ...[]byte) *mock_chat.MockChatProvider {
+	members := make([]model.ChatMember, 0, len(uids))
+	for _, uid := range uids {
+		members = append(members, model.ChatMember{Uid: uid})
+	}
+
+	mockChatProvider := &mock_chat.MockChatProvider{}
+	mockChatProvider.On("Chat", mock.Anything, mock.Anything).Return(model.Chat{Members: members}, nil)
+
+	return mockChatProvider
+}
+
+func TestEditMessage(t *testing.T) {
+	t.Parallel()
+
+	author := []byte("author")
+	message := model.ChatMessage{Id: []byte("mid"), Cid: []byte("chat"), Uid: author, Text: "Hello"}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		edited := message
+		edited.Text = "Hi"
+		edited.EditedAt = time.Now().UnixMilli()
+
+		mockMessageProvider.On("Message", mock.Anything, message.Cid, message.Id).Return(message, nil)
+		mockMessageSaver.On("Update", mock.Anything, message.Cid, message.Id, "Hi").Return(edited, nil)
+		mockChatNotifier.On("MessageEdited", mock.Anything, &edited).Return(nil)
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+		mockMessageIndexer.On("Index", mock.Anything, &edited).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(author),
+			ChatNotifier:    mockChatNotifier,
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+			MessageIndexer:  mockMessageIndexer,
+		})
+
+		res, err := service.EditMessage(context.Background(), message.Cid, message.Id, author, "Hi")
+		require.NoError(t, err)
+		assert.Equal(t, edited, *res)
+		mockChatNotifier.AssertExpectations(t)
+		mockMessageIndexer.AssertExpectations(t)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		deleted := message
+		deleted.Text = ""
+		deleted.Deleted = true
+
+		tests := []struct {
+			name       string
+			uid        []byte
+			message    model.ChatMessage
+			messageErr error
+			expected   error
+		}{
+			{name: "NotAuthor", uid: []byte("other"), message: message, expected: ErrNotMessageAuthor},
+			{name: "Deleted", uid: author, message: deleted, expected: ErrMessageDeleted},
+			{name: "NotFound", uid: author, messageErr: chat.ErrMessageNotFound, expected: ErrMessageNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				mockMessageProvider := &mock_chat.MockMessageProvider{}
+				mockMessageSaver := &mock_chat.MockMessageSaver{}
+				mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).
+					Return(tt.message, tt.messageErr)
+
+				service := NewService(log, Deps{
+					ChatProvider:    memberChatProvider(author, []byte("other")),
+					MessageProvider: mockMessageProvider,
+					MessageSaver:    mockMessageSaver,
+				})
+
+				_, err := service.EditMessage(context.Background(), message.Cid, message.Id, tt.uid, "Hi")
+				assert.ErrorIs(t, err, tt.expected)
+				mockMessageSaver.AssertNotCalled(t, "Update")
+			})
+		}
+	})
+	t.Run("DeletedMeanwhile", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockMessageProvider.On("Message", mock.Anything, message.Cid, message.Id).Return(message, nil)
+		mockMessageSaver.On("Update", mock.Anything, message.Cid, message.Id, "Hi").
+			Return(model.ChatMessage{}, chat.ErrMessageDeleted)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(author),
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+		})
+
+		_, err := service.EditMessage(context.Background(), message.Cid, message.Id, author, "Hi")
+		assert.ErrorIs(t, err, ErrMessageDeleted)
+	})
+}
+
+func TestDeleteMessage(t *testing.T) {
+	t.Parallel()
+
+	author := []byte("author")
+	message := model.ChatMessage{Id: []byte("mid"), Cid: []byte("chat"), Uid: author, Text: "Hello", Timestamp: 42}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		deleted := message
+		deleted.Text = ""
+		deleted.Deleted = true
+
+		mockMessageProvider.On("Message", mock.Anything, message.Cid, message.Id).Return(message, nil)
+		mockMessageSaver.On("Delete", mock.Anything, message.Cid, message.Id).Return(deleted, nil)
+		mockChatNotifier.On("MessageDeleted", mock.Anything, &deleted).Return(nil)
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+		mockMessageIndexer.On("Remove", mock.Anything, message.Cid, message.Id).Return(nil)
+		mockChatSaver.On("DecUnread", mock.Anything, message.Cid, [][]byte{[]byte("reader")}, message.Timestamp).
+			Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(author, []byte("reader")),
+			ChatSaver:       mockChatSaver,
+			ChatNotifier:    mockChatNotifier,
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+			MessageIndexer:  mockMessageIndexer,
+		})
+
+		res, err := service.DeleteMessage(context.Background(), message.Cid, message.Id, author)
+		require.NoError(t, err)
+		assert.Equal(t, deleted, *res)
+		mockChatNotifier.AssertExpectations(t)
+		mockMessageIndexer.AssertExpectations(t)
+		mockChatSaver.AssertExpectations(t)
+	})
+	t.Run("NotAuthorError", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageSaver := &mock_chat.MockMessageSaver{}
+		mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).Return(message, nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(author, []byte("other")),
+			MessageProvider: mockMessageProvider,
+			MessageSaver:    mockMessageSaver,
+		})
+
+		_, err := service.DeleteMessage(context.Background(), message.Cid, message.Id, []byte("other"))
+		assert.ErrorIs(t, err, ErrNotMessageAuthor)
+		mockMessageSaver.AssertNotCalled(t, "Delete")
+	})
+	t.Run("NotMemberError", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider([]byte("other")),
+			MessageProvider: mockMessageProvider,
+		})
+
+		_, err := service.DeleteMessage(context.Background(), message.Cid, message.Id, author)
+		assert.ErrorIs(t, err, ErrNotChatMember)
+		mockMessageProvider.AssertNotCalled(t, "Message")
+	})
+}
+
+func TestMarkRead(t *testing.T) {
+	t.Parallel()
+
+	reader := []byte("reader")
+	message := model.ChatMessage{Id: []byte("mid"), Cid: []byte("chat"), Uid: []byte("author"), Timestamp: 42}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		expected := model.ReadReceipt{Cid: message.Cid, Uid: reader, Mid: message.Id}
+
+		mockMessageProvider.On("Message", mock.Anything, message.Cid, message.Id).Return(message, nil)
+		mockMessageProvider.On("Messages", mock.Anything, message.Cid, []byte(nil), message.Id, mock.Anything).
+			Return(model.MessagesPage{Messages: []model.ChatMessage{}}, nil)
+		mockChatSaver.On("MarkRead", mock.Anything, reader, message.Cid, message.Id, message.Timestamp, int64(0)).
+			Return(true, nil)
+		mockChatNotifier.On("MessagesRead", mock.Anything, &expected).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(reader),
+			ChatSaver:       mockChatSaver,
+			ChatNotifier:    mockChatNotifier,
+			MessageProvider: mockMessageProvider,
+		})
+
+		receipt, err := service.MarkRead(context.Background(), message.Cid, message.Id, reader)
+		require.NoError(t, err)
+		assert.Equal(t, expected, *receipt)
+		mockChatNotifier.AssertExpectations(t)
+	})
+	t.Run("NotLatest", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		// two newer messages on the first page, one deleted, and one on the next
+		mockMessageProvider.On("Message", mock.Anything, message.Cid, message.Id).Return(message, nil)
+		mockMessageProvider.On("Messages", mock.Anything, message.Cid, []byte(nil), message.Id, mock.Anything).
+			Return(model.MessagesPage{
+				Messages: []model.ChatMessage{{Id: []byte("m1")}, {Id: []byte("m2"), Deleted: true}},
+				Next:     []byte("m2"),
+			}, nil)
+		mockMessageProvider.On("Messages", mock.Anything, message.Cid, []byte(nil), []byte("m2"), mock.Anything).
+			Return(model.MessagesPage{Messages: []model.ChatMessage{{Id: []byte("m3")}}}, nil)
+		mockChatSaver.On("MarkRead", mock.Anything, reader, message.Cid, message.Id, message.Timestamp, int64(2)).
+			Return(true, nil)
+		mockChatNotifier.On("MessagesRead", mock.Anything, mock.Anything).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(reader),
+			ChatSaver:       mockChatSaver,
+			ChatNotifier:    mockChatNotifier,
+			MessageProvider: mockMessageProvider,
+		})
+
+		_, err := service.MarkRead(context.Background(), message.Cid, message.Id, reader)
+		require.NoError(t, err)
+		mockChatSaver.AssertExpectations(t)
+	})
+	t.Run("NotAdvanced", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockChatSaver := &mock_chat.MockChatSaver{}
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		mockMessageProvider.On("Message", mock.Anything, message.Cid, message.Id).Return(message, nil)
+		mockMessageProvider.On("Messages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(model.MessagesPage{Messages: []model.ChatMessage{}}, nil)
+		mockChatSaver.On("MarkRead", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(false, nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(reader),
+			ChatSaver:       mockChatSaver,
+			ChatNotifier:    mockChatNotifier,
+			MessageProvider: mockMessageProvider,
+		})
+
+		_, err := service.MarkRead(context.Background(), message.Cid, message.Id, reader)
+		require.NoError(t, err)
+		mockChatNotifier.AssertNotCalled(t, "MessagesRead")
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name       string
+			uid        []byte
+			messageErr error
+			expected   error
+		}{
+			{name: "NotMember", uid: []byte("other"), expected: ErrNotChatMember},
+			{name: "MessageNotFound", uid: reader, messageErr: chat.ErrMessageNotFound, expected: ErrMessageNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				mockMessageProvider := &mock_chat.MockMessageProvider{}
+				mockChatSaver := &mock_chat.MockChatSaver{}
+				mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ChatMessage{}, tt.messageErr)
+
+				service := NewService(log, Deps{
+					ChatProvider:    memberChatProvider(reader),
+					ChatSaver:       mockChatSaver,
+					MessageProvider: mockMessageProvider,
+				})
+
+				_, err := service.MarkRead(context.Background(), message.Cid, message.Id, tt.uid)
+				assert.ErrorIs(t, err, tt.expected)
+				mockChatSaver.AssertNotCalled(t, "MarkRead")
+			})
+		}
+	})
+}
+
+func TestChatStates(t *testing.T) {
+	t.Parallel()
+
+	mockUserChatProvider := &mock_chat.MockUserChatProvider{}
+	mockMessageProvider := &mock_chat.MockMessageProvider{}
+
+	last := model.ChatMessage{Id: []byte("last"), Cid: []byte("read"), Text: "Hello"}
+
+	mockUserChatProvider.On("UserChats", mock.Anything, []byte("user")).Return(model.UserChats{
+		Uid: []byte("user"),
+		Chats: []model.UserChat{
+			{Cid: []byte("read"), LastRead: []byte("mid"), Unread: 3},
+			{Cid: []byte("empty")},
+		},
+	}, nil)
+	mockMessageProvider.On("Messages", mock.Anything, []byte("read"), []byte(nil), []byte(nil), 1).
+		Return(model.MessagesPage{Messages: []model.ChatMessage{last}}, nil)
+	mockMessageProvider.On("Messages", mock.Anything, []byte("empty"), []byte(nil), []byte(nil), 1).
+		Return(model.MessagesPage{}, chat.ErrMessagesNotFound)
+
+	service := NewService(log, Deps{
+		UserChatProvider: mockUserChatProvider,
+		MessageProvider:  mockMessageProvider,
+	})
+
+	states, err := service.ChatStates(context.Background(), []byte("user"))
+	require.NoError(t, err)
+	assert.Equal(t, []model.ChatState{
+		{Cid: []byte("read"), LastRead: []byte("mid"), Unread: 3, LastMessage: &last},
+		{Cid: []byte("empty")},
+	}, states)
+}
+
+func TestLastMessages(t *testing.T) {
+	t.Parallel()
+
+	mockUserChatProvider := &mock_chat.MockUserChatProvider{}
+	mockMessageProvider := &mock_chat.MockMessageProvider{}
+
+	last := model.ChatMessage{Id: []byte("last"), Cid: []byte("chat"), Text: "Hello"}
+
+	mockUserChatProvider.On("UserChats", mock.Anything, []byte("user")).Return(model.UserChats{
+		Uid:   []byte("user"),
+		Chats: []model.UserChat{{Cid: []byte("chat")}, {Cid: []byte("empty")}},
+	}, nil)
+	mockMessageProvider.On("Messages", mock.Anything, []byte("chat"), []byte(nil), []byte(nil), 1).
+		Return(model.MessagesPage{Messages: []model.ChatMessage{last}}, nil)
+	mockMessageProvider.On("Messages", mock.Anything, []byte("empty"), []byte(nil), []byte(nil), 1).
+		Return(model.MessagesPage{}, chat.ErrMessagesNotFound)
+
+	service := NewService(log, Deps{
+		UserChatProvider: mockUserChatProvider,
+		MessageProvider:  mockMessageProvider,
+	})
+
+	messages, err := service.LastMessages(context.Background(), []byte("user"))
+	require.NoError(t, err)
+	assert.Equal(t, []model.ChatMessage{last}, messages, "chats without messages are skipped")
+}
+
+func TestSearchMessages(t *testing.T) {
+	t.Parallel()
+
+	uid := []byte("user")
+
+	t.Run("AllUserChats", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserChatProvider := &mock_chat.MockUserChatProvider{}
+		mockUserChatProvider.On("UserChats", mock.Anything, uid).Return(model.UserChats{
+			Uid:   uid,
+			Chats: []model.UserChat{{Cid: []byte("chat1")}, {Cid: []byte("chat2")}},
+		}, nil)
+
+		expected := model.MessageHitsPage{Hits: []model.MessageHit{{Snippet: "Hello"}}}
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+		mockMessageIndexer.On(
+			"Search",
+			mock.Anything,
+			[][]byte{[]byte("chat1"), []byte("chat2")},
+			"hello",
+			[]byte(nil),
+			defaultSearchLimit,
+		).Return(expected, nil)
+
+		service := NewService(log, Deps{
+			UserChatProvider: mockUserChatProvider,
+			MessageIndexer:   mockMessageIndexer,
+		})
+
+		page, err := service.SearchMessages(context.Background(), uid, nil, "hello", nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, expected, *page)
+		mockMessageIndexer.AssertExpectations(t)
+	})
+	t.Run("NoUserChats", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserChatProvider := &mock_chat.MockUserChatProvider{}
+		mockUserChatProvider.On("UserChats", mock.Anything, uid).Return(model.UserChats{}, chat.ErrUserChatsNotFound)
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+
+		service := NewService(log, Deps{
+			UserChatProvider: mockUserChatProvider,
+			MessageIndexer:   mockMessageIndexer,
+		})
+
+		page, err := service.SearchMessages(context.Background(), uid, nil, "hello", nil, 0)
+		require.NoError(t, err)
+		assert.Empty(t, page.Hits)
+		mockMessageIndexer.AssertNotCalled(t, "Search")
+	})
+	t.Run("InvalidCursorError", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageIndexer := &mock_chat.MockMessageIndexer{}
+		mockMessageIndexer.On("Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything, maxSearchLimit).
+			Return(model.MessageHitsPage{}, ErrInvalidCursor)
+
+		service := NewService(log, Deps{
+			ChatProvider:   memberChatProvider(uid),
+			MessageIndexer: mockMessageIndexer,
+		})
+
+		_, err := service.SearchMessages(context.Background(), uid, []byte("chat"), "hello", []byte("bad"), 1000)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestAddReaction(t *testing.T) {
+	t.Parallel()
+
+	uid := []byte("user")
+	expected := &model.ReactionUpdate{
+		Reaction: model.Reaction{Cid: []byte("chat"), Mid: []byte("mid"), Uid: uid, Emoji: "👍"},
+		Added:    true,
+		Count:    3,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockMessageProvider := &mock_chat.MockMessageProvider{}
+		mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).Return(model.ChatMessage{}, nil)
+		mockReactionSaver := &mock_chat.MockReactionSaver{}
+		mockReactionSaver.On("AddReaction", mock.Anything, &expected.Reaction).Return(int64(3), nil)
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+		mockChatNotifier.On("ReactionUpdated", mock.Anything, expected).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:    memberChatProvider(uid),
+			ChatNotifier:    mockChatNotifier,
+			MessageProvider: mockMessageProvider,
+			ReactionSaver:   mockReactionSaver,
+		})
+
+		update, err := service.AddReaction(context.Background(), []byte("chat"), []byte("mid"), uid, "👍")
+		require.NoError(t, err)
+		assert.Equal(t, expected, update)
+		mockChatNotifier.AssertExpectations(t)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name     string
+			emoji    string
+			message  model.ChatMessage
+			saveErr  error
+			expected error
+		}{
+			{name: "InvalidEmoji", emoji: "+1", expected: validate.ErrEmoji},
+			{name: "MessageDeleted", emoji: "👍", message: model.ChatMessage{Deleted: true}, expected: ErrMessageDeleted},
+			{name: "Exists", emoji: "👍", saveErr: chat.ErrReactionExists, expected: ErrReactionExists},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				mockMessageProvider := &mock_chat.MockMessageProvider{}
+				mockMessageProvider.On("Message", mock.Anything, mock.Anything, mock.Anything).Return(tt.message, nil)
+				mockReactionSaver := &mock_chat.MockReactionSaver{}
+				mockReactionSaver.On("AddReaction", mock.Anything, mock.Anything).Return(int64(0), tt.saveErr)
+				mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+				service := NewService(log, Deps{
+					ChatProvider:    memberChatProvider(uid),
+					ChatNotifier:    mockChatNotifier,
+					MessageProvider: mockMessageProvider,
+					ReactionSaver:   mockReactionSaver,
+				})
+
+				_, err := service.AddReaction(context.Background(), []byte("chat"), []byte("mid"), uid, tt.emoji)
+				assert.ErrorIs(t, err, tt.expected)
+				mockChatNotifier.AssertNotCalled(t, "ReactionUpdated")
+			})
+		}
+	})
+}
+
+func TestRemoveReaction(t *testing.T) {
+	t.Parallel()
+
+	uid := []byte("user")
+	reaction := model.Reaction{Cid: []byte("chat"), Mid: []byte("mid"), Uid: uid, Emoji: "👍"}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockReactionSaver := &mock_chat.MockReactionSaver{}
+		mockReactionSaver.On("RemoveReaction", mock.Anything, &reaction).Return(int64(0), nil)
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+		mockChatNotifier.On("ReactionUpdated", mock.Anything, mock.Anything).Return(nil)
+
+		service := NewService(log, Deps{
+			ChatProvider:  memberChatProvider(uid),
+			ChatNotifier:  mockChatNotifier,
+			ReactionSaver: mockReactionSaver,
+		})
+
+		update, err := service.RemoveReaction(context.Background(), []byte("chat"), []byte("mid"), uid, "👍")
+		require.NoError(t, err)
+		assert.Equal(t, &model.ReactionUpdate{Reaction: reaction}, update, "removed with no count left")
+		mockChatNotifier.AssertExpectations(t)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		mockReactionSaver := &mock_chat.MockReactionSaver{}
+		mockReactionSaver.On("RemoveReaction", mock.Anything, mock.Anything).Return(int64(0), chat.ErrReactionNotFound)
+		mockChatNotifier := &mock_chat.MockChatNotifier{}
+
+		service := NewService(log, Deps{
+			ChatProvider:  memberChatProvider(uid),
+			ChatNotifier:  mockChatNotifier,
+			ReactionSaver: mockReactionSaver,
+		})
+
+		_, err := service.RemoveReaction(context.Background(), []byte("chat"), []byte("mid"), uid, "👍")
+		assert.ErrorIs(t, err, ErrReactionNotFound)
+		mockChatNotifier.AssertNotCalled(t, "ReactionUpdated")
+	})
+}
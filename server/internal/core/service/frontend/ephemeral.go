@@ -0,0 +1,111 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/id"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const throttleSweepSize = 1024
+
+type ChatMembership interface {
+	InChat(clientId []byte, cid []byte) bool
+}
+
+type EphemeralNotifier interface {
+	Typing(ctx context.Context, cid []byte, uid []byte) error
+	Presence(ctx context.Context, cid []byte, uid []byte, online bool) error
+}
+
+type Ephemeral struct {
+	log *slog.Logger
+
+	cm ChatMembership
+	en EphemeralNotifier
+
+	typing *throttle
+}
+
+func NewEphemeral(log *slog.Logger, cm ChatMembership, en EphemeralNotifier, typingInterval time.Duration) *Ephemeral {
+	return &Ephemeral{
+		log:    log,
+		cm:     cm,
+		en:     en,
+		typing: newThrottle(typingInterval),
+	}
+}
+
+func (e *Ephemeral) Typing(ctx context.Context, clientId []byte, uid []byte, cid []byte) error {
+	const op = "ephemeral.Typing"
+	log := e.log.With(slog.String("op", op), slog.String("c", id.String(clientId)))
+
+	if !e.cm.InChat(clientId, cid) {
+		return fmt.Errorf("%s: %w", op, primary.ErrPermissionDenied)
+	}
+	if !e.typing.allow(string(clientId) + string(cid)) {
+		return fmt.Errorf("%s: %w", op, primary.ErrThrottled)
+	}
+
+	if err := e.en.Typing(ctx, cid, uid); err != nil {
+		log.Error("failed to notify typing", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (e *Ephemeral) PresenceChanged(uid []byte, cids [][]byte, online bool) {
+	const op = "ephemeral.PresenceChanged"
+	log := e.log.With(slog.String("op", op), slog.String("uid", id.String(uid)))
+
+	log.Debug(fmt.Sprintf("user online=%t", online))
+
+	for _, cid := range cids {
+		if err := e.en.Presence(context.Background(), cid, uid, online); err != nil {
+			log.Error("failed to notify presence in "+id.String(cid), logger.Err(err))
+		}
+	}
+}
+
+type throttle struct {
+	interval time.Duration
+	last     map[string]time.Time
+	now      func() time.Time
+
+	mu *sync.Mutex
+}
+
+func newThrottle(interval time.Duration) *throttle {
+	return &throttle{
+		interval: interval,
+		last:     make(map[string]time.Time),
+		now:      time.Now,
+		mu:       &sync.Mutex{},
+	}
+}
+
+func (t *throttle) allow(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if last, ok := t.last[key]; ok && now.Sub(last) < t.interval {
+		return false
+	}
+
+	if len(t.last) >= throttleSweepSize {
+		for k, last := range t.last {
+			if now.Sub(last) >= t.interval {
+				delete(t.last, k)
+			}
+		}
+	}
+	t.last[key] = now
+
+	return true
+}
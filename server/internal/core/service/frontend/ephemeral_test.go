@@ -0,0 +1,152 @@
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/proto"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+var log = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+type testClient struct {
+	id   []byte
+	msgs []*frontendv1.Downstream
+	mu   sync.Mutex
+}
+
+func (c *testClient) GetId() []byte {
+	return c.id
+}
+
+func (c *testClient) Send(msg []byte) error {
+	downstream := &frontendv1.Downstream{}
+	if err := proto.Unmarshal(msg, downstream); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.msgs = append(c.msgs, downstream)
+
+	return nil
+}
+
+func (c *testClient) received() []frontendv1.DownstreamType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	types := make([]frontendv1.DownstreamType, 0, len(c.msgs))
+	for _, msg := range c.msgs {
+		types = append(types, msg.GetType())
+	}
+
+	return types
+}
+
+type presenceRecorder struct {
+	changes []bool
+	mu      sync.Mutex
+}
+
+func (r *presenceRecorder) PresenceChanged(uid []byte, cids [][]byte, online bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, online)
+}
+
+func newId() []byte {
+	uid := uuid.New()
+	return uid[:]
+}
+
+func connect(t *testing.T, registry *ClientRegistry, uid []byte, chats ...model.Chat) *testClient {
+	client := &testClient{id: newId()}
+	require.NoError(t, registry.Register(client))
+	require.NoError(t, registry.SetInfo(client.id, &model.User{Id: uid}, chats))
+
+	return client
+}
+
+func TestPresence(t *testing.T) {
+	t.Parallel()
+
+	uid := newId()
+	chat := model.Chat{Id: newId(), Members: []model.ChatMember{{Uid: uid}}}
+
+	registry := NewClientRegistry(log, nil, nil)
+	recorder := &presenceRecorder{}
+	registry.WatchPresence(recorder)
+
+	first := connect(t, registry, uid, chat)
+	second := connect(t, registry, uid, chat)
+	require.NoError(t, registry.SetAuth(second.id, "token"))
+	assert.Equal(t, []bool{true}, recorder.changes, "second connection does not repeat online")
+
+	require.NoError(t, registry.Unregister(first.id))
+	assert.Equal(t, []bool{true}, recorder.changes, "user is online while connection remains")
+
+	require.NoError(t, registry.UnsetAuth(second.id))
+	assert.Equal(t, []bool{true, false}, recorder.changes, "last connection gone makes user offline")
+}
+
+func TestPresenceDelivery(t *testing.T) {
+	t.Parallel()
+
+	uid1, uid2 := newId(), newId()
+	chat := model.Chat{Id: newId(), Members: []model.ChatMember{{Uid: uid1}, {Uid: uid2}}}
+
+	registry := NewClientRegistry(log, nil, nil)
+	notifier := NewNotifier(log, registry, registry)
+	registry.WatchPresence(NewEphemeral(log, registry, notifier, time.Second))
+
+	other := connect(t, registry, uid2, chat)
+	own := connect(t, registry, uid1, chat)
+
+	assert.Equal(t, []frontendv1.DownstreamType{frontendv1.DownstreamType_D_PRESENCE}, other.received())
+	assert.True(t, other.msgs[0].GetPush())
+	assert.Zero(t, other.msgs[0].GetRequestId())
+	assert.Empty(t, own.received(), "user is not notified about own presence")
+}
+
+func TestTyping(t *testing.T) {
+	t.Parallel()
+
+	uid1, uid2 := newId(), newId()
+	chat := model.Chat{Id: newId(), Members: []model.ChatMember{{Uid: uid1}, {Uid: uid2}}}
+
+	registry := NewClientRegistry(log, nil, nil)
+	notifier := NewNotifier(log, registry, registry)
+	ephemeral := NewEphemeral(log, registry, notifier, time.Second)
+
+	now := time.Now()
+	ephemeral.typing.now = func() time.Time { return now }
+
+	typer := connect(t, registry, uid1, chat)
+	sibling := connect(t, registry, uid1, chat)
+	other := connect(t, registry, uid2, chat)
+	stranger := connect(t, registry, newId())
+
+	require.NoError(t, ephemeral.Typing(context.Background(), typer.id, uid1, chat.Id))
+	assert.ErrorIs(t, ephemeral.Typing(context.Background(), typer.id, uid1, chat.Id), primary.ErrThrottled)
+	require.NoError(t, ephemeral.Typing(context.Background(), sibling.id, uid1, chat.Id), "throttled per client")
+
+	now = now.Add(time.Second)
+	require.NoError(t, ephemeral.Typing(context.Background(), typer.id, uid1, chat.Id))
+
+	assert.ErrorIs(t, ephemeral.Typing(context.Background(), stranger.id, uid1, chat.Id), primary.ErrPermissionDenied)
+
+	typing := frontendv1.DownstreamType_D_TYPING
+	assert.Equal(t, []frontendv1.DownstreamType{typing, typing, typing}, other.received())
+	assert.Empty(t, typer.received(), "typing is not echoed to own connections")
+	assert.Empty(t, sibling.received(), "typing is not echoed to own connections")
+}
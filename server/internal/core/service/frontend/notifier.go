@@ -10,6 +10,7 @@ import (
 	"github.com/dvid-messanger/pkg/id"
 	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
 	"log/slog"
+	"slices"
 	"strconv"
 )
 
@@ -22,10 +23,12 @@ type Notifier struct {
 
 type ClientProvider interface {
 	Clients(cid []byte) ([]Client, error)
+	OtherClients(cid []byte, uid []byte) ([]Client, error)
 }
 
 type ChatRegistry interface {
 	RegisterChat(chat *model.Chat) error
+	UpdateChat(chat *model.Chat) ([]Client, error)
 }
 
 func NewNotifier(log *slog.Logger, cp ClientProvider, cr ChatRegistry) *Notifier {
@@ -47,13 +50,10 @@ func (n *Notifier) NewMessage(ctx context.Context, message *model.ChatMessage) e
 	downstream, err := makeDownstream(message)
 	if err != nil {
 		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, c := range clients {
-		c.Send(downstream)
-	}
-
-	log.Debug("notified " + strconv.Itoa(len(clients)) + " clients in " + id.String(message.Cid))
+	send(log, "chat "+id.String(message.Cid), clients, downstream)
 
 	return nil
 }
@@ -75,6 +75,244 @@ func (n *Notifier) NewChat(ctx context.Context, chat *model.Chat) error {
 	return nil
 }
 
+func (n *Notifier) ChatUpdated(ctx context.Context, chat *model.Chat) error {
+	const op = "frontend.ChatUpdated"
+	log := n.log.With(slog.String("op", op))
+
+	log.Debug("notifying chat updated " + id.String(chat.Id))
+
+	removed, err := n.cr.UpdateChat(chat)
+	if err != nil {
+		log.Error("failed to update chat", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	clients, err := n.cp.Clients(chat.Id)
+	if err != nil {
+		log.Error("failed to get clients", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	downstream, err := makeChatUpdatedDownstream(chat)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	send(log, "chat "+id.String(chat.Id), slices.Concat(clients, removed), downstream)
+
+	return nil
+}
+
+func (n *Notifier) MessageEdited(ctx context.Context, message *model.ChatMessage) error {
+	const op = "frontend.MessageEdited"
+	log := n.log.With(slog.String("op", op))
+
+	log.Debug("notifying message edited in " + id.String(message.Cid))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamMessageEdited{Message: converter.ChatMessageToDTO(message)},
+		frontendv1.DownstreamType_D_MESSAGE_EDITED,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = n.sendToChat(log, message.Cid, downstream); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) MessageDeleted(ctx context.Context, message *model.ChatMessage) error {
+	const op = "frontend.MessageDeleted"
+	log := n.log.With(slog.String("op", op))
+
+	log.Debug("notifying message deleted in " + id.String(message.Cid))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamMessageDeleted{Message: converter.ChatMessageToDTO(message)},
+		frontendv1.DownstreamType_D_MESSAGE_DELETED,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = n.sendToChat(log, message.Cid, downstream); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error {
+	const op = "frontend.MessagesRead"
+	log := n.log.With(slog.String("op", op))
+
+	log.Debug("notifying messages read in " + id.String(receipt.Cid))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamMessagesRead{Receipt: converter.ReadReceiptToDTO(receipt)},
+		frontendv1.DownstreamType_D_MESSAGES_READ,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = n.sendToChat(log, receipt.Cid, downstream); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error {
+	const op = "frontend.ReactionUpdated"
+	log := n.log.With(slog.String("op", op))
+
+	log.Debug("notifying reaction updated in " + id.String(update.Cid))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamReactionUpdated{Update: converter.ReactionUpdateToDTO(update)},
+		frontendv1.DownstreamType_D_REACTION_UPDATED,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = n.sendToChat(log, update.Cid, downstream); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) Typing(ctx context.Context, cid []byte, uid []byte) error {
+	const op = "frontend.Typing"
+	log := n.log.With(slog.String("op", op))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamTyping{Cid: cid, Uid: uid},
+		frontendv1.DownstreamType_D_TYPING,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = n.sendToOthers(log, cid, uid, downstream); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (n *Notifier) Presence(ctx context.Context, cid []byte, uid []byte, online bool) error {
+	const op = "frontend.Presence"
+	log := n.log.With(slog.String("op", op))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamPresence{Cid: cid, Uid: uid, Online: online},
+		frontendv1.DownstreamType_D_PRESENCE,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = n.sendToOthers(log, cid, uid, downstream); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ProfileUpdated pushes the user once to every client in any of cids, clients
+// sharing several of them with the user are not notified twice.
+func (n *Notifier) ProfileUpdated(ctx context.Context, cids [][]byte, user *model.User) error {
+	const op = "frontend.ProfileUpdated"
+	log := n.log.With(slog.String("op", op))
+
+	downstream, err := proto.MarshalDownstream(
+		&frontendv1.DownstreamProfileUpdated{User: converter.UserToDTO(user)},
+		frontendv1.DownstreamType_D_PROFILE_UPDATED,
+		nil,
+	)
+	if err != nil {
+		log.Error("failed to make downstream message", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	seen := make(map[string]struct{})
+	var recipients []Client
+	for _, cid := range cids {
+		clients, err := n.cp.Clients(cid)
+		if err != nil {
+			log.Error("failed to get clients", logger.Err(err))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		for _, c := range clients {
+			if _, ok := seen[string(c.GetId())]; ok {
+				continue
+			}
+			seen[string(c.GetId())] = struct{}{}
+			recipients = append(recipients, c)
+		}
+	}
+
+	send(log, "contacts of user "+id.String(user.Id), recipients, downstream)
+
+	return nil
+}
+
+func (n *Notifier) sendToChat(log *slog.Logger, cid []byte, downstream []byte) error {
+	clients, err := n.cp.Clients(cid)
+	if err != nil {
+		log.Error("failed to get clients", logger.Err(err))
+		return err
+	}
+
+	send(log, "chat "+id.String(cid), clients, downstream)
+
+	return nil
+}
+
+func (n *Notifier) sendToOthers(log *slog.Logger, cid []byte, uid []byte, downstream []byte) error {
+	clients, err := n.cp.OtherClients(cid, uid)
+	if err != nil {
+		log.Error("failed to get clients", logger.Err(err))
+		return err
+	}
+
+	send(log, "chat "+id.String(cid), clients, downstream)
+
+	return nil
+}
+
+// send pushes downstream to clients, to names the recipients in the log.
+func send(log *slog.Logger, to string, clients []Client, downstream []byte) {
+	failed := 0
+	for _, c := range clients {
+		if err := c.Send(downstream); err != nil {
+			failed++
+			log.Warn("failed to send to client", slog.String("c", id.String(c.GetId())), logger.Err(err))
+		}
+	}
+
+	log.Debug("notified " + strconv.Itoa(len(clients)-failed) + " of " + strconv.Itoa(len(clients)) + " clients, " + to)
+}
+
 func makeDownstream(message *model.ChatMessage) ([]byte, error) {
 	downstream := &frontendv1.DownstreamNewMessage{Message: converter.ChatMessageToDTO(message)}
 
@@ -84,3 +322,13 @@ func makeDownstream(message *model.ChatMessage) ([]byte, error) {
 		nil,
 	)
 }
+
+func makeChatUpdatedDownstream(chat *model.Chat) ([]byte, error) {
+	downstream := &frontendv1.DownstreamChatUpdated{Chat: converter.ChatToDTO(chat)}
+
+	return proto.MarshalDownstream[*frontendv1.DownstreamChatUpdated](
+		downstream,
+		frontendv1.DownstreamType_D_CHAT_UPDATED,
+		nil,
+	)
+}
@@ -0,0 +1,37 @@
+package frontend
+
+import (
+	"context"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/proto"
+	frontendv1 "github.com/dvid-messanger/protos/gen/frontend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNotifierProfileUpdated(t *testing.T) {
+	t.Parallel()
+
+	uid, contactUid := newId(), newId()
+	members := []model.ChatMember{{Uid: uid}, {Uid: contactUid}}
+	direct := model.Chat{Id: newId(), Members: members}
+	group := model.Chat{Id: newId(), Members: members}
+	unrelated := model.Chat{Id: newId(), Members: []model.ChatMember{{Uid: newId()}}}
+
+	registry := NewClientRegistry(log, nil, nil)
+	notifier := NewNotifier(log, registry, registry)
+
+	contact := connect(t, registry, contactUid, direct, group)
+	stranger := connect(t, registry, newId(), unrelated)
+
+	usr := &model.User{Id: uid, DisplayName: "Alice"}
+	require.NoError(t, notifier.ProfileUpdated(context.Background(), [][]byte{direct.Id, group.Id}, usr))
+
+	require.Equal(t, []frontendv1.DownstreamType{frontendv1.DownstreamType_D_PROFILE_UPDATED}, contact.received(),
+		"pushed once for both shared chats")
+	pushed := &frontendv1.DownstreamProfileUpdated{}
+	require.NoError(t, proto.Unmarshal(contact.msgs[0].GetPayload(), pushed))
+	assert.Equal(t, "Alice", pushed.GetUser().GetDisplayName())
+	assert.Empty(t, stranger.received())
+}
@@ -0,0 +1,81 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/id"
+	"log/slog"
+	"time"
+)
+
+const notifyProfileTimeout = 10 * time.Second
+
+type ProfileUpdater interface {
+	Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error)
+}
+
+type UserChatsProvider interface {
+	UserChats(ctx context.Context, uid []byte) ([]model.Chat, error)
+}
+
+type ProfileNotifier interface {
+	ProfileUpdated(ctx context.Context, cids [][]byte, user *model.User) error
+}
+
+// Profile updates the user's profile and pushes it to every chat the user
+// is in, so contacts see the change without reloading.
+type Profile struct {
+	log *slog.Logger
+
+	users ProfileUpdater
+	chats UserChatsProvider
+	pn    ProfileNotifier
+}
+
+func NewProfile(log *slog.Logger, users ProfileUpdater, chats UserChatsProvider, pn ProfileNotifier) *Profile {
+	return &Profile{log: log, users: users, chats: chats, pn: pn}
+}
+
+func (p *Profile) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error) {
+	const op = "profile.Update"
+	log := p.log.With(slog.String("op", op), slog.String("uid", id.String(uid)))
+
+	usr, err := p.users.Update(ctx, uid, upd)
+	if err != nil {
+		log.Debug("failed to update profile", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The update is stored, notifying contacts neither holds up the response
+	// nor is cut short by the request ending.
+	go p.notify(context.WithoutCancel(ctx), log, usr)
+
+	return usr, nil
+}
+
+// notify pushes usr once to the chats it is in, failing only delays contacts
+// until they reload.
+func (p *Profile) notify(ctx context.Context, log *slog.Logger, usr *model.User) {
+	ctx, cancel := context.WithTimeout(ctx, notifyProfileTimeout)
+	defer cancel()
+
+	chats, err := p.chats.UserChats(ctx, usr.Id)
+	if err != nil {
+		log.Error("failed to get chats to notify", logger.Err(err))
+		return
+	}
+	if len(chats) == 0 {
+		return
+	}
+
+	cids := make([][]byte, 0, len(chats))
+	for _, chat := range chats {
+		cids = append(cids, chat.Id)
+	}
+
+	if err = p.pn.ProfileUpdated(ctx, cids, usr); err != nil {
+		log.Error("failed to notify profile updated", logger.Err(err))
+	}
+}
@@ -0,0 +1,75 @@
+package frontend_test
+
+import (
+	"context"
+	"errors"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/frontend"
+	"github.com/dvid-messanger/test/mocks/mock_frontend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestProfileUpdate(t *testing.T) {
+	t.Parallel()
+
+	uid := testId()
+	name := "Alice"
+	upd := model.ProfileUpdate{DisplayName: &name}
+	chats := []model.Chat{{Id: testId()}, {Id: testId()}}
+
+	t.Run("NotifiesDetached", func(t *testing.T) {
+		t.Parallel()
+
+		usr := &model.User{Id: uid, DisplayName: name}
+		users := mock_frontend.NewMockProfileUpdater(t)
+		userChats := mock_frontend.NewMockUserChatsProvider(t)
+		notifier := mock_frontend.NewMockProfileNotifier(t)
+		notified := make(chan struct{})
+
+		users.EXPECT().Update(mock.Anything, uid, upd).Return(usr, nil)
+		userChats.EXPECT().UserChats(mock.Anything, uid).RunAndReturn(
+			func(ctx context.Context, _ []byte) ([]model.Chat, error) {
+				return chats, ctx.Err()
+			})
+		notifier.EXPECT().ProfileUpdated(mock.Anything, [][]byte{chats[0].Id, chats[1].Id}, usr).
+			Run(func(context.Context, [][]byte, *model.User) { close(notified) }).
+			Return(nil).
+			Once()
+
+		// the request ending must not cut the notification short
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		updated, err := frontend.NewProfile(testLog, users, userChats, notifier).Update(ctx, uid, upd)
+		require.NoError(t, err)
+		assert.Equal(t, name, updated.DisplayName)
+
+		select {
+		case <-notified:
+		case <-time.After(time.Second):
+			t.Fatal("contacts not notified")
+		}
+	})
+	t.Run("UpdateFailed", func(t *testing.T) {
+		t.Parallel()
+
+		errFailed := errors.New("failed")
+		users := mock_frontend.NewMockProfileUpdater(t)
+		users.EXPECT().Update(mock.Anything, uid, upd).Return(nil, errFailed)
+
+		// the other mocks fail the test on any call
+		profile := frontend.NewProfile(
+			testLog,
+			users,
+			mock_frontend.NewMockUserChatsProvider(t),
+			mock_frontend.NewMockProfileNotifier(t),
+		)
+
+		_, err := profile.Update(context.Background(), uid, upd)
+		assert.ErrorIs(t, err, errFailed)
+	})
+}
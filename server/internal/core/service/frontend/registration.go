@@ -0,0 +1,83 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
+	"github.com/dvid-messanger/internal/pkg/logger"
+	"github.com/dvid-messanger/pkg/id"
+	"log/slog"
+	"time"
+)
+
+const compensateTimeout = 5 * time.Second
+
+var ErrCompensationFailed = errors.New("registration compensation failed")
+
+type UserAccounts interface {
+	Create(ctx context.Context, email string, bio string) (*model.User, error)
+	Delete(ctx context.Context, uid []byte) error
+}
+
+type AuthAccounts interface {
+	Create(ctx context.Context, uid []byte, email string, pass string) ([]byte, error)
+	Delete(ctx context.Context, uid []byte) error
+}
+
+// Registration creates the user and its credentials, undoing both when
+// either step fails so no half registered user is left behind.
+type Registration struct {
+	log  *slog.Logger
+	user UserAccounts
+	auth AuthAccounts
+}
+
+func NewRegistration(log *slog.Logger, user UserAccounts, auth AuthAccounts) *Registration {
+	return &Registration{log: log, user: user, auth: auth}
+}
+
+func (r *Registration) Register(ctx context.Context, email string, password string, bio string) (*model.User, error) {
+	const op = "registration.Register"
+	log := r.log.With(slog.String("op", op))
+
+	if err := validate.Email(email); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := validate.Password(password); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	email = validate.NormalizeEmail(email)
+
+	usr, err := r.user.Create(ctx, email, bio)
+	if err != nil {
+		log.Debug("failed to create user", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = r.auth.Create(ctx, usr.Id, email, password); err != nil {
+		log.Warn("failed to create auth, compensating", slog.String("uid", id.String(usr.Id)), logger.Err(err))
+
+		if cErr := r.compensate(ctx, usr.Id); cErr != nil {
+			log.Error("failed to compensate registration", slog.String("uid", id.String(usr.Id)), logger.Err(cErr))
+			return nil, fmt.Errorf("%s: %w", op, errors.Join(err, ErrCompensationFailed))
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return usr, nil
+}
+
+// compensate deletes the credentials too, as a failed create may still have
+// been applied. It outlives ctx, which may be what made the create fail.
+func (r *Registration) compensate(ctx context.Context, uid []byte) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), compensateTimeout)
+	defer cancel()
+
+	if err := r.auth.Delete(ctx, uid); err != nil {
+		return err
+	}
+
+	return r.user.Delete(ctx, uid)
+}
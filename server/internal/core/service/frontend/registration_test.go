@@ -0,0 +1,83 @@
+package frontend_test
+
+import (
+	"context"
+	"errors"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
+	"github.com/dvid-messanger/internal/core/service/frontend"
+	"github.com/dvid-messanger/test/mocks/mock_frontend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRegistration(t *testing.T) {
+	t.Parallel()
+
+	const (
+		email = "test@example.com"
+		pass  = "passw0rd"
+	)
+	uid := []byte("uid")
+	errFailed := errors.New("failed")
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		users := mock_frontend.NewMockUserAccounts(t)
+		creds := mock_frontend.NewMockAuthAccounts(t)
+		users.EXPECT().Create(mock.Anything, email, "bio").Return(&model.User{Id: uid, Email: email}, nil)
+		creds.EXPECT().Create(mock.Anything, uid, email, pass).Return(uid, nil)
+
+		r := frontend.NewRegistration(testLog, users, creds)
+
+		usr, err := r.Register(context.Background(), "Test@Example.com", pass, "bio")
+		require.NoError(t, err)
+		assert.Equal(t, email, usr.Email, "email lower cased")
+	})
+	t.Run("InvalidInput", func(t *testing.T) {
+		t.Parallel()
+
+		// the mocks fail the test on any call
+		r := frontend.NewRegistration(testLog, mock_frontend.NewMockUserAccounts(t), mock_frontend.NewMockAuthAccounts(t))
+
+		_, err := r.Register(context.Background(), "not-an-email", pass, "bio")
+		assert.ErrorIs(t, err, validate.ErrInvalidEmail)
+		_, err = r.Register(context.Background(), email, "password", "bio")
+		assert.ErrorIs(t, err, validate.ErrWeakPassword)
+	})
+	t.Run("AuthFailedCompensates", func(t *testing.T) {
+		t.Parallel()
+
+		users := mock_frontend.NewMockUserAccounts(t)
+		creds := mock_frontend.NewMockAuthAccounts(t)
+		users.EXPECT().Create(mock.Anything, email, "bio").Return(&model.User{Id: uid, Email: email}, nil)
+		creds.EXPECT().Create(mock.Anything, uid, email, pass).Return(nil, errFailed)
+		creds.EXPECT().Delete(mock.Anything, uid).Return(nil)
+		users.EXPECT().Delete(mock.Anything, uid).Return(nil)
+
+		r := frontend.NewRegistration(testLog, users, creds)
+
+		_, err := r.Register(context.Background(), email, pass, "bio")
+		assert.ErrorIs(t, err, errFailed)
+		assert.NotErrorIs(t, err, frontend.ErrCompensationFailed)
+	})
+	t.Run("CompensationFailed", func(t *testing.T) {
+		t.Parallel()
+
+		users := mock_frontend.NewMockUserAccounts(t)
+		creds := mock_frontend.NewMockAuthAccounts(t)
+		users.EXPECT().Create(mock.Anything, email, "bio").Return(&model.User{Id: uid, Email: email}, nil)
+		creds.EXPECT().Create(mock.Anything, uid, email, pass).Return(nil, errFailed)
+		creds.EXPECT().Delete(mock.Anything, uid).Return(nil)
+		users.EXPECT().Delete(mock.Anything, uid).Return(errFailed)
+
+		r := frontend.NewRegistration(testLog, users, creds)
+
+		_, err := r.Register(context.Background(), email, pass, "bio")
+		assert.ErrorIs(t, err, errFailed)
+		assert.ErrorIs(t, err, frontend.ErrCompensationFailed)
+	})
+}
@@ -2,30 +2,37 @@ package frontend
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/primary"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/modelutil"
+	"github.com/dvid-messanger/internal/pkg/logger"
 	"github.com/dvid-messanger/pkg/id"
 	"log/slog"
 	"slices"
 	"strconv"
 	"sync"
+	"time"
 )
 
 var (
 	ErrClientNotRegistered     = errors.New("client not registered")
 	ErrClientAlreadyRegistered = errors.New("client already registered")
 	ErrClientNotAuthorized     = errors.New("client not authorized")
-	ErrClientAlreadyAuthorized = errors.New("client already authorized")
+	ErrClientAlreadyAuthorized = &primary.Error{Kind: primary.ErrFailedPrecondition, Desc: "client already authorized"}
 
 	ErrClientAlreadyMappedToUser = errors.New("client already mapped to user")
+	ErrClientMappedToOtherUser   = errors.New("client mapped to other user")
 
 	ErrChatAlreadyRegistered = errors.New("chat already registered")
 )
 
 const (
 	claimAuth = "auth"
+
+	presenceTimeout = 2 * time.Second
 )
 
 type Client interface {
@@ -33,6 +40,29 @@ type Client interface {
 	GetId() []byte
 }
 
+type ChatWatcher interface {
+	WatchChat(cid []byte) error
+	UnwatchChat(cid []byte)
+}
+
+type PresenceWatcher interface {
+	PresenceChanged(uid []byte, cids [][]byte, online bool)
+}
+
+// PresenceStore counts users connected across all frontend instances, each
+// instance reports only its own first and last connection of a user. The
+// results tell whether the user went online or offline globally.
+type PresenceStore interface {
+	Connect(ctx context.Context, uid []byte) (bool, error)
+	Disconnect(ctx context.Context, uid []byte) (bool, error)
+}
+
+type presence struct {
+	uid    []byte
+	cids   [][]byte
+	online bool
+}
+
 type clientWithClaims struct {
 	client Client
 	claims map[string]interface{}
@@ -42,21 +72,48 @@ type ClientRegistry struct {
 	log         *slog.Logger
 	clients     map[[16]byte]*clientWithClaims
 	clientUser  map[[16]byte]*model.User
+	userClients map[[16]byte]int
 	chatClients map[[16]byte][]Client
 
+	cw ChatWatcher
+	pw PresenceWatcher
+	ps PresenceStore
+
+	// sharedUsers are the users reported connected to ps, guarded by presenceMu
+	sharedUsers map[[16]byte]struct{}
+
 	mu *sync.RWMutex
+	// watchMu orders chat watcher calls, they are made outside of mu
+	watchMu *sync.Mutex
+	// presenceMu orders presence store calls, they are made outside of mu
+	presenceMu *sync.Mutex
 }
 
-func NewClientRegistry(log *slog.Logger) *ClientRegistry {
+// NewClientRegistry counts presence locally when ps is nil, which is only
+// right for a single frontend instance.
+func NewClientRegistry(log *slog.Logger, cw ChatWatcher, ps PresenceStore) *ClientRegistry {
 	return &ClientRegistry{
 		log:         log,
+		cw:          cw,
+		ps:          ps,
 		clients:     make(map[[16]byte]*clientWithClaims),
 		clientUser:  make(map[[16]byte]*model.User),
+		userClients: make(map[[16]byte]int),
 		chatClients: make(map[[16]byte][]Client),
+		sharedUsers: make(map[[16]byte]struct{}),
 		mu:          &sync.RWMutex{},
+		watchMu:     &sync.Mutex{},
+		presenceMu:  &sync.Mutex{},
 	}
 }
 
+func (cr *ClientRegistry) WatchPresence(pw PresenceWatcher) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	cr.pw = pw
+}
+
 func (cr *ClientRegistry) Register(client primary.Client) error {
 	const op = "registry.Register"
 	log := cr.log.With(slog.String("op", op), slog.String("c", id.String(client.GetId())))
@@ -103,6 +160,38 @@ func (cr *ClientRegistry) SetAuth(clientId []byte, auth string) error {
 	return nil
 }
 
+// ReplaceAuth only refreshes the auth of an authorized client, uid is the
+// owner of the new auth and must match the user the client is mapped to.
+func (cr *ClientRegistry) ReplaceAuth(clientId []byte, uid []byte, auth string) error {
+	const op = "registry.ReplaceAuth"
+	log := cr.log.With(slog.String("op", op), slog.String("c", id.String(clientId)))
+
+	log.Debug("replacing auth")
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	clientClaims, ok := cr.clients[id.Id(clientId)]
+	if !ok {
+		log.Error("not registered")
+		return fmt.Errorf("%s: %w", op, ErrClientNotRegistered)
+	}
+	if clientClaims.claims[claimAuth] == nil {
+		log.Error("not authorized")
+		return fmt.Errorf("%s: %w", op, ErrClientNotAuthorized)
+	}
+	if user, ok := cr.clientUser[id.Id(clientId)]; ok && !bytes.Equal(user.Id, uid) {
+		log.Error("mapped to uid " + id.String(user.Id) + ", not " + id.String(uid))
+		return fmt.Errorf("%s: %w", op, ErrClientMappedToOtherUser)
+	}
+
+	clientClaims.claims[claimAuth] = auth
+
+	log.Debug("auth replaced")
+
+	return nil
+}
+
 func (cr *ClientRegistry) Auth(clientId []byte) (string, error) {
 	const op = "registry.Auth"
 	log := cr.log.With(slog.String("op", op), slog.String("c", id.String(clientId)))
@@ -131,18 +220,23 @@ func (cr *ClientRegistry) UnsetAuth(clientId []byte) error {
 	log.Debug("unsetting auth")
 
 	cr.mu.Lock()
-	defer cr.mu.Unlock()
 	clientClaims, ok := cr.clients[id.Id(clientId)]
 	if !ok {
+		cr.mu.Unlock()
 		return fmt.Errorf("%s: %w", op, ErrClientNotRegistered)
 	}
 	_, ok = clientClaims.claims[claimAuth]
 	if !ok {
+		cr.mu.Unlock()
 		return fmt.Errorf("%s: %w", op, ErrClientNotAuthorized)
 	}
 
 	delete(clientClaims.claims, claimAuth)
-	cr.removeClientInfo(clientId)
+	p, unwatched := cr.removeClientInfo(clientId)
+	cr.mu.Unlock()
+
+	cr.syncWatch(unwatched)
+	cr.notifyPresence(p)
 
 	log.Debug("auth unset")
 	return nil
@@ -154,23 +248,23 @@ func (cr *ClientRegistry) SetInfo(clientId []byte, user *model.User, chats []mod
 
 	log.Debug("setting info for uid " + id.String(user.Id))
 
-	cr.mu.Lock()
-	defer cr.mu.Unlock()
-
-	clientClaims, ok := cr.clients[id.Id(clientId)]
-	if !ok {
-		return fmt.Errorf("%s: %w", op, ErrClientNotRegistered)
-	}
-	_, ok = cr.clientUser[id.Id(clientId)]
-	if ok {
-		return fmt.Errorf("%s: %w", op, ErrClientAlreadyMappedToUser)
+	p, watched, err := cr.setClientUser(clientId, user, chats)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
+	if err = cr.syncWatch(watched); err != nil {
+		log.Error("failed to watch chats, rolling back")
 
-	cr.clientUser[id.Id(clientId)] = user
-	for _, chat := range chats {
-		cr.chatClients[id.Id(chat.Id)] = append(cr.chatClients[id.Id(chat.Id)], clientClaims.client)
+		cr.mu.Lock()
+		_, unwatched := cr.removeClientInfo(clientId)
+		cr.mu.Unlock()
+		cr.syncWatch(unwatched)
+
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	cr.notifyPresence(p)
+
 	log.Debug("client mapped to uid " + id.String(user.Id))
 	return nil
 }
@@ -182,15 +276,19 @@ func (cr *ClientRegistry) Unregister(clientId []byte) error {
 	log.Debug("unregistering")
 
 	cr.mu.Lock()
-	defer cr.mu.Unlock()
 	_, exists := cr.clients[id.Id(clientId)]
 	if !exists {
+		cr.mu.Unlock()
 		log.Error("not registered")
 		return fmt.Errorf("%s: %w", op, ErrClientNotRegistered)
 	}
 
 	delete(cr.clients, id.Id(clientId))
-	cr.removeClientInfo(clientId)
+	p, unwatched := cr.removeClientInfo(clientId)
+	cr.mu.Unlock()
+
+	cr.syncWatch(unwatched)
+	cr.notifyPresence(p)
 
 	log.Debug("unregistered")
 
@@ -212,6 +310,36 @@ func (cr *ClientRegistry) Clients(cid []byte) ([]Client, error) {
 	return clients, nil
 }
 
+func (cr *ClientRegistry) OtherClients(cid []byte, uid []byte) ([]Client, error) {
+	const op = "registry.OtherClients"
+	log := cr.log.With(slog.String("op", op))
+
+	log.Debug("getting other clients " + id.String(cid))
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	clients := make([]Client, 0, len(cr.chatClients[id.Id(cid)]))
+	for _, client := range cr.chatClients[id.Id(cid)] {
+		user, ok := cr.clientUser[id.Id(client.GetId())]
+		if ok && bytes.Equal(user.Id, uid) {
+			continue
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+func (cr *ClientRegistry) InChat(clientId []byte, cid []byte) bool {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	return slices.ContainsFunc(cr.chatClients[id.Id(cid)], func(client Client) bool {
+		return bytes.Equal(client.GetId(), clientId)
+	})
+}
+
 func (cr *ClientRegistry) RegisterChat(chat *model.Chat) error {
 	const op = "registry.RegisterChat"
 	log := cr.log.With(slog.String("op", op))
@@ -219,30 +347,133 @@ func (cr *ClientRegistry) RegisterChat(chat *model.Chat) error {
 	log.Debug("registering new chat " + id.String(chat.Id))
 
 	cr.mu.Lock()
-	defer cr.mu.Unlock()
 	_, exists := cr.chatClients[id.Id(chat.Id)]
 	if exists {
+		cr.mu.Unlock()
 		return fmt.Errorf("%s: %w", op, ErrChatAlreadyRegistered)
 	}
 
+	clients := cr.memberClients(chat)
+	cr.chatClients[id.Id(chat.Id)] = clients
+	cr.mu.Unlock()
+
+	if len(clients) != 0 {
+		if err := cr.syncWatch([][]byte{chat.Id}); err != nil {
+			cr.mu.Lock()
+			delete(cr.chatClients, id.Id(chat.Id))
+			cr.mu.Unlock()
+			cr.syncWatch([][]byte{chat.Id})
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Debug("registered new chat for " + strconv.Itoa(len(clients)) + " clients")
+
+	return nil
+}
+
+func (cr *ClientRegistry) UpdateChat(chat *model.Chat) ([]Client, error) {
+	const op = "registry.UpdateChat"
+	log := cr.log.With(slog.String("op", op))
+
+	log.Debug("updating chat " + id.String(chat.Id))
+
+	cr.mu.Lock()
+	clients := cr.memberClients(chat)
+	previous := cr.chatClients[id.Id(chat.Id)]
+	removed := make([]Client, 0)
+	for _, client := range previous {
+		if !slices.ContainsFunc(clients, func(c Client) bool {
+			return bytes.Equal(c.GetId(), client.GetId())
+		}) {
+			removed = append(removed, client)
+		}
+	}
+
+	cr.chatClients[id.Id(chat.Id)] = clients
+	cr.mu.Unlock()
+
+	if (len(previous) == 0) != (len(clients) == 0) {
+		if err := cr.syncWatch([][]byte{chat.Id}); err != nil {
+			cr.mu.Lock()
+			cr.chatClients[id.Id(chat.Id)] = previous
+			cr.mu.Unlock()
+			cr.syncWatch([][]byte{chat.Id})
+
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Debug("updated chat for " + strconv.Itoa(len(clients)) + " clients, " +
+		strconv.Itoa(len(removed)) + " clients removed")
+
+	return removed, nil
+}
+
+func (cr *ClientRegistry) memberClients(chat *model.Chat) []Client {
 	clients := make([]Client, 0)
 	for client, user := range cr.clientUser {
-		if slices.ContainsFunc(chat.Members, func(member model.ChatMember) bool {
-			return bytes.Equal(member.Uid, user.Id)
-		}) {
+		if modelutil.IsMember(chat, user.Id) {
 			clients = append(clients, cr.clients[client].client)
 		}
 	}
 
-	cr.chatClients[id.Id(chat.Id)] = clients
+	return clients
+}
 
-	log.Debug("registered new chat for " + strconv.Itoa(len(clients)) + " clients")
+// setClientUser also returns the chats that got their first client and need
+// to be watched.
+func (cr *ClientRegistry) setClientUser(
+	clientId []byte,
+	user *model.User,
+	chats []model.Chat,
+) (*presence, [][]byte, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
 
-	return nil
+	clientClaims, ok := cr.clients[id.Id(clientId)]
+	if !ok {
+		return nil, nil, ErrClientNotRegistered
+	}
+	// the info may already be set on the handshake, later chat changes keep it
+	// current, so only the user is refreshed
+	if mapped, ok := cr.clientUser[id.Id(clientId)]; ok {
+		if !bytes.Equal(mapped.Id, user.Id) {
+			return nil, nil, ErrClientAlreadyMappedToUser
+		}
+
+		cr.clientUser[id.Id(clientId)] = user
+		return nil, nil, nil
+	}
+
+	cr.clientUser[id.Id(clientId)] = user
+	cids := make([][]byte, 0, len(chats))
+	watched := make([][]byte, 0)
+	for _, chat := range chats {
+		if len(cr.chatClients[id.Id(chat.Id)]) == 0 {
+			watched = append(watched, chat.Id)
+		}
+		cr.chatClients[id.Id(chat.Id)] = append(cr.chatClients[id.Id(chat.Id)], clientClaims.client)
+		cids = append(cids, chat.Id)
+	}
+
+	cr.userClients[id.Id(user.Id)]++
+	if cr.userClients[id.Id(user.Id)] > 1 {
+		return nil, watched, nil
+	}
+
+	return &presence{uid: user.Id, cids: cids, online: true}, watched, nil
 }
 
-func (cr *ClientRegistry) removeClientInfo(clientId []byte) {
+// removeClientInfo also returns the chats left without clients that need to
+// be unwatched.
+func (cr *ClientRegistry) removeClientInfo(clientId []byte) (*presence, [][]byte) {
+	user, mapped := cr.clientUser[id.Id(clientId)]
 	delete(cr.clientUser, id.Id(clientId))
+
+	cids := make([][]byte, 0)
+	unwatched := make([][]byte, 0)
 	for cid, clients := range cr.chatClients {
 		idx := slices.IndexFunc(clients, func(chatClient Client) bool {
 			return bytes.Equal(chatClient.GetId(), clientId)
@@ -253,5 +484,108 @@ func (cr *ClientRegistry) removeClientInfo(clientId []byte) {
 
 		clients[idx] = clients[len(clients)-1]
 		cr.chatClients[cid] = clients[:len(clients)-1]
+		if len(clients) == 1 {
+			unwatched = append(unwatched, cid[:])
+		}
+		cids = append(cids, cid[:])
+	}
+
+	if !mapped {
+		return nil, unwatched
+	}
+
+	cr.userClients[id.Id(user.Id)]--
+	if cr.userClients[id.Id(user.Id)] > 0 {
+		return nil, unwatched
+	}
+	delete(cr.userClients, id.Id(user.Id))
+
+	return &presence{uid: user.Id, cids: cids, online: false}, unwatched
+}
+
+func (cr *ClientRegistry) notifyPresence(p *presence) {
+	if p == nil || (cr.ps != nil && !cr.sharePresence(p)) {
+		return
+	}
+
+	cr.mu.RLock()
+	pw := cr.pw
+	cr.mu.RUnlock()
+
+	if pw != nil {
+		pw.PresenceChanged(p.uid, p.cids, p.online)
+	}
+}
+
+// sharePresence reports the local presence of the user to the store and tells
+// whether it changed globally. Like syncWatch it checks the clients again
+// under presenceMu, so racing calls reach the store in order.
+func (cr *ClientRegistry) sharePresence(p *presence) bool {
+	const op = "registry.sharePresence"
+	log := cr.log.With(slog.String("op", op), slog.String("uid", id.String(p.uid)))
+
+	cr.presenceMu.Lock()
+	defer cr.presenceMu.Unlock()
+
+	cr.mu.RLock()
+	online := cr.userClients[id.Id(p.uid)] != 0
+	cr.mu.RUnlock()
+
+	_, shared := cr.sharedUsers[id.Id(p.uid)]
+	if online != p.online || online == shared {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), presenceTimeout)
+	defer cancel()
+
+	// sharedUsers follows the store only, so a failed call is made again on the
+	// next local presence change of the user
+	var changed bool
+	var err error
+	if online {
+		changed, err = cr.ps.Connect(ctx, p.uid)
+	} else {
+		changed, err = cr.ps.Disconnect(ctx, p.uid)
+	}
+	if err != nil {
+		log.Error("failed to share presence", logger.Err(err))
+		return false
+	}
+
+	if online {
+		cr.sharedUsers[id.Id(p.uid)] = struct{}{}
+	} else {
+		delete(cr.sharedUsers, id.Id(p.uid))
+	}
+
+	return changed
+}
+
+// syncWatch watches the chats having clients and unwatches the others. It runs
+// without mu so broker round trips block no other calls, checking the clients
+// again under watchMu keeps racing calls from leaving a stale subscription.
+func (cr *ClientRegistry) syncWatch(cids [][]byte) error {
+	if cr.cw == nil {
+		return nil
+	}
+
+	cr.watchMu.Lock()
+	defer cr.watchMu.Unlock()
+
+	for _, cid := range cids {
+		cr.mu.RLock()
+		watch := len(cr.chatClients[id.Id(cid)]) != 0
+		cr.mu.RUnlock()
+
+		if !watch {
+			cr.cw.UnwatchChat(cid)
+			continue
+		}
+		if err := cr.cw.WatchChat(cid); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
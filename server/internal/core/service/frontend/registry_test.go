@@ -0,0 +1,187 @@
+package frontend_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/dvid-messanger/internal/adapter/primary"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/service/frontend"
+	"github.com/dvid-messanger/test/mocks/mock_frontend"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+var testLog = slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+func testId() []byte {
+	uid := uuid.New()
+	return uid[:]
+}
+
+func TestSetInfoWatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		uid := testId()
+		chat := model.Chat{Id: testId(), Members: []model.ChatMember{{Uid: uid}}}
+
+		watcher := mock_frontend.NewMockChatWatcher(t)
+		registry := frontend.NewClientRegistry(testLog, watcher, nil)
+
+		client := mock_frontend.NewMockClient(t)
+		client.EXPECT().GetId().Return(testId())
+
+		watcher.EXPECT().WatchChat(chat.Id).
+			Run(func(cid []byte) {
+				// registry lock must not be held while subscribing
+				assert.True(t, registry.InChat(client.GetId(), cid))
+			}).
+			Return(nil).Once()
+
+		require.NoError(t, registry.Register(client))
+		require.NoError(t, registry.SetInfo(client.GetId(), &model.User{Id: uid}, []model.Chat{chat}))
+	})
+
+	t.Run("RollbackOnWatchError", func(t *testing.T) {
+		t.Parallel()
+
+		uid := testId()
+		chat := model.Chat{Id: testId(), Members: []model.ChatMember{{Uid: uid}}}
+		watchErr := errors.New("subscribe failed")
+
+		watcher := mock_frontend.NewMockChatWatcher(t)
+		watcher.EXPECT().WatchChat(mock.Anything).Return(watchErr).Once()
+		watcher.EXPECT().UnwatchChat(chat.Id).Return().Once()
+		registry := frontend.NewClientRegistry(testLog, watcher, nil)
+
+		client := mock_frontend.NewMockClient(t)
+		client.EXPECT().GetId().Return(testId())
+
+		require.NoError(t, registry.Register(client))
+		err := registry.SetInfo(client.GetId(), &model.User{Id: uid}, []model.Chat{chat})
+		assert.ErrorIs(t, err, watchErr)
+		assert.False(t, registry.InChat(client.GetId(), chat.Id))
+
+		clients, err := registry.Clients(chat.Id)
+		require.NoError(t, err)
+		assert.Empty(t, clients)
+	})
+}
+
+func TestHandshakeInfo(t *testing.T) {
+	t.Parallel()
+
+	uid := testId()
+	registry := frontend.NewClientRegistry(testLog, nil, nil)
+
+	client := mock_frontend.NewMockClient(t)
+	client.EXPECT().GetId().Return(testId())
+	require.NoError(t, registry.Register(client))
+	require.NoError(t, registry.SetAuth(client.GetId(), "token"))
+	require.NoError(t, registry.SetInfo(client.GetId(), &model.User{Id: uid}, nil))
+
+	err := registry.SetAuth(client.GetId(), "token")
+	assert.ErrorIs(t, err, primary.ErrFailedPrecondition, "auth token after handshake auth")
+
+	require.NoError(t, registry.SetInfo(client.GetId(), &model.User{Id: uid}, nil), "init info after handshake")
+	err = registry.SetInfo(client.GetId(), &model.User{Id: testId()}, nil)
+	assert.ErrorIs(t, err, frontend.ErrClientAlreadyMappedToUser)
+}
+
+func TestReplaceAuth(t *testing.T) {
+	t.Parallel()
+
+	uid := testId()
+	registry := frontend.NewClientRegistry(testLog, nil, nil)
+
+	client := mock_frontend.NewMockClient(t)
+	client.EXPECT().GetId().Return(testId())
+	require.NoError(t, registry.Register(client))
+
+	err := registry.ReplaceAuth(client.GetId(), uid, "refreshed")
+	assert.ErrorIs(t, err, frontend.ErrClientNotAuthorized)
+
+	require.NoError(t, registry.SetAuth(client.GetId(), "token"))
+	require.NoError(t, registry.SetInfo(client.GetId(), &model.User{Id: uid}, nil))
+
+	err = registry.ReplaceAuth(client.GetId(), testId(), "stolen")
+	assert.ErrorIs(t, err, frontend.ErrClientMappedToOtherUser)
+
+	require.NoError(t, registry.ReplaceAuth(client.GetId(), uid, "refreshed"))
+	auth, err := registry.Auth(client.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", auth)
+}
+
+type presenceChange struct {
+	uid    []byte
+	online bool
+}
+
+type presenceRecorder struct {
+	changes []presenceChange
+	mu      sync.Mutex
+}
+
+func (r *presenceRecorder) PresenceChanged(uid []byte, cids [][]byte, online bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, presenceChange{uid: uid, online: online})
+}
+
+func TestSharedPresence(t *testing.T) {
+	t.Parallel()
+
+	uid := testId()
+	chat := model.Chat{Id: testId(), Members: []model.ChatMember{{Uid: uid}}}
+
+	store := mock_frontend.NewMockPresenceStore(t)
+	registry := frontend.NewClientRegistry(testLog, nil, store)
+	recorder := &presenceRecorder{}
+	registry.WatchPresence(recorder)
+
+	connect := func() []byte {
+		client := mock_frontend.NewMockClient(t)
+		client.EXPECT().GetId().Return(testId())
+		require.NoError(t, registry.Register(client))
+		require.NoError(t, registry.SetInfo(client.GetId(), &model.User{Id: uid}, []model.Chat{chat}))
+
+		return client.GetId()
+	}
+
+	// user is already connected to another instance
+	store.EXPECT().Connect(mock.Anything, uid).Return(false, nil).Once()
+	first := connect()
+	second := connect()
+	assert.Empty(t, recorder.changes, "no online event while user is online elsewhere")
+
+	require.NoError(t, registry.Unregister(first))
+	assert.Empty(t, recorder.changes, "store is not told about remaining local connections")
+
+	store.EXPECT().Disconnect(mock.Anything, uid).Return(true, nil).Once()
+	require.NoError(t, registry.Unregister(second))
+	assert.Equal(t, []presenceChange{{uid: uid, online: false}}, recorder.changes)
+
+	store.EXPECT().Connect(mock.Anything, uid).Return(false, errors.New("store unavailable")).Once()
+	third := connect()
+	assert.Len(t, recorder.changes, 1, "store errors do not produce events")
+
+	require.NoError(t, registry.Unregister(third))
+	store.EXPECT().Connect(mock.Anything, uid).Return(true, nil).Once()
+	fourth := connect()
+	assert.Len(t, recorder.changes, 2, "failed connect is retried on the next local presence change")
+
+	store.EXPECT().Disconnect(mock.Anything, uid).Return(false, errors.New("store unavailable")).Once()
+	require.NoError(t, registry.Unregister(fourth))
+
+	// the store still counts the user, so reconnecting must not connect twice
+	connect()
+	assert.Len(t, recorder.changes, 2)
+}
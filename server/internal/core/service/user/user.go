@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/user"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/internal/pkg/logger"
 	"log/slog"
+	"strings"
 )
 
 type Service struct {
@@ -18,13 +20,22 @@ type Service struct {
 
 type UserSaver interface {
 	Save(ctx context.Context, email string, bio string) (model.User, error)
+	Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (model.User, error)
+	Delete(ctx context.Context, uid []byte) error
 }
 
 type UserProvider interface {
 	User(ctx context.Context, uid []byte) (model.User, error)
 	Users(ctx context.Context) ([]model.User, error)
+	UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error)
+	Search(ctx context.Context, query string, prefix bool, after []byte, limit int) (model.UsersPage, error)
 }
 
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
 var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
@@ -44,6 +55,11 @@ func (u *Service) Create(ctx context.Context, email string, bio string) (*model.
 
 	log.Debug("registering user")
 
+	if err := validate.Bio(bio); err != nil {
+		log.Debug("invalid bio", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	usr, err := u.us.Save(ctx, email, bio)
 	if err != nil {
 		if errors.Is(err, user.ErrUserExists) {
@@ -100,3 +116,115 @@ func (u *Service) Users(ctx context.Context) ([]model.User, error) {
 
 	return users, nil
 }
+
+func (u *Service) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error) {
+	const op = "user.Update"
+	log := u.log.With(slog.String("op", op))
+
+	log.Debug("updating user")
+
+	if err := validate.Profile(upd); err != nil {
+		log.Debug("invalid profile", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	usr, err := u.us.Update(ctx, uid, upd)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			log.Debug("user not found", logger.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+
+		log.Error("failed to update user", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("user updated")
+
+	return &usr, nil
+}
+
+// UsersByIds returns the known users among uids, each at most once.
+func (u *Service) UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error) {
+	const op = "user.UsersByIds"
+	log := u.log.With(slog.String("op", op))
+
+	log.Debug("getting users by ids")
+
+	seen := make(map[string]struct{}, len(uids))
+	unique := make([][]byte, 0, len(uids))
+	for _, uid := range uids {
+		if _, ok := seen[string(uid)]; ok {
+			continue
+		}
+		seen[string(uid)] = struct{}{}
+		unique = append(unique, uid)
+	}
+	if len(unique) == 0 {
+		return []model.User{}, nil
+	}
+
+	users, err := u.up.UsersByIds(ctx, unique)
+	if err != nil {
+		log.Error("failed to get users by ids", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("users by ids got")
+
+	return users, nil
+}
+
+// Search pages through users whose email or display name contains query, or
+// starts with it when prefix is set. An empty query lists all users.
+func (u *Service) Search(
+	ctx context.Context,
+	query string,
+	prefix bool,
+	after []byte,
+	limit int,
+) (*model.UsersPage, error) {
+	const op = "user.Search"
+	log := u.log.With(slog.String("op", op))
+
+	log.Debug("searching users")
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	page, err := u.up.Search(ctx, strings.TrimSpace(query), prefix, after, limit)
+	if err != nil {
+		log.Error("failed to search users", logger.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("users searched")
+
+	return &page, nil
+}
+
+// Delete removes the user, a missing user is not an error so the call can be
+// repeated when compensating a failed registration.
+func (u *Service) Delete(ctx context.Context, uid []byte) error {
+	const op = "user.Delete"
+	log := u.log.With(slog.String("op", op))
+
+	log.Debug("deleting user")
+
+	if err := u.us.Delete(ctx, uid); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			log.Debug("user already deleted")
+			return nil
+		}
+
+		log.Error("failed to delete user", logger.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("user deleted")
+
+	return nil
+}
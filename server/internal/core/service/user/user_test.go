@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/dvid-messanger/internal/adapter/secondary/storage/user"
 	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/core/domain/validate"
 	"github.com/dvid-messanger/test/mocks/mock_user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -69,6 +70,77 @@ func TestCreateUser(t *testing.T) {
 	})
 }
 
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserSaver := &mock_user.MockUserSaver{}
+		mockUserSaver.On("Delete", mock.Anything, []byte("uid")).Return(nil)
+
+		service := NewUser(log, mockUserSaver, &mock_user.MockUserProvider{})
+
+		assert.NoError(t, service.Delete(context.Background(), []byte("uid")))
+		mockUserSaver.AssertExpectations(t)
+	})
+	t.Run("MissingUserNoError", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserSaver := &mock_user.MockUserSaver{}
+		mockUserSaver.On("Delete", mock.Anything, []byte("uid")).Return(user.ErrUserNotFound)
+
+		service := NewUser(log, mockUserSaver, &mock_user.MockUserProvider{})
+
+		assert.NoError(t, service.Delete(context.Background(), []byte("uid")))
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	name := "Alice"
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		upd := model.ProfileUpdate{DisplayName: &name}
+		expectedUser := model.User{Id: []byte("uid"), Email: "test@example.com", DisplayName: name}
+
+		mockUserSaver := &mock_user.MockUserSaver{}
+		mockUserSaver.On("Update", mock.Anything, []byte("uid"), upd).Return(expectedUser, nil)
+
+		service := NewUser(log, mockUserSaver, &mock_user.MockUserProvider{})
+
+		usr, err := service.Update(context.Background(), []byte("uid"), upd)
+		require.NoError(t, err)
+		assert.Equal(t, expectedUser, *usr)
+	})
+	t.Run("UserNotFoundError", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserSaver := &mock_user.MockUserSaver{}
+		mockUserSaver.On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(model.User{}, user.ErrUserNotFound)
+
+		service := NewUser(log, mockUserSaver, &mock_user.MockUserProvider{})
+
+		_, err := service.Update(context.Background(), []byte("uid"), model.ProfileUpdate{DisplayName: &name})
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+	t.Run("InvalidProfileError", func(t *testing.T) {
+		t.Parallel()
+
+		avatar := "not a url"
+		mockUserSaver := &mock_user.MockUserSaver{}
+		service := NewUser(log, mockUserSaver, &mock_user.MockUserProvider{})
+
+		_, err := service.Update(context.Background(), []byte("uid"), model.ProfileUpdate{Avatar: &avatar})
+		assert.ErrorIs(t, err, validate.ErrAvatar)
+		mockUserSaver.AssertNotCalled(t, "Update")
+	})
+}
+
 func TestUser(t *testing.T) {
 	t.Parallel()
 
@@ -147,3 +219,70 @@ func TestUsers(t *testing.T) {
 		assert.Equal(t, expectedUsers, users)
 	})
 }
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"DefaultLimit", 0, defaultSearchLimit},
+		{"KeptLimit", 5, 5},
+		{"CappedLimit", 1000, maxSearchLimit},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUserSaver := &mock_user.MockUserSaver{}
+			mockUserProvider := &mock_user.MockUserProvider{}
+
+			expected := model.UsersPage{Users: []model.User{{Id: []byte("user1"), Email: "user1@example.com"}}}
+			mockUserProvider.On("Search", mock.Anything, "user", true, []byte(nil), tc.want).Return(expected, nil)
+
+			service := NewUser(log, mockUserSaver, mockUserProvider)
+
+			page, err := service.Search(context.Background(), " user ", true, nil, tc.limit)
+			require.NoError(t, err)
+			assert.Equal(t, expected, *page)
+			mockUserProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsersByIds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DuplicatesDropped", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserSaver := &mock_user.MockUserSaver{}
+		mockUserProvider := &mock_user.MockUserProvider{}
+
+		expected := []model.User{{Id: []byte("user1")}, {Id: []byte("user2")}}
+		mockUserProvider.On("UsersByIds", mock.Anything, [][]byte{[]byte("user1"), []byte("user2")}).
+			Return(expected, nil)
+
+		service := NewUser(log, mockUserSaver, mockUserProvider)
+
+		users, err := service.UsersByIds(context.Background(), [][]byte{[]byte("user1"), []byte("user2"), []byte("user1")})
+		require.NoError(t, err)
+		assert.Equal(t, expected, users)
+		mockUserProvider.AssertExpectations(t)
+	})
+	t.Run("EmptyNotFetched", func(t *testing.T) {
+		t.Parallel()
+
+		mockUserSaver := &mock_user.MockUserSaver{}
+		mockUserProvider := &mock_user.MockUserProvider{}
+
+		service := NewUser(log, mockUserSaver, mockUserProvider)
+
+		users, err := service.UsersByIds(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+		mockUserProvider.AssertNotCalled(t, "UsersByIds")
+	})
+}
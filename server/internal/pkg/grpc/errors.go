@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"time"
+)
+
+const errorDomain = "messenger"
+
+// Reasons detailing failed calls, they tell apart errors sharing a code.
+const (
+	ReasonInvalidArgument    = "INVALID_ARGUMENT"
+	ReasonInvalidDisplayName = "INVALID_DISPLAY_NAME"
+	ReasonInvalidAvatar      = "INVALID_AVATAR"
+	ReasonInvalidBio         = "INVALID_BIO"
+	ReasonInvalidEmoji       = "INVALID_EMOJI"
+	ReasonInvalidCredentials = "INVALID_CREDENTIALS"
+	ReasonInvalidToken       = "INVALID_TOKEN"
+	ReasonUserNotFound       = "USER_NOT_FOUND"
+	ReasonUserExists         = "USER_EXISTS"
+	ReasonChatNotFound       = "CHAT_NOT_FOUND"
+	ReasonChatExists         = "CHAT_EXISTS"
+	ReasonNotGroupChat       = "NOT_GROUP_CHAT"
+	ReasonNotChatOwner       = "NOT_CHAT_OWNER"
+	ReasonNotChatMember      = "NOT_CHAT_MEMBER"
+	ReasonMemberNotFound     = "MEMBER_NOT_FOUND"
+	ReasonMemberExists       = "MEMBER_EXISTS"
+	ReasonOwnerCannotLeave   = "OWNER_CANNOT_LEAVE"
+	ReasonMessageNotFound    = "MESSAGE_NOT_FOUND"
+	ReasonMessageDeleted     = "MESSAGE_DELETED"
+	ReasonNotMessageAuthor   = "NOT_MESSAGE_AUTHOR"
+	ReasonReactionExists     = "REACTION_EXISTS"
+	ReasonReactionNotFound   = "REACTION_NOT_FOUND"
+	ReasonRateLimited        = "RATE_LIMITED"
+)
+
+var ErrInternal = status.Error(codes.Internal, "internal error")
+
+// Error returns a status error detailed with reason.
+func Error(code codes.Code, reason string, msg string) error {
+	return withDetails(status.New(code, msg), &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+}
+
+// FieldError returns an invalid argument error for a bad request field.
+func FieldError(field string, desc string) error {
+	return withDetails(
+		status.New(codes.InvalidArgument, field+" "+desc),
+		&errdetails.ErrorInfo{Reason: ReasonInvalidArgument, Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: desc},
+		}},
+	)
+}
+
+// ThrottledError returns a resource exhausted error telling to retry after
+// retryAfter.
+func ThrottledError(retryAfter time.Duration, msg string) error {
+	return withDetails(
+		status.New(codes.ResourceExhausted, msg),
+		&errdetails.ErrorInfo{Reason: ReasonRateLimited, Domain: errorDomain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+}
+
+// Reason returns the reason detailing st, empty when there is none.
+func Reason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+			return info.GetReason()
+		}
+	}
+
+	return ""
+}
+
+// RetryAfter returns the delay st asks to wait before retrying, zero when
+// there is none.
+func RetryAfter(st *status.Status) time.Duration {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+
+	return 0
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if detailed, err := st.WithDetails(details...); err == nil {
+		return detailed.Err()
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	st := status.Convert(Error(codes.NotFound, ReasonChatNotFound, "chat not found"))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "chat not found", st.Message())
+	assert.Equal(t, ReasonChatNotFound, Reason(st))
+
+	assert.Empty(t, Reason(status.New(codes.NotFound, "not found")))
+	assert.Empty(t, Reason(status.Convert(ErrInternal)))
+}
+
+func TestThrottledError(t *testing.T) {
+	t.Parallel()
+
+	st := status.Convert(ThrottledError(1500*time.Millisecond, "rate limited"))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, ReasonRateLimited, Reason(st))
+	assert.Equal(t, 1500*time.Millisecond, RetryAfter(st))
+
+	assert.Zero(t, RetryAfter(status.New(codes.ResourceExhausted, "rate limited")))
+}
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	st := status.Convert(FieldError("cid", "is required"))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "cid is required", st.Message())
+	assert.Equal(t, ReasonInvalidArgument, Reason(st))
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if req, ok := detail.(*errdetails.BadRequest); ok {
+			violations = req.GetFieldViolations()
+		}
+	}
+	require.Len(t, violations, 1)
+	assert.Equal(t, "cid", violations[0].GetField())
+	assert.Equal(t, "is required", violations[0].GetDescription())
+}
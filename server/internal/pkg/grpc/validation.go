@@ -1,16 +1,11 @@
 package grpc
 
-import (
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-)
-
 func ValidateId(id []byte, name string) error {
 	if len(id) == 0 {
-		return status.Error(codes.InvalidArgument, name+" is required")
+		return FieldError(name, "is required")
 	}
 	if len(id) != 16 {
-		return status.Error(codes.InvalidArgument, name+" is bad")
+		return FieldError(name, "is bad")
 	}
 
 	return nil
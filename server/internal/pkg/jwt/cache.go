@@ -0,0 +1,64 @@
+package jwt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const cacheSweepSize = 4096
+
+type cacheEntry struct {
+	revoked bool
+	until   time.Time
+}
+
+// RevocationCache remembers revocation lookups of an underlying list.
+// Revoked tokens never become valid again, so only negative answers expire.
+type RevocationCache struct {
+	rl  RevocationList
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+func NewRevocationCache(rl RevocationList, ttl time.Duration) *RevocationCache {
+	return &RevocationCache{
+		rl:      rl,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *RevocationCache) Revoked(ctx context.Context, jti string) (bool, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[jti]
+	if ok && (entry.revoked || c.now().Before(entry.until)) {
+		c.mu.Unlock()
+		return entry.revoked, nil
+	}
+	c.mu.Unlock()
+
+	revoked, err := c.rl.Revoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= cacheSweepSize {
+		for k, e := range c.entries {
+			if !now.Before(e.until) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[jti] = cacheEntry{revoked: revoked, until: now.Add(c.ttl)}
+
+	return revoked, nil
+}
@@ -0,0 +1,277 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound       = errors.New("key not found")
+	ErrNoSigningKey      = errors.New("no signing key")
+	ErrUnsupportedKey    = errors.New("unsupported key type")
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+)
+
+const keyRefetchInterval = 5 * time.Second
+
+type KeyResolver interface {
+	PublicKey(ctx context.Context, kid string) (model.PublicKey, error)
+}
+
+// KeyRefresher is implemented by resolvers caching keys, Refresh is called
+// when a cached key failed to verify a signature and tells whether the keys
+// were refetched.
+type KeyRefresher interface {
+	Refresh(ctx context.Context) bool
+}
+
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+}
+
+// KeySet holds the private keys of the issuer. Every key is published for
+// verification, only the signing one is used for new tokens.
+type KeySet struct {
+	mu      sync.RWMutex
+	keys    map[string]signingKey
+	signing string
+}
+
+func NewKeySet() *KeySet {
+	return &KeySet{keys: make(map[string]signingKey)}
+}
+
+func (ks *KeySet) Add(kid string, private crypto.Signer) error {
+	method, err := methodFor(private.Public())
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.keys[kid] = signingKey{id: kid, method: method, private: private}
+	if ks.signing == "" {
+		ks.signing = kid
+	}
+
+	return nil
+}
+
+func (ks *KeySet) AddFile(kid string, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	private, err := ParsePrivateKey(data)
+	if err != nil {
+		return err
+	}
+
+	return ks.Add(kid, private)
+}
+
+func (ks *KeySet) Generate(kid string) error {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	return ks.Add(kid, private)
+}
+
+// Rotate makes kid the signing key, previous keys stay valid for verification.
+func (ks *KeySet) Rotate(kid string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if _, ok := ks.keys[kid]; !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+	ks.signing = kid
+
+	return nil
+}
+
+func (ks *KeySet) PublicKey(_ context.Context, kid string) (model.PublicKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return model.PublicKey{}, fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+
+	return model.PublicKey{Id: key.id, Alg: key.method.Alg(), Key: key.private.Public()}, nil
+}
+
+func (ks *KeySet) PublicKeys() []model.PublicKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	keys := make([]model.PublicKey, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		keys = append(keys, model.PublicKey{Id: key.id, Alg: key.method.Alg(), Key: key.private.Public()})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Id < keys[j].Id })
+
+	return keys
+}
+
+func (ks *KeySet) signingKey() (signingKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[ks.signing]
+	if !ok {
+		return signingKey{}, ErrNoSigningKey
+	}
+
+	return key, nil
+}
+
+type KeyFetcher interface {
+	PublicKeys(ctx context.Context) ([]model.PublicKey, error)
+}
+
+// RemoteKeySet caches public keys of a remote issuer. Keys are refetched
+// once ttl passes, an unknown kid shows up or a signature fails to verify.
+type RemoteKeySet struct {
+	fetcher KeyFetcher
+	ttl     time.Duration
+
+	// fetchMu serializes fetches, they are made outside of mu
+	fetchMu   sync.Mutex
+	mu        sync.Mutex
+	keys      map[string]model.PublicKey
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+func NewRemoteKeySet(fetcher KeyFetcher, ttl time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{
+		fetcher: fetcher,
+		ttl:     ttl,
+		keys:    make(map[string]model.PublicKey),
+		now:     time.Now,
+	}
+}
+
+func (r *RemoteKeySet) PublicKey(ctx context.Context, kid string) (model.PublicKey, error) {
+	key, ok, fetchedAt := r.lookup(kid)
+	age := r.now().Sub(fetchedAt)
+	if ok && age < r.ttl {
+		return key, nil
+	}
+	if !ok && age < keyRefetchInterval {
+		return model.PublicKey{}, fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+
+	if err := r.fetch(ctx, fetchedAt); err != nil {
+		if ok {
+			return key, nil
+		}
+		return model.PublicKey{}, err
+	}
+
+	key, ok, _ = r.lookup(kid)
+	if !ok {
+		return model.PublicKey{}, fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+
+	return key, nil
+}
+
+// Refresh refetches the keys unless that was done recently, so a forged
+// signature can not make every request hit the issuer.
+func (r *RemoteKeySet) Refresh(ctx context.Context) bool {
+	_, _, fetchedAt := r.lookup("")
+	if r.now().Sub(fetchedAt) < keyRefetchInterval {
+		return false
+	}
+
+	return r.fetch(ctx, fetchedAt) == nil
+}
+
+func (r *RemoteKeySet) lookup(kid string) (model.PublicKey, bool, time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[kid]
+	return key, ok, r.fetchedAt
+}
+
+// fetch replaces the keys fetched at seen. When another call has fetched them
+// in the meantime its result is used instead.
+func (r *RemoteKeySet) fetch(ctx context.Context, seen time.Time) error {
+	r.fetchMu.Lock()
+	defer r.fetchMu.Unlock()
+
+	if _, _, fetchedAt := r.lookup(""); fetchedAt.After(seen) {
+		return nil
+	}
+
+	keys, err := r.fetcher.PublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	fetched := make(map[string]model.PublicKey, len(keys))
+	for _, k := range keys {
+		fetched[k.Id] = k
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = fetched
+	r.fetchedAt = r.now()
+
+	return nil
+}
+
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, ErrUnsupportedKey
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, ErrInvalidPrivateKey
+}
+
+func methodFor(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch public.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
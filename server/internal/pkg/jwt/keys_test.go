@@ -0,0 +1,89 @@
+package jwt
+
+import (
+	"context"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var testUser = model.UserCredentials{Id: []byte("0123456789abcdef"), Email: "test@example.com"}
+
+type blockingFetcher struct {
+	keys    *KeySet
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *blockingFetcher) PublicKeys(_ context.Context) ([]model.PublicKey, error) {
+	if f.started != nil {
+		f.started <- struct{}{}
+		<-f.release
+	}
+	return f.keys.PublicKeys(), nil
+}
+
+func TestRemoteKeySet_ReplacedKey(t *testing.T) {
+	t.Parallel()
+
+	issuer := NewKeySet()
+	require.NoError(t, issuer.Generate("k1"))
+
+	fetcher := &blockingFetcher{keys: issuer}
+	now := time.Now()
+	remote := NewRemoteKeySet(fetcher, time.Hour)
+	remote.now = func() time.Time { return now }
+	verifier := NewVerifier(remote, nil)
+
+	token, err := NewTokenizer(issuer, nil).MakeToken(testUser, model.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+	_, err = verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+
+	// issuer restarted with a new key under the same kid
+	restarted := NewKeySet()
+	require.NoError(t, restarted.Generate("k1"))
+	fetcher.keys = restarted
+	token, err = NewTokenizer(restarted, nil).MakeToken(testUser, model.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), token)
+	assert.ErrorIs(t, err, ErrTokenInvalid, "refetch on bad signature is rate limited")
+
+	now = now.Add(keyRefetchInterval)
+	_, err = verifier.Verify(context.Background(), token)
+	assert.NoError(t, err, "keys refetched after signature failure")
+}
+
+func TestRemoteKeySet_FetchUnlocked(t *testing.T) {
+	t.Parallel()
+
+	issuer := NewKeySet()
+	require.NoError(t, issuer.Generate("k1"))
+
+	fetcher := &blockingFetcher{keys: issuer}
+	remote := NewRemoteKeySet(fetcher, time.Hour)
+	now := time.Now()
+	remote.now = func() time.Time { return now }
+	_, err := remote.PublicKey(context.Background(), "k1")
+	require.NoError(t, err)
+
+	fetcher.started = make(chan struct{})
+	fetcher.release = make(chan struct{})
+	now = now.Add(keyRefetchInterval)
+
+	done := make(chan error)
+	go func() {
+		_, err := remote.PublicKey(context.Background(), "unknown")
+		done <- err
+	}()
+	<-fetcher.started
+
+	_, err = remote.PublicKey(context.Background(), "k1")
+	assert.NoError(t, err, "cached key is served while fetching")
+
+	close(fetcher.release)
+	assert.ErrorIs(t, <-done, ErrKeyNotFound)
+}
@@ -1,33 +1,64 @@
 package jwt
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"github.com/dvid-messanger/internal/core/domain/model"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"time"
 )
 
-var ErrTokenInvalid = errors.New("token invalid")
+var (
+	ErrTokenInvalid = errors.New("token invalid")
+	ErrTokenRevoked = errors.New("token revoked")
+)
+
+type RevocationList interface {
+	Revoked(ctx context.Context, jti string) (bool, error)
+}
+
+var validMethods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
 
 type Tokenizer struct {
-	method jwt.SigningMethod
-	secret []byte
+	keys   KeyResolver
+	signer *KeySet
+	rl     RevocationList
 }
 
-func NewTokenizer(secret []byte) *Tokenizer {
-	return &Tokenizer{method: jwt.SigningMethodHS256, secret: secret}
+// NewTokenizer creates a tokenizer signing with the keys of the set; rl may be
+// nil to skip revocation checks.
+func NewTokenizer(keys *KeySet, rl RevocationList) *Tokenizer {
+	return &Tokenizer{keys: keys, signer: keys, rl: rl}
 }
 
-func (t *Tokenizer) MakeToken(user model.UserCredentials, duration time.Duration) (string, error) {
-	token := jwt.New(t.method)
+// NewVerifier creates a tokenizer which can only verify tokens.
+func NewVerifier(keys KeyResolver, rl RevocationList) *Tokenizer {
+	return &Tokenizer{keys: keys, rl: rl}
+}
+
+func (t *Tokenizer) MakeToken(user model.UserCredentials, typ string, duration time.Duration) (string, error) {
+	if t.signer == nil {
+		return "", ErrNoSigningKey
+	}
+	key, err := t.signer.signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.New(key.method)
+	token.Header["kid"] = key.id
 
 	claims := token.Claims.(jwt.MapClaims)
+	claims["jti"] = uuid.NewString()
+	claims["typ"] = typ
 	claims["uid"] = base64.StdEncoding.EncodeToString(user.Id)
 	claims["email"] = user.Email
 	claims["exp"] = time.Now().Add(duration).Unix()
 
-	tokenStr, err := token.SignedString(t.secret)
+	tokenStr, err := token.SignedString(key.private)
 	if err != nil {
 		return "", err
 	}
@@ -35,17 +66,83 @@ func (t *Tokenizer) MakeToken(user model.UserCredentials, duration time.Duration
 	return tokenStr, nil
 }
 
-func (t *Tokenizer) Verify(token string) (jwt.MapClaims, error) {
-	parsed, err := jwt.Parse(token, func(token *jwt.Token) (any, error) {
-		return t.secret, nil
-	})
+func (t *Tokenizer) Verify(ctx context.Context, token string) (*model.TokenClaims, error) {
+	parsed, err := t.parse(ctx, token)
+	if errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		if refresher, ok := t.keys.(KeyRefresher); ok && refresher.Refresh(ctx) {
+			parsed, err = t.parse(ctx, token)
+		}
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
 	}
 
-	if claims, ok := parsed.Claims.(jwt.MapClaims); ok && parsed.Valid {
-		return claims, nil
-	} else {
+	mapClaims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
 		return nil, ErrTokenInvalid
 	}
+
+	claims, err := parseClaims(mapClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.rl != nil {
+		revoked, err := t.rl.Revoked(ctx, claims.Id)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+func (t *Tokenizer) parse(ctx context.Context, token string) (*jwt.Token, error) {
+	return jwt.Parse(token, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("kid header missing")
+		}
+
+		key, err := t.keys.PublicKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if key.Alg != token.Method.Alg() {
+			return nil, fmt.Errorf("unexpected alg %s for key %s", token.Method.Alg(), kid)
+		}
+
+		return key.Key, nil
+	}, jwt.WithValidMethods(validMethods), jwt.WithExpirationRequired())
+}
+
+func parseClaims(mapClaims jwt.MapClaims) (*model.TokenClaims, error) {
+	jti, _ := mapClaims["jti"].(string)
+	typ, _ := mapClaims["typ"].(string)
+	uidStr, _ := mapClaims["uid"].(string)
+	email, _ := mapClaims["email"].(string)
+	if jti == "" || typ == "" || uidStr == "" {
+		return nil, fmt.Errorf("%w: required claim missing", ErrTokenInvalid)
+	}
+
+	uid, err := base64.StdEncoding.DecodeString(uidStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
+	}
+
+	exp, err := mapClaims.GetExpirationTime()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
+	}
+
+	return &model.TokenClaims{
+		Id:        jti,
+		Uid:       uid,
+		Email:     email,
+		Type:      typ,
+		ExpiresAt: exp.Time,
+	}, nil
 }
@@ -0,0 +1,130 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/dvid-messanger/internal/core/domain/model"
+	"github.com/dvid-messanger/internal/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var user = model.UserCredentials{Id: []byte("0123456789abcdef"), Email: "test@example.com"}
+
+type staticRevocations map[string]bool
+
+func (s staticRevocations) Revoked(_ context.Context, jti string) (bool, error) {
+	return s[jti], nil
+}
+
+type countingFetcher struct {
+	keys  *jwt.KeySet
+	calls int
+}
+
+func (f *countingFetcher) PublicKeys(_ context.Context) ([]model.PublicKey, error) {
+	f.calls++
+	return f.keys.PublicKeys(), nil
+}
+
+func TestTokenizer(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys := jwt.NewKeySet()
+	require.NoError(t, keys.Generate("ed"))
+	require.NoError(t, keys.Add("rsa", rsaKey))
+
+	tokenizer := jwt.NewTokenizer(keys, nil)
+
+	edToken, err := tokenizer.MakeToken(user, model.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, keys.Rotate("rsa"))
+	rsaToken, err := tokenizer.MakeToken(user, model.TokenTypeRefresh, time.Hour)
+	require.NoError(t, err)
+
+	claims, err := tokenizer.Verify(context.Background(), edToken)
+	require.NoError(t, err, "token of previous key still valid after rotation")
+	assert.Equal(t, user.Id, claims.Uid)
+	assert.Equal(t, model.TokenTypeAccess, claims.Type)
+
+	claims, err = tokenizer.Verify(context.Background(), rsaToken)
+	require.NoError(t, err)
+	assert.Equal(t, model.TokenTypeRefresh, claims.Type)
+}
+
+func TestTokenizer_Revoked(t *testing.T) {
+	t.Parallel()
+
+	keys := jwt.NewKeySet()
+	require.NoError(t, keys.Generate("ed"))
+
+	token, err := jwt.NewTokenizer(keys, nil).MakeToken(user, model.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+
+	claims, err := jwt.NewTokenizer(keys, nil).Verify(context.Background(), token)
+	require.NoError(t, err)
+
+	verifier := jwt.NewVerifier(keys, staticRevocations{claims.Id: true})
+	_, err = verifier.Verify(context.Background(), token)
+	assert.ErrorIs(t, err, jwt.ErrTokenRevoked)
+
+	_, err = verifier.MakeToken(user, model.TokenTypeAccess, time.Hour)
+	assert.ErrorIs(t, err, jwt.ErrNoSigningKey)
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	t.Parallel()
+
+	keys := jwt.NewKeySet()
+	require.NoError(t, keys.Generate("k1"))
+
+	fetcher := &countingFetcher{keys: keys}
+	remote := jwt.NewRemoteKeySet(fetcher, time.Hour)
+	verifier := jwt.NewVerifier(remote, nil)
+	tokenizer := jwt.NewTokenizer(keys, nil)
+
+	token, err := tokenizer.MakeToken(user, model.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = verifier.Verify(context.Background(), token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, fetcher.calls, "keys are cached")
+
+	_, err = remote.PublicKey(context.Background(), "unknown")
+	assert.ErrorIs(t, err, jwt.ErrKeyNotFound)
+	assert.Equal(t, 1, fetcher.calls, "unknown kid refetch is rate limited")
+}
+
+func TestNewJWKSet(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys := jwt.NewKeySet()
+	require.NoError(t, keys.Generate("ed"))
+	require.NoError(t, keys.Add("rsa", rsaKey))
+
+	set, err := jwt.NewJWKSet(keys.PublicKeys())
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 2)
+
+	assert.Equal(t, "ed", set.Keys[0].Kid)
+	assert.Equal(t, "OKP", set.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", set.Keys[0].Alg)
+	assert.NotEmpty(t, set.Keys[0].X)
+
+	assert.Equal(t, "rsa", set.Keys[1].Kid)
+	assert.Equal(t, "RSA", set.Keys[1].Kty)
+	assert.Equal(t, "RS256", set.Keys[1].Alg)
+	assert.Equal(t, "AQAB", set.Keys[1].E)
+}
@@ -16,10 +16,11 @@ func Unmarshal[T proto.Message](msg []byte, base T) error {
 	return nil
 }
 
+// MarshalDownstream marshals a server push, it is not correlated to any upstream request.
 func MarshalDownstream[T proto.Message](msg T, msgType frontendv1.DownstreamType, dErr *frontendv1.DownstreamError) ([]byte, error) {
 	const op = "proto.MarshalDownstream"
 
-	downstream := &frontendv1.Downstream{Type: msgType, Error: dErr}
+	downstream := &frontendv1.Downstream{Type: msgType, Error: dErr, Push: true}
 
 	if !reflect.ValueOf(msg).IsZero() {
 		marshalled, err := proto.Marshal(msg)
@@ -36,3 +37,19 @@ func MarshalDownstream[T proto.Message](msg T, msgType frontendv1.DownstreamType
 
 	return res, nil
 }
+
+func MarshalChatEvent[T proto.Message](msg T, eventType frontendv1.ChatEventType) ([]byte, error) {
+	const op = "proto.MarshalChatEvent"
+
+	marshalled, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := proto.Marshal(&frontendv1.ChatEvent{Type: eventType, Payload: marshalled})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
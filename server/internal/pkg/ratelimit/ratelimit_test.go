@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newTestLimiter(limit Limit) (*Limiter, *clock) {
+	c := &clock{now: time.Unix(0, 0)}
+	l := NewLimiter(limit)
+	l.now = c.Now
+	return l, c
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	l, c := newTestLimiter(Limit{Rate: 2, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a")
+		assert.True(t, ok, "burst allowed")
+	}
+
+	ok, retry := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retry)
+
+	ok, _ = l.Allow("b")
+	assert.True(t, ok, "keys have own buckets")
+
+	c.now = c.now.Add(250 * time.Millisecond)
+	ok, retry = l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retry)
+
+	c.now = c.now.Add(250 * time.Millisecond)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok, "token refilled")
+
+	c.now = c.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _ = l.Allow("a")
+		assert.True(t, ok, "refill capped at burst")
+	}
+	ok, _ = l.Allow("a")
+	assert.False(t, ok, "refill capped at burst")
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	t.Parallel()
+
+	l, c := newTestLimiter(Limit{Rate: 1, Burst: 1})
+
+	for i := 0; i < sweepSize; i++ {
+		l.Allow(strconv.Itoa(i))
+	}
+	assert.Len(t, l.buckets, sweepSize)
+
+	c.now = c.now.Add(time.Second)
+	l.Allow("new")
+	assert.Len(t, l.buckets, 1, "refilled buckets forgotten")
+}
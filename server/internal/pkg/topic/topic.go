@@ -0,0 +1,9 @@
+package topic
+
+import "github.com/dvid-messanger/pkg/id"
+
+const Chats = "chats"
+
+func Chat(cid []byte) string {
+	return "chat." + id.String(cid)
+}
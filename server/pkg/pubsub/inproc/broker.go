@@ -0,0 +1,75 @@
+package inproc
+
+import (
+	"context"
+	"github.com/dvid-messanger/pkg/pubsub"
+	"sync"
+)
+
+type Broker struct {
+	topics map[string]map[*subscription]struct{}
+
+	mu *sync.RWMutex
+}
+
+type subscription struct {
+	broker  *Broker
+	topic   string
+	handler pubsub.Handler
+}
+
+func New() *Broker {
+	return &Broker{
+		topics: make(map[string]map[*subscription]struct{}),
+		mu:     &sync.RWMutex{},
+	}
+}
+
+func (b *Broker) Publish(_ context.Context, topic string, payload []byte) error {
+	b.mu.RLock()
+	subs := make([]*subscription, 0, len(b.topics[topic]))
+	for sub := range b.topics[topic] {
+		subs = append(subs, sub)
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		sub.handler(payload)
+	}
+
+	return nil
+}
+
+func (b *Broker) Subscribe(topic string, handler pubsub.Handler) (pubsub.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscription{broker: b, topic: topic, handler: handler}
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[*subscription]struct{})
+	}
+	b.topics[topic][sub] = struct{}{}
+
+	return sub, nil
+}
+
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	clear(b.topics)
+
+	return nil
+}
+
+func (s *subscription) Unsubscribe() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	delete(s.broker.topics[s.topic], s)
+	if len(s.broker.topics[s.topic]) == 0 {
+		delete(s.broker.topics, s.topic)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package inproc
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBroker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PublishToSubscribers", func(t *testing.T) {
+		t.Parallel()
+
+		broker := New()
+
+		var first, second, other [][]byte
+		_, err := broker.Subscribe("topic", func(payload []byte) { first = append(first, payload) })
+		require.NoError(t, err)
+		_, err = broker.Subscribe("topic", func(payload []byte) { second = append(second, payload) })
+		require.NoError(t, err)
+		_, err = broker.Subscribe("other", func(payload []byte) { other = append(other, payload) })
+		require.NoError(t, err)
+
+		require.NoError(t, broker.Publish(context.Background(), "topic", []byte("payload")))
+
+		assert.Equal(t, [][]byte{[]byte("payload")}, first)
+		assert.Equal(t, [][]byte{[]byte("payload")}, second)
+		assert.Empty(t, other)
+	})
+	t.Run("Unsubscribe", func(t *testing.T) {
+		t.Parallel()
+
+		broker := New()
+
+		received := 0
+		sub, err := broker.Subscribe("topic", func([]byte) { received++ })
+		require.NoError(t, err)
+
+		require.NoError(t, broker.Publish(context.Background(), "topic", []byte("payload")))
+		require.NoError(t, sub.Unsubscribe())
+		require.NoError(t, broker.Publish(context.Background(), "topic", []byte("payload")))
+
+		assert.Equal(t, 1, received)
+		assert.Empty(t, broker.topics)
+	})
+}
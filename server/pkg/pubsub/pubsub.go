@@ -0,0 +1,15 @@
+package pubsub
+
+import "context"
+
+type Handler func(payload []byte)
+
+type Subscription interface {
+	Unsubscribe() error
+}
+
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(topic string, handler Handler) (Subscription, error)
+	Close() error
+}
@@ -0,0 +1,118 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/dvid-messanger/pkg/pubsub"
+	"github.com/redis/go-redis/v9"
+	"sync"
+)
+
+type Broker struct {
+	client *redis.Client
+	ps     *redis.PubSub
+	topics map[string]map[*subscription]struct{}
+
+	mu *sync.RWMutex
+}
+
+type subscription struct {
+	broker  *Broker
+	topic   string
+	handler pubsub.Handler
+}
+
+func New(ctx context.Context, addr string) (*Broker, error) {
+	const op = "redis.New"
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	b := &Broker{
+		client: client,
+		ps:     client.Subscribe(ctx),
+		topics: make(map[string]map[*subscription]struct{}),
+		mu:     &sync.RWMutex{},
+	}
+	go b.receive()
+
+	return b, nil
+}
+
+func (b *Broker) Publish(ctx context.Context, topic string, payload []byte) error {
+	const op = "redis.Publish"
+
+	if err := b.client.Publish(ctx, topic, payload).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (b *Broker) Subscribe(topic string, handler pubsub.Handler) (pubsub.Subscription, error) {
+	const op = "redis.Subscribe"
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.topics[topic]) == 0 {
+		if err := b.ps.Subscribe(context.Background(), topic); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		b.topics[topic] = make(map[*subscription]struct{})
+	}
+
+	sub := &subscription{broker: b, topic: topic, handler: handler}
+	b.topics[topic][sub] = struct{}{}
+
+	return sub, nil
+}
+
+func (b *Broker) Close() error {
+	const op = "redis.Close"
+
+	if err := b.ps.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := b.client.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (b *Broker) receive() {
+	for msg := range b.ps.Channel() {
+		b.mu.RLock()
+		subs := make([]*subscription, 0, len(b.topics[msg.Channel]))
+		for sub := range b.topics[msg.Channel] {
+			subs = append(subs, sub)
+		}
+		b.mu.RUnlock()
+
+		for _, sub := range subs {
+			sub.handler([]byte(msg.Payload))
+		}
+	}
+}
+
+func (s *subscription) Unsubscribe() error {
+	const op = "redis.Unsubscribe"
+
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	delete(s.broker.topics[s.topic], s)
+	if len(s.broker.topics[s.topic]) != 0 {
+		return nil
+	}
+
+	delete(s.broker.topics, s.topic)
+	if err := s.broker.ps.Unsubscribe(context.Background(), s.topic); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
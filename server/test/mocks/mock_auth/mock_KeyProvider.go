@@ -0,0 +1,82 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_auth
+
+import (
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockKeyProvider is an autogenerated mock type for the KeyProvider type
+type MockKeyProvider struct {
+	mock.Mock
+}
+
+type MockKeyProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyProvider) EXPECT() *MockKeyProvider_Expecter {
+	return &MockKeyProvider_Expecter{mock: &_m.Mock}
+}
+
+// PublicKeys provides a mock function with given fields:
+func (_m *MockKeyProvider) PublicKeys() []model.PublicKey {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKeys")
+	}
+
+	var r0 []model.PublicKey
+	if rf, ok := ret.Get(0).(func() []model.PublicKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PublicKey)
+		}
+	}
+
+	return r0
+}
+
+// MockKeyProvider_PublicKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeys'
+type MockKeyProvider_PublicKeys_Call struct {
+	*mock.Call
+}
+
+// PublicKeys is a helper method to define mock.On call
+func (_e *MockKeyProvider_Expecter) PublicKeys() *MockKeyProvider_PublicKeys_Call {
+	return &MockKeyProvider_PublicKeys_Call{Call: _e.mock.On("PublicKeys")}
+}
+
+func (_c *MockKeyProvider_PublicKeys_Call) Run(run func()) *MockKeyProvider_PublicKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockKeyProvider_PublicKeys_Call) Return(_a0 []model.PublicKey) *MockKeyProvider_PublicKeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKeyProvider_PublicKeys_Call) RunAndReturn(run func() []model.PublicKey) *MockKeyProvider_PublicKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKeyProvider creates a new instance of MockKeyProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyProvider {
+	mock := &MockKeyProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_auth
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRevocationProvider is an autogenerated mock type for the RevocationProvider type
+type MockRevocationProvider struct {
+	mock.Mock
+}
+
+type MockRevocationProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevocationProvider) EXPECT() *MockRevocationProvider_Expecter {
+	return &MockRevocationProvider_Expecter{mock: &_m.Mock}
+}
+
+// Revoked provides a mock function with given fields: ctx, jti
+func (_m *MockRevocationProvider) Revoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevocationProvider_Revoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoked'
+type MockRevocationProvider_Revoked_Call struct {
+	*mock.Call
+}
+
+// Revoked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jti string
+func (_e *MockRevocationProvider_Expecter) Revoked(ctx interface{}, jti interface{}) *MockRevocationProvider_Revoked_Call {
+	return &MockRevocationProvider_Revoked_Call{Call: _e.mock.On("Revoked", ctx, jti)}
+}
+
+func (_c *MockRevocationProvider_Revoked_Call) Run(run func(ctx context.Context, jti string)) *MockRevocationProvider_Revoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRevocationProvider_Revoked_Call) Return(_a0 bool, _a1 error) *MockRevocationProvider_Revoked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevocationProvider_Revoked_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockRevocationProvider_Revoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevocationProvider creates a new instance of MockRevocationProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevocationProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevocationProvider {
+	mock := &MockRevocationProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,9 +22,9 @@ func (_m *MockTokenMaker) EXPECT() *MockTokenMaker_Expecter {
 	return &MockTokenMaker_Expecter{mock: &_m.Mock}
 }
 
-// MakeToken provides a mock function with given fields: user, duration
-func (_m *MockTokenMaker) MakeToken(user model.UserCredentials, duration time.Duration) (string, error) {
-	ret := _m.Called(user, duration)
+// MakeToken provides a mock function with given fields: user, typ, duration
+func (_m *MockTokenMaker) MakeToken(user model.UserCredentials, typ string, duration time.Duration) (string, error) {
+	ret := _m.Called(user, typ, duration)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MakeToken")
@@ -32,17 +32,17 @@ func (_m *MockTokenMaker) MakeToken(user model.UserCredentials, duration time.Du
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.UserCredentials, time.Duration) (string, error)); ok {
-		return rf(user, duration)
+	if rf, ok := ret.Get(0).(func(model.UserCredentials, string, time.Duration) (string, error)); ok {
+		return rf(user, typ, duration)
 	}
-	if rf, ok := ret.Get(0).(func(model.UserCredentials, time.Duration) string); ok {
-		r0 = rf(user, duration)
+	if rf, ok := ret.Get(0).(func(model.UserCredentials, string, time.Duration) string); ok {
+		r0 = rf(user, typ, duration)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.UserCredentials, time.Duration) error); ok {
-		r1 = rf(user, duration)
+	if rf, ok := ret.Get(1).(func(model.UserCredentials, string, time.Duration) error); ok {
+		r1 = rf(user, typ, duration)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +57,15 @@ type MockTokenMaker_MakeToken_Call struct {
 
 // MakeToken is a helper method to define mock.On call
 //   - user model.UserCredentials
+//   - typ string
 //   - duration time.Duration
-func (_e *MockTokenMaker_Expecter) MakeToken(user interface{}, duration interface{}) *MockTokenMaker_MakeToken_Call {
-	return &MockTokenMaker_MakeToken_Call{Call: _e.mock.On("MakeToken", user, duration)}
+func (_e *MockTokenMaker_Expecter) MakeToken(user interface{}, typ interface{}, duration interface{}) *MockTokenMaker_MakeToken_Call {
+	return &MockTokenMaker_MakeToken_Call{Call: _e.mock.On("MakeToken", user, typ, duration)}
 }
 
-func (_c *MockTokenMaker_MakeToken_Call) Run(run func(user model.UserCredentials, duration time.Duration)) *MockTokenMaker_MakeToken_Call {
+func (_c *MockTokenMaker_MakeToken_Call) Run(run func(user model.UserCredentials, typ string, duration time.Duration)) *MockTokenMaker_MakeToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(model.UserCredentials), args[1].(time.Duration))
+		run(args[0].(model.UserCredentials), args[1].(string), args[2].(time.Duration))
 	})
 	return _c
 }
@@ -74,7 +75,7 @@ func (_c *MockTokenMaker_MakeToken_Call) Return(_a0 string, _a1 error) *MockToke
 	return _c
 }
 
-func (_c *MockTokenMaker_MakeToken_Call) RunAndReturn(run func(model.UserCredentials, time.Duration) (string, error)) *MockTokenMaker_MakeToken_Call {
+func (_c *MockTokenMaker_MakeToken_Call) RunAndReturn(run func(model.UserCredentials, string, time.Duration) (string, error)) *MockTokenMaker_MakeToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_auth
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTokenRevoker is an autogenerated mock type for the TokenRevoker type
+type MockTokenRevoker struct {
+	mock.Mock
+}
+
+type MockTokenRevoker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTokenRevoker) EXPECT() *MockTokenRevoker_Expecter {
+	return &MockTokenRevoker_Expecter{mock: &_m.Mock}
+}
+
+// Revoke provides a mock function with given fields: ctx, jti, expiresAt
+func (_m *MockTokenRevoker) Revoke(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, jti, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return rf(ctx, jti, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, jti, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, jti, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTokenRevoker_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockTokenRevoker_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jti string
+//   - expiresAt time.Time
+func (_e *MockTokenRevoker_Expecter) Revoke(ctx interface{}, jti interface{}, expiresAt interface{}) *MockTokenRevoker_Revoke_Call {
+	return &MockTokenRevoker_Revoke_Call{Call: _e.mock.On("Revoke", ctx, jti, expiresAt)}
+}
+
+func (_c *MockTokenRevoker_Revoke_Call) Run(run func(ctx context.Context, jti string, expiresAt time.Time)) *MockTokenRevoker_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTokenRevoker_Revoke_Call) Return(_a0 bool, _a1 error) *MockTokenRevoker_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTokenRevoker_Revoke_Call) RunAndReturn(run func(context.Context, string, time.Time) (bool, error)) *MockTokenRevoker_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTokenRevoker creates a new instance of MockTokenRevoker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTokenRevoker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTokenRevoker {
+	mock := &MockTokenRevoker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,53 @@ func (_m *MockUserSaver) EXPECT() *MockUserSaver_Expecter {
 	return &MockUserSaver_Expecter{mock: &_m.Mock}
 }
 
+// Delete provides a mock function with given fields: ctx, uid
+func (_m *MockUserSaver) Delete(ctx context.Context, uid []byte) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserSaver_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserSaver_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockUserSaver_Expecter) Delete(ctx interface{}, uid interface{}) *MockUserSaver_Delete_Call {
+	return &MockUserSaver_Delete_Call{Call: _e.mock.On("Delete", ctx, uid)}
+}
+
+func (_c *MockUserSaver_Delete_Call) Run(run func(ctx context.Context, uid []byte)) *MockUserSaver_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserSaver_Delete_Call) Return(_a0 error) *MockUserSaver_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSaver_Delete_Call) RunAndReturn(run func(context.Context, []byte) error) *MockUserSaver_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, uid, email, passHash
 func (_m *MockUserSaver) Save(ctx context.Context, uid []byte, email string, passHash []byte) (model.UserCredentials, error) {
 	ret := _m.Called(ctx, uid, email, passHash)
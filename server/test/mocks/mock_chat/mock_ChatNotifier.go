@@ -22,6 +22,194 @@ func (_m *MockChatNotifier) EXPECT() *MockChatNotifier_Expecter {
 	return &MockChatNotifier_Expecter{mock: &_m.Mock}
 }
 
+// ChatUpdated provides a mock function with given fields: ctx, _a1
+func (_m *MockChatNotifier) ChatUpdated(ctx context.Context, _a1 *model.Chat) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChatUpdated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Chat) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatNotifier_ChatUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChatUpdated'
+type MockChatNotifier_ChatUpdated_Call struct {
+	*mock.Call
+}
+
+// ChatUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *model.Chat
+func (_e *MockChatNotifier_Expecter) ChatUpdated(ctx interface{}, _a1 interface{}) *MockChatNotifier_ChatUpdated_Call {
+	return &MockChatNotifier_ChatUpdated_Call{Call: _e.mock.On("ChatUpdated", ctx, _a1)}
+}
+
+func (_c *MockChatNotifier_ChatUpdated_Call) Run(run func(ctx context.Context, _a1 *model.Chat)) *MockChatNotifier_ChatUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Chat))
+	})
+	return _c
+}
+
+func (_c *MockChatNotifier_ChatUpdated_Call) Return(_a0 error) *MockChatNotifier_ChatUpdated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatNotifier_ChatUpdated_Call) RunAndReturn(run func(context.Context, *model.Chat) error) *MockChatNotifier_ChatUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessageDeleted provides a mock function with given fields: ctx, message
+func (_m *MockChatNotifier) MessageDeleted(ctx context.Context, message *model.ChatMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessageDeleted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ChatMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatNotifier_MessageDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessageDeleted'
+type MockChatNotifier_MessageDeleted_Call struct {
+	*mock.Call
+}
+
+// MessageDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.ChatMessage
+func (_e *MockChatNotifier_Expecter) MessageDeleted(ctx interface{}, message interface{}) *MockChatNotifier_MessageDeleted_Call {
+	return &MockChatNotifier_MessageDeleted_Call{Call: _e.mock.On("MessageDeleted", ctx, message)}
+}
+
+func (_c *MockChatNotifier_MessageDeleted_Call) Run(run func(ctx context.Context, message *model.ChatMessage)) *MockChatNotifier_MessageDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *MockChatNotifier_MessageDeleted_Call) Return(_a0 error) *MockChatNotifier_MessageDeleted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatNotifier_MessageDeleted_Call) RunAndReturn(run func(context.Context, *model.ChatMessage) error) *MockChatNotifier_MessageDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessageEdited provides a mock function with given fields: ctx, message
+func (_m *MockChatNotifier) MessageEdited(ctx context.Context, message *model.ChatMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessageEdited")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ChatMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatNotifier_MessageEdited_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessageEdited'
+type MockChatNotifier_MessageEdited_Call struct {
+	*mock.Call
+}
+
+// MessageEdited is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.ChatMessage
+func (_e *MockChatNotifier_Expecter) MessageEdited(ctx interface{}, message interface{}) *MockChatNotifier_MessageEdited_Call {
+	return &MockChatNotifier_MessageEdited_Call{Call: _e.mock.On("MessageEdited", ctx, message)}
+}
+
+func (_c *MockChatNotifier_MessageEdited_Call) Run(run func(ctx context.Context, message *model.ChatMessage)) *MockChatNotifier_MessageEdited_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *MockChatNotifier_MessageEdited_Call) Return(_a0 error) *MockChatNotifier_MessageEdited_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatNotifier_MessageEdited_Call) RunAndReturn(run func(context.Context, *model.ChatMessage) error) *MockChatNotifier_MessageEdited_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessagesRead provides a mock function with given fields: ctx, receipt
+func (_m *MockChatNotifier) MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error {
+	ret := _m.Called(ctx, receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessagesRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReadReceipt) error); ok {
+		r0 = rf(ctx, receipt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatNotifier_MessagesRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessagesRead'
+type MockChatNotifier_MessagesRead_Call struct {
+	*mock.Call
+}
+
+// MessagesRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - receipt *model.ReadReceipt
+func (_e *MockChatNotifier_Expecter) MessagesRead(ctx interface{}, receipt interface{}) *MockChatNotifier_MessagesRead_Call {
+	return &MockChatNotifier_MessagesRead_Call{Call: _e.mock.On("MessagesRead", ctx, receipt)}
+}
+
+func (_c *MockChatNotifier_MessagesRead_Call) Run(run func(ctx context.Context, receipt *model.ReadReceipt)) *MockChatNotifier_MessagesRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ReadReceipt))
+	})
+	return _c
+}
+
+func (_c *MockChatNotifier_MessagesRead_Call) Return(_a0 error) *MockChatNotifier_MessagesRead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatNotifier_MessagesRead_Call) RunAndReturn(run func(context.Context, *model.ReadReceipt) error) *MockChatNotifier_MessagesRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewChat provides a mock function with given fields: ctx, _a1
 func (_m *MockChatNotifier) NewChat(ctx context.Context, _a1 *model.Chat) error {
 	ret := _m.Called(ctx, _a1)
@@ -116,6 +304,53 @@ func (_c *MockChatNotifier_NewMessage_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// ReactionUpdated provides a mock function with given fields: ctx, update
+func (_m *MockChatNotifier) ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactionUpdated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReactionUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatNotifier_ReactionUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReactionUpdated'
+type MockChatNotifier_ReactionUpdated_Call struct {
+	*mock.Call
+}
+
+// ReactionUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update *model.ReactionUpdate
+func (_e *MockChatNotifier_Expecter) ReactionUpdated(ctx interface{}, update interface{}) *MockChatNotifier_ReactionUpdated_Call {
+	return &MockChatNotifier_ReactionUpdated_Call{Call: _e.mock.On("ReactionUpdated", ctx, update)}
+}
+
+func (_c *MockChatNotifier_ReactionUpdated_Call) Run(run func(ctx context.Context, update *model.ReactionUpdate)) *MockChatNotifier_ReactionUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ReactionUpdate))
+	})
+	return _c
+}
+
+func (_c *MockChatNotifier_ReactionUpdated_Call) Return(_a0 error) *MockChatNotifier_ReactionUpdated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatNotifier_ReactionUpdated_Call) RunAndReturn(run func(context.Context, *model.ReactionUpdate) error) *MockChatNotifier_ReactionUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockChatNotifier creates a new instance of MockChatNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockChatNotifier(t interface {
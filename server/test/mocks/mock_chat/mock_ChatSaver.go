@@ -22,6 +22,280 @@ func (_m *MockChatSaver) EXPECT() *MockChatSaver_Expecter {
 	return &MockChatSaver_Expecter{mock: &_m.Mock}
 }
 
+// AddMember provides a mock function with given fields: ctx, cid, uid
+func (_m *MockChatSaver) AddMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	ret := _m.Called(ctx, cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (model.Chat, error)); ok {
+		return rf(ctx, cid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) model.Chat); ok {
+		r0 = rf(ctx, cid, uid)
+	} else {
+		r0 = ret.Get(0).(model.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChatSaver_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type MockChatSaver_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+func (_e *MockChatSaver_Expecter) AddMember(ctx interface{}, cid interface{}, uid interface{}) *MockChatSaver_AddMember_Call {
+	return &MockChatSaver_AddMember_Call{Call: _e.mock.On("AddMember", ctx, cid, uid)}
+}
+
+func (_c *MockChatSaver_AddMember_Call) Run(run func(ctx context.Context, cid []byte, uid []byte)) *MockChatSaver_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatSaver_AddMember_Call) Return(_a0 model.Chat, _a1 error) *MockChatSaver_AddMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChatSaver_AddMember_Call) RunAndReturn(run func(context.Context, []byte, []byte) (model.Chat, error)) *MockChatSaver_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DecUnread provides a mock function with given fields: ctx, cid, uids, timestamp
+func (_m *MockChatSaver) DecUnread(ctx context.Context, cid []byte, uids [][]byte, timestamp int64) error {
+	ret := _m.Called(ctx, cid, uids, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecUnread")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, int64) error); ok {
+		r0 = rf(ctx, cid, uids, timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatSaver_DecUnread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecUnread'
+type MockChatSaver_DecUnread_Call struct {
+	*mock.Call
+}
+
+// DecUnread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uids [][]byte
+//   - timestamp int64
+func (_e *MockChatSaver_Expecter) DecUnread(ctx interface{}, cid interface{}, uids interface{}, timestamp interface{}) *MockChatSaver_DecUnread_Call {
+	return &MockChatSaver_DecUnread_Call{Call: _e.mock.On("DecUnread", ctx, cid, uids, timestamp)}
+}
+
+func (_c *MockChatSaver_DecUnread_Call) Run(run func(ctx context.Context, cid []byte, uids [][]byte, timestamp int64)) *MockChatSaver_DecUnread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([][]byte), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockChatSaver_DecUnread_Call) Return(_a0 error) *MockChatSaver_DecUnread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatSaver_DecUnread_Call) RunAndReturn(run func(context.Context, []byte, [][]byte, int64) error) *MockChatSaver_DecUnread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncUnread provides a mock function with given fields: ctx, cid, uids
+func (_m *MockChatSaver) IncUnread(ctx context.Context, cid []byte, uids [][]byte) error {
+	ret := _m.Called(ctx, cid, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncUnread")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte) error); ok {
+		r0 = rf(ctx, cid, uids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatSaver_IncUnread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncUnread'
+type MockChatSaver_IncUnread_Call struct {
+	*mock.Call
+}
+
+// IncUnread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uids [][]byte
+func (_e *MockChatSaver_Expecter) IncUnread(ctx interface{}, cid interface{}, uids interface{}) *MockChatSaver_IncUnread_Call {
+	return &MockChatSaver_IncUnread_Call{Call: _e.mock.On("IncUnread", ctx, cid, uids)}
+}
+
+func (_c *MockChatSaver_IncUnread_Call) Run(run func(ctx context.Context, cid []byte, uids [][]byte)) *MockChatSaver_IncUnread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([][]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatSaver_IncUnread_Call) Return(_a0 error) *MockChatSaver_IncUnread_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatSaver_IncUnread_Call) RunAndReturn(run func(context.Context, []byte, [][]byte) error) *MockChatSaver_IncUnread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function with given fields: ctx, uid, cid, mid, timestamp, unread
+func (_m *MockChatSaver) MarkRead(ctx context.Context, uid []byte, cid []byte, mid []byte, timestamp int64, unread int64) (bool, error) {
+	ret := _m.Called(ctx, uid, cid, mid, timestamp, unread)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, int64, int64) (bool, error)); ok {
+		return rf(ctx, uid, cid, mid, timestamp, unread)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, int64, int64) bool); ok {
+		r0 = rf(ctx, uid, cid, mid, timestamp, unread)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte, int64, int64) error); ok {
+		r1 = rf(ctx, uid, cid, mid, timestamp, unread)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChatSaver_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type MockChatSaver_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - cid []byte
+//   - mid []byte
+//   - timestamp int64
+//   - unread int64
+func (_e *MockChatSaver_Expecter) MarkRead(ctx interface{}, uid interface{}, cid interface{}, mid interface{}, timestamp interface{}, unread interface{}) *MockChatSaver_MarkRead_Call {
+	return &MockChatSaver_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, uid, cid, mid, timestamp, unread)}
+}
+
+func (_c *MockChatSaver_MarkRead_Call) Run(run func(ctx context.Context, uid []byte, cid []byte, mid []byte, timestamp int64, unread int64)) *MockChatSaver_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte), args[4].(int64), args[5].(int64))
+	})
+	return _c
+}
+
+func (_c *MockChatSaver_MarkRead_Call) Return(_a0 bool, _a1 error) *MockChatSaver_MarkRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChatSaver_MarkRead_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte, int64, int64) (bool, error)) *MockChatSaver_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, cid, uid
+func (_m *MockChatSaver) RemoveMember(ctx context.Context, cid []byte, uid []byte) (model.Chat, error) {
+	ret := _m.Called(ctx, cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (model.Chat, error)); ok {
+		return rf(ctx, cid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) model.Chat); ok {
+		r0 = rf(ctx, cid, uid)
+	} else {
+		r0 = ret.Get(0).(model.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChatSaver_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type MockChatSaver_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+func (_e *MockChatSaver_Expecter) RemoveMember(ctx interface{}, cid interface{}, uid interface{}) *MockChatSaver_RemoveMember_Call {
+	return &MockChatSaver_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, cid, uid)}
+}
+
+func (_c *MockChatSaver_RemoveMember_Call) Run(run func(ctx context.Context, cid []byte, uid []byte)) *MockChatSaver_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatSaver_RemoveMember_Call) Return(_a0 model.Chat, _a1 error) *MockChatSaver_RemoveMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChatSaver_RemoveMember_Call) RunAndReturn(run func(context.Context, []byte, []byte) (model.Chat, error)) *MockChatSaver_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, from, to
 func (_m *MockChatSaver) Save(ctx context.Context, from []byte, to []byte) (model.Chat, error) {
 	ret := _m.Called(ctx, from, to)
@@ -80,6 +354,65 @@ func (_c *MockChatSaver_Save_Call) RunAndReturn(run func(context.Context, []byte
 	return _c
 }
 
+// SaveGroup provides a mock function with given fields: ctx, owner, title, members
+func (_m *MockChatSaver) SaveGroup(ctx context.Context, owner []byte, title string, members [][]byte) (model.Chat, error) {
+	ret := _m.Called(ctx, owner, title, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGroup")
+	}
+
+	var r0 model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, [][]byte) (model.Chat, error)); ok {
+		return rf(ctx, owner, title, members)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, [][]byte) model.Chat); ok {
+		r0 = rf(ctx, owner, title, members)
+	} else {
+		r0 = ret.Get(0).(model.Chat)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string, [][]byte) error); ok {
+		r1 = rf(ctx, owner, title, members)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChatSaver_SaveGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveGroup'
+type MockChatSaver_SaveGroup_Call struct {
+	*mock.Call
+}
+
+// SaveGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner []byte
+//   - title string
+//   - members [][]byte
+func (_e *MockChatSaver_Expecter) SaveGroup(ctx interface{}, owner interface{}, title interface{}, members interface{}) *MockChatSaver_SaveGroup_Call {
+	return &MockChatSaver_SaveGroup_Call{Call: _e.mock.On("SaveGroup", ctx, owner, title, members)}
+}
+
+func (_c *MockChatSaver_SaveGroup_Call) Run(run func(ctx context.Context, owner []byte, title string, members [][]byte)) *MockChatSaver_SaveGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(string), args[3].([][]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatSaver_SaveGroup_Call) Return(_a0 model.Chat, _a1 error) *MockChatSaver_SaveGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChatSaver_SaveGroup_Call) RunAndReturn(run func(context.Context, []byte, string, [][]byte) (model.Chat, error)) *MockChatSaver_SaveGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockChatSaver creates a new instance of MockChatSaver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockChatSaver(t interface {
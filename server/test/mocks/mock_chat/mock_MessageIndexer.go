@@ -0,0 +1,192 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_chat
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMessageIndexer is an autogenerated mock type for the MessageIndexer type
+type MockMessageIndexer struct {
+	mock.Mock
+}
+
+type MockMessageIndexer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMessageIndexer) EXPECT() *MockMessageIndexer_Expecter {
+	return &MockMessageIndexer_Expecter{mock: &_m.Mock}
+}
+
+// Index provides a mock function with given fields: ctx, message
+func (_m *MockMessageIndexer) Index(ctx context.Context, message *model.ChatMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Index")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ChatMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMessageIndexer_Index_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Index'
+type MockMessageIndexer_Index_Call struct {
+	*mock.Call
+}
+
+// Index is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.ChatMessage
+func (_e *MockMessageIndexer_Expecter) Index(ctx interface{}, message interface{}) *MockMessageIndexer_Index_Call {
+	return &MockMessageIndexer_Index_Call{Call: _e.mock.On("Index", ctx, message)}
+}
+
+func (_c *MockMessageIndexer_Index_Call) Run(run func(ctx context.Context, message *model.ChatMessage)) *MockMessageIndexer_Index_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *MockMessageIndexer_Index_Call) Return(_a0 error) *MockMessageIndexer_Index_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMessageIndexer_Index_Call) RunAndReturn(run func(context.Context, *model.ChatMessage) error) *MockMessageIndexer_Index_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, cid, mid
+func (_m *MockMessageIndexer) Remove(ctx context.Context, cid []byte, mid []byte) error {
+	ret := _m.Called(ctx, cid, mid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) error); ok {
+		r0 = rf(ctx, cid, mid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMessageIndexer_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockMessageIndexer_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+func (_e *MockMessageIndexer_Expecter) Remove(ctx interface{}, cid interface{}, mid interface{}) *MockMessageIndexer_Remove_Call {
+	return &MockMessageIndexer_Remove_Call{Call: _e.mock.On("Remove", ctx, cid, mid)}
+}
+
+func (_c *MockMessageIndexer_Remove_Call) Run(run func(ctx context.Context, cid []byte, mid []byte)) *MockMessageIndexer_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockMessageIndexer_Remove_Call) Return(_a0 error) *MockMessageIndexer_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMessageIndexer_Remove_Call) RunAndReturn(run func(context.Context, []byte, []byte) error) *MockMessageIndexer_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, cids, query, after, limit
+func (_m *MockMessageIndexer) Search(ctx context.Context, cids [][]byte, query string, after []byte, limit int) (model.MessageHitsPage, error) {
+	ret := _m.Called(ctx, cids, query, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 model.MessageHitsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte, string, []byte, int) (model.MessageHitsPage, error)); ok {
+		return rf(ctx, cids, query, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte, string, []byte, int) model.MessageHitsPage); ok {
+		r0 = rf(ctx, cids, query, after, limit)
+	} else {
+		r0 = ret.Get(0).(model.MessageHitsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, [][]byte, string, []byte, int) error); ok {
+		r1 = rf(ctx, cids, query, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMessageIndexer_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockMessageIndexer_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cids [][]byte
+//   - query string
+//   - after []byte
+//   - limit int
+func (_e *MockMessageIndexer_Expecter) Search(ctx interface{}, cids interface{}, query interface{}, after interface{}, limit interface{}) *MockMessageIndexer_Search_Call {
+	return &MockMessageIndexer_Search_Call{Call: _e.mock.On("Search", ctx, cids, query, after, limit)}
+}
+
+func (_c *MockMessageIndexer_Search_Call) Run(run func(ctx context.Context, cids [][]byte, query string, after []byte, limit int)) *MockMessageIndexer_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([][]byte), args[2].(string), args[3].([]byte), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockMessageIndexer_Search_Call) Return(_a0 model.MessageHitsPage, _a1 error) *MockMessageIndexer_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMessageIndexer_Search_Call) RunAndReturn(run func(context.Context, [][]byte, string, []byte, int) (model.MessageHitsPage, error)) *MockMessageIndexer_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMessageIndexer creates a new instance of MockMessageIndexer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMessageIndexer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMessageIndexer {
+	mock := &MockMessageIndexer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,29 +22,85 @@ func (_m *MockMessageProvider) EXPECT() *MockMessageProvider_Expecter {
 	return &MockMessageProvider_Expecter{mock: &_m.Mock}
 }
 
-// Messages provides a mock function with given fields: ctx, cid
-func (_m *MockMessageProvider) Messages(ctx context.Context, cid []byte) ([]model.ChatMessage, error) {
-	ret := _m.Called(ctx, cid)
+// Message provides a mock function with given fields: ctx, cid, mid
+func (_m *MockMessageProvider) Message(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, mid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Message")
+	}
+
+	var r0 model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (model.ChatMessage, error)); ok {
+		return rf(ctx, cid, mid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) model.ChatMessage); ok {
+		r0 = rf(ctx, cid, mid)
+	} else {
+		r0 = ret.Get(0).(model.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, mid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMessageProvider_Message_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Message'
+type MockMessageProvider_Message_Call struct {
+	*mock.Call
+}
+
+// Message is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+func (_e *MockMessageProvider_Expecter) Message(ctx interface{}, cid interface{}, mid interface{}) *MockMessageProvider_Message_Call {
+	return &MockMessageProvider_Message_Call{Call: _e.mock.On("Message", ctx, cid, mid)}
+}
+
+func (_c *MockMessageProvider_Message_Call) Run(run func(ctx context.Context, cid []byte, mid []byte)) *MockMessageProvider_Message_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockMessageProvider_Message_Call) Return(_a0 model.ChatMessage, _a1 error) *MockMessageProvider_Message_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMessageProvider_Message_Call) RunAndReturn(run func(context.Context, []byte, []byte) (model.ChatMessage, error)) *MockMessageProvider_Message_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Messages provides a mock function with given fields: ctx, cid, before, after, limit
+func (_m *MockMessageProvider) Messages(ctx context.Context, cid []byte, before []byte, after []byte, limit int) (model.MessagesPage, error) {
+	ret := _m.Called(ctx, cid, before, after, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Messages")
 	}
 
-	var r0 []model.ChatMessage
+	var r0 model.MessagesPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []byte) ([]model.ChatMessage, error)); ok {
-		return rf(ctx, cid)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, int) (model.MessagesPage, error)); ok {
+		return rf(ctx, cid, before, after, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []byte) []model.ChatMessage); ok {
-		r0 = rf(ctx, cid)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, int) model.MessagesPage); ok {
+		r0 = rf(ctx, cid, before, after, limit)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.ChatMessage)
-		}
+		r0 = ret.Get(0).(model.MessagesPage)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
-		r1 = rf(ctx, cid)
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte, int) error); ok {
+		r1 = rf(ctx, cid, before, after, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,23 +116,26 @@ type MockMessageProvider_Messages_Call struct {
 // Messages is a helper method to define mock.On call
 //   - ctx context.Context
 //   - cid []byte
-func (_e *MockMessageProvider_Expecter) Messages(ctx interface{}, cid interface{}) *MockMessageProvider_Messages_Call {
-	return &MockMessageProvider_Messages_Call{Call: _e.mock.On("Messages", ctx, cid)}
+//   - before []byte
+//   - after []byte
+//   - limit int
+func (_e *MockMessageProvider_Expecter) Messages(ctx interface{}, cid interface{}, before interface{}, after interface{}, limit interface{}) *MockMessageProvider_Messages_Call {
+	return &MockMessageProvider_Messages_Call{Call: _e.mock.On("Messages", ctx, cid, before, after, limit)}
 }
 
-func (_c *MockMessageProvider_Messages_Call) Run(run func(ctx context.Context, cid []byte)) *MockMessageProvider_Messages_Call {
+func (_c *MockMessageProvider_Messages_Call) Run(run func(ctx context.Context, cid []byte, before []byte, after []byte, limit int)) *MockMessageProvider_Messages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]byte))
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte), args[4].(int))
 	})
 	return _c
 }
 
-func (_c *MockMessageProvider_Messages_Call) Return(_a0 []model.ChatMessage, _a1 error) *MockMessageProvider_Messages_Call {
+func (_c *MockMessageProvider_Messages_Call) Return(_a0 model.MessagesPage, _a1 error) *MockMessageProvider_Messages_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMessageProvider_Messages_Call) RunAndReturn(run func(context.Context, []byte) ([]model.ChatMessage, error)) *MockMessageProvider_Messages_Call {
+func (_c *MockMessageProvider_Messages_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte, int) (model.MessagesPage, error)) *MockMessageProvider_Messages_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -22,9 +22,67 @@ func (_m *MockMessageSaver) EXPECT() *MockMessageSaver_Expecter {
 	return &MockMessageSaver_Expecter{mock: &_m.Mock}
 }
 
-// Save provides a mock function with given fields: ctx, cid, from, text
-func (_m *MockMessageSaver) Save(ctx context.Context, cid []byte, from []byte, text string) (model.ChatMessage, error) {
-	ret := _m.Called(ctx, cid, from, text)
+// Delete provides a mock function with given fields: ctx, cid, mid
+func (_m *MockMessageSaver) Delete(ctx context.Context, cid []byte, mid []byte) (model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, mid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (model.ChatMessage, error)); ok {
+		return rf(ctx, cid, mid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) model.ChatMessage); ok {
+		r0 = rf(ctx, cid, mid)
+	} else {
+		r0 = ret.Get(0).(model.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, mid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMessageSaver_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockMessageSaver_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+func (_e *MockMessageSaver_Expecter) Delete(ctx interface{}, cid interface{}, mid interface{}) *MockMessageSaver_Delete_Call {
+	return &MockMessageSaver_Delete_Call{Call: _e.mock.On("Delete", ctx, cid, mid)}
+}
+
+func (_c *MockMessageSaver_Delete_Call) Run(run func(ctx context.Context, cid []byte, mid []byte)) *MockMessageSaver_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockMessageSaver_Delete_Call) Return(_a0 model.ChatMessage, _a1 error) *MockMessageSaver_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMessageSaver_Delete_Call) RunAndReturn(run func(context.Context, []byte, []byte) (model.ChatMessage, error)) *MockMessageSaver_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, cid, from, text, refs
+func (_m *MockMessageSaver) Save(ctx context.Context, cid []byte, from []byte, text string, refs model.MessageRefs) (model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, from, text, refs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
@@ -32,17 +90,17 @@ func (_m *MockMessageSaver) Save(ctx context.Context, cid []byte, from []byte, t
 
 	var r0 model.ChatMessage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string) (model.ChatMessage, error)); ok {
-		return rf(ctx, cid, from, text)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string, model.MessageRefs) (model.ChatMessage, error)); ok {
+		return rf(ctx, cid, from, text, refs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string) model.ChatMessage); ok {
-		r0 = rf(ctx, cid, from, text)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string, model.MessageRefs) model.ChatMessage); ok {
+		r0 = rf(ctx, cid, from, text, refs)
 	} else {
 		r0 = ret.Get(0).(model.ChatMessage)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, string) error); ok {
-		r1 = rf(ctx, cid, from, text)
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, string, model.MessageRefs) error); ok {
+		r1 = rf(ctx, cid, from, text, refs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,13 +118,14 @@ type MockMessageSaver_Save_Call struct {
 //   - cid []byte
 //   - from []byte
 //   - text string
-func (_e *MockMessageSaver_Expecter) Save(ctx interface{}, cid interface{}, from interface{}, text interface{}) *MockMessageSaver_Save_Call {
-	return &MockMessageSaver_Save_Call{Call: _e.mock.On("Save", ctx, cid, from, text)}
+//   - refs model.MessageRefs
+func (_e *MockMessageSaver_Expecter) Save(ctx interface{}, cid interface{}, from interface{}, text interface{}, refs interface{}) *MockMessageSaver_Save_Call {
+	return &MockMessageSaver_Save_Call{Call: _e.mock.On("Save", ctx, cid, from, text, refs)}
 }
 
-func (_c *MockMessageSaver_Save_Call) Run(run func(ctx context.Context, cid []byte, from []byte, text string)) *MockMessageSaver_Save_Call {
+func (_c *MockMessageSaver_Save_Call) Run(run func(ctx context.Context, cid []byte, from []byte, text string, refs model.MessageRefs)) *MockMessageSaver_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(string))
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(string), args[4].(model.MessageRefs))
 	})
 	return _c
 }
@@ -76,7 +135,66 @@ func (_c *MockMessageSaver_Save_Call) Return(_a0 model.ChatMessage, _a1 error) *
 	return _c
 }
 
-func (_c *MockMessageSaver_Save_Call) RunAndReturn(run func(context.Context, []byte, []byte, string) (model.ChatMessage, error)) *MockMessageSaver_Save_Call {
+func (_c *MockMessageSaver_Save_Call) RunAndReturn(run func(context.Context, []byte, []byte, string, model.MessageRefs) (model.ChatMessage, error)) *MockMessageSaver_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, cid, mid, text
+func (_m *MockMessageSaver) Update(ctx context.Context, cid []byte, mid []byte, text string) (model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, mid, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string) (model.ChatMessage, error)); ok {
+		return rf(ctx, cid, mid, text)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string) model.ChatMessage); ok {
+		r0 = rf(ctx, cid, mid, text)
+	} else {
+		r0 = ret.Get(0).(model.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, string) error); ok {
+		r1 = rf(ctx, cid, mid, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMessageSaver_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockMessageSaver_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+//   - text string
+func (_e *MockMessageSaver_Expecter) Update(ctx interface{}, cid interface{}, mid interface{}, text interface{}) *MockMessageSaver_Update_Call {
+	return &MockMessageSaver_Update_Call{Call: _e.mock.On("Update", ctx, cid, mid, text)}
+}
+
+func (_c *MockMessageSaver_Update_Call) Run(run func(ctx context.Context, cid []byte, mid []byte, text string)) *MockMessageSaver_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockMessageSaver_Update_Call) Return(_a0 model.ChatMessage, _a1 error) *MockMessageSaver_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMessageSaver_Update_Call) RunAndReturn(run func(context.Context, []byte, []byte, string) (model.ChatMessage, error)) *MockMessageSaver_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
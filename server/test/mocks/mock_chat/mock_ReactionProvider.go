@@ -0,0 +1,97 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_chat
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReactionProvider is an autogenerated mock type for the ReactionProvider type
+type MockReactionProvider struct {
+	mock.Mock
+}
+
+type MockReactionProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReactionProvider) EXPECT() *MockReactionProvider_Expecter {
+	return &MockReactionProvider_Expecter{mock: &_m.Mock}
+}
+
+// Reactions provides a mock function with given fields: ctx, cid, mids
+func (_m *MockReactionProvider) Reactions(ctx context.Context, cid []byte, mids [][]byte) ([]model.MessageReactions, error) {
+	ret := _m.Called(ctx, cid, mids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reactions")
+	}
+
+	var r0 []model.MessageReactions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte) ([]model.MessageReactions, error)); ok {
+		return rf(ctx, cid, mids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte) []model.MessageReactions); ok {
+		r0 = rf(ctx, cid, mids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MessageReactions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte) error); ok {
+		r1 = rf(ctx, cid, mids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReactionProvider_Reactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reactions'
+type MockReactionProvider_Reactions_Call struct {
+	*mock.Call
+}
+
+// Reactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mids [][]byte
+func (_e *MockReactionProvider_Expecter) Reactions(ctx interface{}, cid interface{}, mids interface{}) *MockReactionProvider_Reactions_Call {
+	return &MockReactionProvider_Reactions_Call{Call: _e.mock.On("Reactions", ctx, cid, mids)}
+}
+
+func (_c *MockReactionProvider_Reactions_Call) Run(run func(ctx context.Context, cid []byte, mids [][]byte)) *MockReactionProvider_Reactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([][]byte))
+	})
+	return _c
+}
+
+func (_c *MockReactionProvider_Reactions_Call) Return(_a0 []model.MessageReactions, _a1 error) *MockReactionProvider_Reactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReactionProvider_Reactions_Call) RunAndReturn(run func(context.Context, []byte, [][]byte) ([]model.MessageReactions, error)) *MockReactionProvider_Reactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReactionProvider creates a new instance of MockReactionProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReactionProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReactionProvider {
+	mock := &MockReactionProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
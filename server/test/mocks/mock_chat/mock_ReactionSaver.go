@@ -0,0 +1,151 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_chat
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReactionSaver is an autogenerated mock type for the ReactionSaver type
+type MockReactionSaver struct {
+	mock.Mock
+}
+
+type MockReactionSaver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReactionSaver) EXPECT() *MockReactionSaver_Expecter {
+	return &MockReactionSaver_Expecter{mock: &_m.Mock}
+}
+
+// AddReaction provides a mock function with given fields: ctx, reaction
+func (_m *MockReactionSaver) AddReaction(ctx context.Context, reaction *model.Reaction) (int64, error) {
+	ret := _m.Called(ctx, reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reaction) (int64, error)); ok {
+		return rf(ctx, reaction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reaction) int64); ok {
+		r0 = rf(ctx, reaction)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Reaction) error); ok {
+		r1 = rf(ctx, reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReactionSaver_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type MockReactionSaver_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reaction *model.Reaction
+func (_e *MockReactionSaver_Expecter) AddReaction(ctx interface{}, reaction interface{}) *MockReactionSaver_AddReaction_Call {
+	return &MockReactionSaver_AddReaction_Call{Call: _e.mock.On("AddReaction", ctx, reaction)}
+}
+
+func (_c *MockReactionSaver_AddReaction_Call) Run(run func(ctx context.Context, reaction *model.Reaction)) *MockReactionSaver_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Reaction))
+	})
+	return _c
+}
+
+func (_c *MockReactionSaver_AddReaction_Call) Return(_a0 int64, _a1 error) *MockReactionSaver_AddReaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReactionSaver_AddReaction_Call) RunAndReturn(run func(context.Context, *model.Reaction) (int64, error)) *MockReactionSaver_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReaction provides a mock function with given fields: ctx, reaction
+func (_m *MockReactionSaver) RemoveReaction(ctx context.Context, reaction *model.Reaction) (int64, error) {
+	ret := _m.Called(ctx, reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reaction) (int64, error)); ok {
+		return rf(ctx, reaction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reaction) int64); ok {
+		r0 = rf(ctx, reaction)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Reaction) error); ok {
+		r1 = rf(ctx, reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReactionSaver_RemoveReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReaction'
+type MockReactionSaver_RemoveReaction_Call struct {
+	*mock.Call
+}
+
+// RemoveReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reaction *model.Reaction
+func (_e *MockReactionSaver_Expecter) RemoveReaction(ctx interface{}, reaction interface{}) *MockReactionSaver_RemoveReaction_Call {
+	return &MockReactionSaver_RemoveReaction_Call{Call: _e.mock.On("RemoveReaction", ctx, reaction)}
+}
+
+func (_c *MockReactionSaver_RemoveReaction_Call) Run(run func(ctx context.Context, reaction *model.Reaction)) *MockReactionSaver_RemoveReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Reaction))
+	})
+	return _c
+}
+
+func (_c *MockReactionSaver_RemoveReaction_Call) Return(_a0 int64, _a1 error) *MockReactionSaver_RemoveReaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReactionSaver_RemoveReaction_Call) RunAndReturn(run func(context.Context, *model.Reaction) (int64, error)) *MockReactionSaver_RemoveReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReactionSaver creates a new instance of MockReactionSaver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReactionSaver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReactionSaver {
+	mock := &MockReactionSaver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuthAccounts is an autogenerated mock type for the AuthAccounts type
+type MockAuthAccounts struct {
+	mock.Mock
+}
+
+type MockAuthAccounts_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuthAccounts) EXPECT() *MockAuthAccounts_Expecter {
+	return &MockAuthAccounts_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, uid, email, pass
+func (_m *MockAuthAccounts) Create(ctx context.Context, uid []byte, email string, pass string) ([]byte, error) {
+	ret := _m.Called(ctx, uid, email, pass)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, string) ([]byte, error)); ok {
+		return rf(ctx, uid, email, pass)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, string) []byte); ok {
+		r0 = rf(ctx, uid, email, pass)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string, string) error); ok {
+		r1 = rf(ctx, uid, email, pass)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthAccounts_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAuthAccounts_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - email string
+//   - pass string
+func (_e *MockAuthAccounts_Expecter) Create(ctx interface{}, uid interface{}, email interface{}, pass interface{}) *MockAuthAccounts_Create_Call {
+	return &MockAuthAccounts_Create_Call{Call: _e.mock.On("Create", ctx, uid, email, pass)}
+}
+
+func (_c *MockAuthAccounts_Create_Call) Run(run func(ctx context.Context, uid []byte, email string, pass string)) *MockAuthAccounts_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthAccounts_Create_Call) Return(_a0 []byte, _a1 error) *MockAuthAccounts_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthAccounts_Create_Call) RunAndReturn(run func(context.Context, []byte, string, string) ([]byte, error)) *MockAuthAccounts_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, uid
+func (_m *MockAuthAccounts) Delete(ctx context.Context, uid []byte) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthAccounts_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockAuthAccounts_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockAuthAccounts_Expecter) Delete(ctx interface{}, uid interface{}) *MockAuthAccounts_Delete_Call {
+	return &MockAuthAccounts_Delete_Call{Call: _e.mock.On("Delete", ctx, uid)}
+}
+
+func (_c *MockAuthAccounts_Delete_Call) Run(run func(ctx context.Context, uid []byte)) *MockAuthAccounts_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockAuthAccounts_Delete_Call) Return(_a0 error) *MockAuthAccounts_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthAccounts_Delete_Call) RunAndReturn(run func(context.Context, []byte) error) *MockAuthAccounts_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuthAccounts creates a new instance of MockAuthAccounts. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuthAccounts(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuthAccounts {
+	mock := &MockAuthAccounts{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockChatMembership is an autogenerated mock type for the ChatMembership type
+type MockChatMembership struct {
+	mock.Mock
+}
+
+type MockChatMembership_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChatMembership) EXPECT() *MockChatMembership_Expecter {
+	return &MockChatMembership_Expecter{mock: &_m.Mock}
+}
+
+// InChat provides a mock function with given fields: clientId, cid
+func (_m *MockChatMembership) InChat(clientId []byte, cid []byte) bool {
+	ret := _m.Called(clientId, cid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InChat")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte, []byte) bool); ok {
+		r0 = rf(clientId, cid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockChatMembership_InChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InChat'
+type MockChatMembership_InChat_Call struct {
+	*mock.Call
+}
+
+// InChat is a helper method to define mock.On call
+//   - clientId []byte
+//   - cid []byte
+func (_e *MockChatMembership_Expecter) InChat(clientId interface{}, cid interface{}) *MockChatMembership_InChat_Call {
+	return &MockChatMembership_InChat_Call{Call: _e.mock.On("InChat", clientId, cid)}
+}
+
+func (_c *MockChatMembership_InChat_Call) Run(run func(clientId []byte, cid []byte)) *MockChatMembership_InChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatMembership_InChat_Call) Return(_a0 bool) *MockChatMembership_InChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatMembership_InChat_Call) RunAndReturn(run func([]byte, []byte) bool) *MockChatMembership_InChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChatMembership creates a new instance of MockChatMembership. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChatMembership(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChatMembership {
+	mock := &MockChatMembership{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
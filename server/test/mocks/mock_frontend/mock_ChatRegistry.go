@@ -0,0 +1,141 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	frontend "github.com/dvid-messanger/internal/core/service/frontend"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+)
+
+// MockChatRegistry is an autogenerated mock type for the ChatRegistry type
+type MockChatRegistry struct {
+	mock.Mock
+}
+
+type MockChatRegistry_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChatRegistry) EXPECT() *MockChatRegistry_Expecter {
+	return &MockChatRegistry_Expecter{mock: &_m.Mock}
+}
+
+// RegisterChat provides a mock function with given fields: chat
+func (_m *MockChatRegistry) RegisterChat(chat *model.Chat) error {
+	ret := _m.Called(chat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterChat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Chat) error); ok {
+		r0 = rf(chat)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatRegistry_RegisterChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterChat'
+type MockChatRegistry_RegisterChat_Call struct {
+	*mock.Call
+}
+
+// RegisterChat is a helper method to define mock.On call
+//   - chat *model.Chat
+func (_e *MockChatRegistry_Expecter) RegisterChat(chat interface{}) *MockChatRegistry_RegisterChat_Call {
+	return &MockChatRegistry_RegisterChat_Call{Call: _e.mock.On("RegisterChat", chat)}
+}
+
+func (_c *MockChatRegistry_RegisterChat_Call) Run(run func(chat *model.Chat)) *MockChatRegistry_RegisterChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.Chat))
+	})
+	return _c
+}
+
+func (_c *MockChatRegistry_RegisterChat_Call) Return(_a0 error) *MockChatRegistry_RegisterChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatRegistry_RegisterChat_Call) RunAndReturn(run func(*model.Chat) error) *MockChatRegistry_RegisterChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChat provides a mock function with given fields: chat
+func (_m *MockChatRegistry) UpdateChat(chat *model.Chat) ([]frontend.Client, error) {
+	ret := _m.Called(chat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChat")
+	}
+
+	var r0 []frontend.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Chat) ([]frontend.Client, error)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Chat) []frontend.Client); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]frontend.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Chat) error); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChatRegistry_UpdateChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChat'
+type MockChatRegistry_UpdateChat_Call struct {
+	*mock.Call
+}
+
+// UpdateChat is a helper method to define mock.On call
+//   - chat *model.Chat
+func (_e *MockChatRegistry_Expecter) UpdateChat(chat interface{}) *MockChatRegistry_UpdateChat_Call {
+	return &MockChatRegistry_UpdateChat_Call{Call: _e.mock.On("UpdateChat", chat)}
+}
+
+func (_c *MockChatRegistry_UpdateChat_Call) Run(run func(chat *model.Chat)) *MockChatRegistry_UpdateChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.Chat))
+	})
+	return _c
+}
+
+func (_c *MockChatRegistry_UpdateChat_Call) Return(_a0 []frontend.Client, _a1 error) *MockChatRegistry_UpdateChat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChatRegistry_UpdateChat_Call) RunAndReturn(run func(*model.Chat) ([]frontend.Client, error)) *MockChatRegistry_UpdateChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChatRegistry creates a new instance of MockChatRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChatRegistry(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChatRegistry {
+	mock := &MockChatRegistry{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockChatWatcher is an autogenerated mock type for the ChatWatcher type
+type MockChatWatcher struct {
+	mock.Mock
+}
+
+type MockChatWatcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChatWatcher) EXPECT() *MockChatWatcher_Expecter {
+	return &MockChatWatcher_Expecter{mock: &_m.Mock}
+}
+
+// UnwatchChat provides a mock function with given fields: cid
+func (_m *MockChatWatcher) UnwatchChat(cid []byte) {
+	_m.Called(cid)
+}
+
+// MockChatWatcher_UnwatchChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnwatchChat'
+type MockChatWatcher_UnwatchChat_Call struct {
+	*mock.Call
+}
+
+// UnwatchChat is a helper method to define mock.On call
+//   - cid []byte
+func (_e *MockChatWatcher_Expecter) UnwatchChat(cid interface{}) *MockChatWatcher_UnwatchChat_Call {
+	return &MockChatWatcher_UnwatchChat_Call{Call: _e.mock.On("UnwatchChat", cid)}
+}
+
+func (_c *MockChatWatcher_UnwatchChat_Call) Run(run func(cid []byte)) *MockChatWatcher_UnwatchChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatWatcher_UnwatchChat_Call) Return() *MockChatWatcher_UnwatchChat_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChatWatcher_UnwatchChat_Call) RunAndReturn(run func([]byte)) *MockChatWatcher_UnwatchChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchChat provides a mock function with given fields: cid
+func (_m *MockChatWatcher) WatchChat(cid []byte) error {
+	ret := _m.Called(cid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchChat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(cid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChatWatcher_WatchChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchChat'
+type MockChatWatcher_WatchChat_Call struct {
+	*mock.Call
+}
+
+// WatchChat is a helper method to define mock.On call
+//   - cid []byte
+func (_e *MockChatWatcher_Expecter) WatchChat(cid interface{}) *MockChatWatcher_WatchChat_Call {
+	return &MockChatWatcher_WatchChat_Call{Call: _e.mock.On("WatchChat", cid)}
+}
+
+func (_c *MockChatWatcher_WatchChat_Call) Run(run func(cid []byte)) *MockChatWatcher_WatchChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChatWatcher_WatchChat_Call) Return(_a0 error) *MockChatWatcher_WatchChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChatWatcher_WatchChat_Call) RunAndReturn(run func([]byte) error) *MockChatWatcher_WatchChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChatWatcher creates a new instance of MockChatWatcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChatWatcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChatWatcher {
+	mock := &MockChatWatcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,152 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	frontend "github.com/dvid-messanger/internal/core/service/frontend"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockClientProvider is an autogenerated mock type for the ClientProvider type
+type MockClientProvider struct {
+	mock.Mock
+}
+
+type MockClientProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClientProvider) EXPECT() *MockClientProvider_Expecter {
+	return &MockClientProvider_Expecter{mock: &_m.Mock}
+}
+
+// Clients provides a mock function with given fields: cid
+func (_m *MockClientProvider) Clients(cid []byte) ([]frontend.Client, error) {
+	ret := _m.Called(cid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clients")
+	}
+
+	var r0 []frontend.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte) ([]frontend.Client, error)); ok {
+		return rf(cid)
+	}
+	if rf, ok := ret.Get(0).(func([]byte) []frontend.Client); ok {
+		r0 = rf(cid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]frontend.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(cid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClientProvider_Clients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clients'
+type MockClientProvider_Clients_Call struct {
+	*mock.Call
+}
+
+// Clients is a helper method to define mock.On call
+//   - cid []byte
+func (_e *MockClientProvider_Expecter) Clients(cid interface{}) *MockClientProvider_Clients_Call {
+	return &MockClientProvider_Clients_Call{Call: _e.mock.On("Clients", cid)}
+}
+
+func (_c *MockClientProvider_Clients_Call) Run(run func(cid []byte)) *MockClientProvider_Clients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClientProvider_Clients_Call) Return(_a0 []frontend.Client, _a1 error) *MockClientProvider_Clients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClientProvider_Clients_Call) RunAndReturn(run func([]byte) ([]frontend.Client, error)) *MockClientProvider_Clients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OtherClients provides a mock function with given fields: cid, uid
+func (_m *MockClientProvider) OtherClients(cid []byte, uid []byte) ([]frontend.Client, error) {
+	ret := _m.Called(cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OtherClients")
+	}
+
+	var r0 []frontend.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, []byte) ([]frontend.Client, error)); ok {
+		return rf(cid, uid)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, []byte) []frontend.Client); ok {
+		r0 = rf(cid, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]frontend.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
+		r1 = rf(cid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClientProvider_OtherClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OtherClients'
+type MockClientProvider_OtherClients_Call struct {
+	*mock.Call
+}
+
+// OtherClients is a helper method to define mock.On call
+//   - cid []byte
+//   - uid []byte
+func (_e *MockClientProvider_Expecter) OtherClients(cid interface{}, uid interface{}) *MockClientProvider_OtherClients_Call {
+	return &MockClientProvider_OtherClients_Call{Call: _e.mock.On("OtherClients", cid, uid)}
+}
+
+func (_c *MockClientProvider_OtherClients_Call) Run(run func(cid []byte, uid []byte)) *MockClientProvider_OtherClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClientProvider_OtherClients_Call) Return(_a0 []frontend.Client, _a1 error) *MockClientProvider_OtherClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClientProvider_OtherClients_Call) RunAndReturn(run func([]byte, []byte) ([]frontend.Client, error)) *MockClientProvider_OtherClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClientProvider creates a new instance of MockClientProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClientProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClientProvider {
+	mock := &MockClientProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,133 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEphemeralNotifier is an autogenerated mock type for the EphemeralNotifier type
+type MockEphemeralNotifier struct {
+	mock.Mock
+}
+
+type MockEphemeralNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEphemeralNotifier) EXPECT() *MockEphemeralNotifier_Expecter {
+	return &MockEphemeralNotifier_Expecter{mock: &_m.Mock}
+}
+
+// Presence provides a mock function with given fields: ctx, cid, uid, online
+func (_m *MockEphemeralNotifier) Presence(ctx context.Context, cid []byte, uid []byte, online bool) error {
+	ret := _m.Called(ctx, cid, uid, online)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Presence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, bool) error); ok {
+		r0 = rf(ctx, cid, uid, online)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEphemeralNotifier_Presence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Presence'
+type MockEphemeralNotifier_Presence_Call struct {
+	*mock.Call
+}
+
+// Presence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+//   - online bool
+func (_e *MockEphemeralNotifier_Expecter) Presence(ctx interface{}, cid interface{}, uid interface{}, online interface{}) *MockEphemeralNotifier_Presence_Call {
+	return &MockEphemeralNotifier_Presence_Call{Call: _e.mock.On("Presence", ctx, cid, uid, online)}
+}
+
+func (_c *MockEphemeralNotifier_Presence_Call) Run(run func(ctx context.Context, cid []byte, uid []byte, online bool)) *MockEphemeralNotifier_Presence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockEphemeralNotifier_Presence_Call) Return(_a0 error) *MockEphemeralNotifier_Presence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEphemeralNotifier_Presence_Call) RunAndReturn(run func(context.Context, []byte, []byte, bool) error) *MockEphemeralNotifier_Presence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Typing provides a mock function with given fields: ctx, cid, uid
+func (_m *MockEphemeralNotifier) Typing(ctx context.Context, cid []byte, uid []byte) error {
+	ret := _m.Called(ctx, cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Typing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) error); ok {
+		r0 = rf(ctx, cid, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEphemeralNotifier_Typing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Typing'
+type MockEphemeralNotifier_Typing_Call struct {
+	*mock.Call
+}
+
+// Typing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+func (_e *MockEphemeralNotifier_Expecter) Typing(ctx interface{}, cid interface{}, uid interface{}) *MockEphemeralNotifier_Typing_Call {
+	return &MockEphemeralNotifier_Typing_Call{Call: _e.mock.On("Typing", ctx, cid, uid)}
+}
+
+func (_c *MockEphemeralNotifier_Typing_Call) Run(run func(ctx context.Context, cid []byte, uid []byte)) *MockEphemeralNotifier_Typing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockEphemeralNotifier_Typing_Call) Return(_a0 error) *MockEphemeralNotifier_Typing_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEphemeralNotifier_Typing_Call) RunAndReturn(run func(context.Context, []byte, []byte) error) *MockEphemeralNotifier_Typing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEphemeralNotifier creates a new instance of MockEphemeralNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEphemeralNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEphemeralNotifier {
+	mock := &MockEphemeralNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
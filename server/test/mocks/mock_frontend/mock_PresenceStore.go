@@ -0,0 +1,150 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPresenceStore is an autogenerated mock type for the PresenceStore type
+type MockPresenceStore struct {
+	mock.Mock
+}
+
+type MockPresenceStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPresenceStore) EXPECT() *MockPresenceStore_Expecter {
+	return &MockPresenceStore_Expecter{mock: &_m.Mock}
+}
+
+// Connect provides a mock function with given fields: ctx, uid
+func (_m *MockPresenceStore) Connect(ctx context.Context, uid []byte) (bool, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Connect")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (bool, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) bool); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPresenceStore_Connect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Connect'
+type MockPresenceStore_Connect_Call struct {
+	*mock.Call
+}
+
+// Connect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockPresenceStore_Expecter) Connect(ctx interface{}, uid interface{}) *MockPresenceStore_Connect_Call {
+	return &MockPresenceStore_Connect_Call{Call: _e.mock.On("Connect", ctx, uid)}
+}
+
+func (_c *MockPresenceStore_Connect_Call) Run(run func(ctx context.Context, uid []byte)) *MockPresenceStore_Connect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockPresenceStore_Connect_Call) Return(_a0 bool, _a1 error) *MockPresenceStore_Connect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPresenceStore_Connect_Call) RunAndReturn(run func(context.Context, []byte) (bool, error)) *MockPresenceStore_Connect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Disconnect provides a mock function with given fields: ctx, uid
+func (_m *MockPresenceStore) Disconnect(ctx context.Context, uid []byte) (bool, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disconnect")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (bool, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) bool); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPresenceStore_Disconnect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disconnect'
+type MockPresenceStore_Disconnect_Call struct {
+	*mock.Call
+}
+
+// Disconnect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockPresenceStore_Expecter) Disconnect(ctx interface{}, uid interface{}) *MockPresenceStore_Disconnect_Call {
+	return &MockPresenceStore_Disconnect_Call{Call: _e.mock.On("Disconnect", ctx, uid)}
+}
+
+func (_c *MockPresenceStore_Disconnect_Call) Run(run func(ctx context.Context, uid []byte)) *MockPresenceStore_Disconnect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockPresenceStore_Disconnect_Call) Return(_a0 bool, _a1 error) *MockPresenceStore_Disconnect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPresenceStore_Disconnect_Call) RunAndReturn(run func(context.Context, []byte) (bool, error)) *MockPresenceStore_Disconnect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPresenceStore creates a new instance of MockPresenceStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPresenceStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPresenceStore {
+	mock := &MockPresenceStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
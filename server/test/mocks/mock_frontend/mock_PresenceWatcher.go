@@ -0,0 +1,67 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockPresenceWatcher is an autogenerated mock type for the PresenceWatcher type
+type MockPresenceWatcher struct {
+	mock.Mock
+}
+
+type MockPresenceWatcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPresenceWatcher) EXPECT() *MockPresenceWatcher_Expecter {
+	return &MockPresenceWatcher_Expecter{mock: &_m.Mock}
+}
+
+// PresenceChanged provides a mock function with given fields: uid, cids, online
+func (_m *MockPresenceWatcher) PresenceChanged(uid []byte, cids [][]byte, online bool) {
+	_m.Called(uid, cids, online)
+}
+
+// MockPresenceWatcher_PresenceChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PresenceChanged'
+type MockPresenceWatcher_PresenceChanged_Call struct {
+	*mock.Call
+}
+
+// PresenceChanged is a helper method to define mock.On call
+//   - uid []byte
+//   - cids [][]byte
+//   - online bool
+func (_e *MockPresenceWatcher_Expecter) PresenceChanged(uid interface{}, cids interface{}, online interface{}) *MockPresenceWatcher_PresenceChanged_Call {
+	return &MockPresenceWatcher_PresenceChanged_Call{Call: _e.mock.On("PresenceChanged", uid, cids, online)}
+}
+
+func (_c *MockPresenceWatcher_PresenceChanged_Call) Run(run func(uid []byte, cids [][]byte, online bool)) *MockPresenceWatcher_PresenceChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].([][]byte), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockPresenceWatcher_PresenceChanged_Call) Return() *MockPresenceWatcher_PresenceChanged_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPresenceWatcher_PresenceChanged_Call) RunAndReturn(run func([]byte, [][]byte, bool)) *MockPresenceWatcher_PresenceChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPresenceWatcher creates a new instance of MockPresenceWatcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPresenceWatcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPresenceWatcher {
+	mock := &MockPresenceWatcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+)
+
+// MockProfileNotifier is an autogenerated mock type for the ProfileNotifier type
+type MockProfileNotifier struct {
+	mock.Mock
+}
+
+type MockProfileNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileNotifier) EXPECT() *MockProfileNotifier_Expecter {
+	return &MockProfileNotifier_Expecter{mock: &_m.Mock}
+}
+
+// ProfileUpdated provides a mock function with given fields: ctx, cids, user
+func (_m *MockProfileNotifier) ProfileUpdated(ctx context.Context, cids [][]byte, user *model.User) error {
+	ret := _m.Called(ctx, cids, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProfileUpdated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte, *model.User) error); ok {
+		r0 = rf(ctx, cids, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProfileNotifier_ProfileUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProfileUpdated'
+type MockProfileNotifier_ProfileUpdated_Call struct {
+	*mock.Call
+}
+
+// ProfileUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cids [][]byte
+//   - user *model.User
+func (_e *MockProfileNotifier_Expecter) ProfileUpdated(ctx interface{}, cids interface{}, user interface{}) *MockProfileNotifier_ProfileUpdated_Call {
+	return &MockProfileNotifier_ProfileUpdated_Call{Call: _e.mock.On("ProfileUpdated", ctx, cids, user)}
+}
+
+func (_c *MockProfileNotifier_ProfileUpdated_Call) Run(run func(ctx context.Context, cids [][]byte, user *model.User)) *MockProfileNotifier_ProfileUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([][]byte), args[2].(*model.User))
+	})
+	return _c
+}
+
+func (_c *MockProfileNotifier_ProfileUpdated_Call) Return(_a0 error) *MockProfileNotifier_ProfileUpdated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProfileNotifier_ProfileUpdated_Call) RunAndReturn(run func(context.Context, [][]byte, *model.User) error) *MockProfileNotifier_ProfileUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProfileNotifier creates a new instance of MockProfileNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileNotifier {
+	mock := &MockProfileNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+)
+
+// MockProfileUpdater is an autogenerated mock type for the ProfileUpdater type
+type MockProfileUpdater struct {
+	mock.Mock
+}
+
+type MockProfileUpdater_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfileUpdater) EXPECT() *MockProfileUpdater_Expecter {
+	return &MockProfileUpdater_Expecter{mock: &_m.Mock}
+}
+
+// Update provides a mock function with given fields: ctx, uid, upd
+func (_m *MockProfileUpdater) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error) {
+	ret := _m.Called(ctx, uid, upd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) (*model.User, error)); ok {
+		return rf(ctx, uid, upd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) *model.User); ok {
+		r0 = rf(ctx, uid, upd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, model.ProfileUpdate) error); ok {
+		r1 = rf(ctx, uid, upd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileUpdater_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockProfileUpdater_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - upd model.ProfileUpdate
+func (_e *MockProfileUpdater_Expecter) Update(ctx interface{}, uid interface{}, upd interface{}) *MockProfileUpdater_Update_Call {
+	return &MockProfileUpdater_Update_Call{Call: _e.mock.On("Update", ctx, uid, upd)}
+}
+
+func (_c *MockProfileUpdater_Update_Call) Run(run func(ctx context.Context, uid []byte, upd model.ProfileUpdate)) *MockProfileUpdater_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(model.ProfileUpdate))
+	})
+	return _c
+}
+
+func (_c *MockProfileUpdater_Update_Call) Return(_a0 *model.User, _a1 error) *MockProfileUpdater_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProfileUpdater_Update_Call) RunAndReturn(run func(context.Context, []byte, model.ProfileUpdate) (*model.User, error)) *MockProfileUpdater_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProfileUpdater creates a new instance of MockProfileUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfileUpdater(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfileUpdater {
+	mock := &MockProfileUpdater{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_frontend
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+)
+
+// MockUserAccounts is an autogenerated mock type for the UserAccounts type
+type MockUserAccounts struct {
+	mock.Mock
+}
+
+type MockUserAccounts_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserAccounts) EXPECT() *MockUserAccounts_Expecter {
+	return &MockUserAccounts_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, email, bio
+func (_m *MockUserAccounts) Create(ctx context.Context, email string, bio string) (*model.User, error) {
+	ret := _m.Called(ctx, email, bio)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.User, error)); ok {
+		return rf(ctx, email, bio)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = rf(ctx, email, bio)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, bio)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserAccounts_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserAccounts_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - bio string
+func (_e *MockUserAccounts_Expecter) Create(ctx interface{}, email interface{}, bio interface{}) *MockUserAccounts_Create_Call {
+	return &MockUserAccounts_Create_Call{Call: _e.mock.On("Create", ctx, email, bio)}
+}
+
+func (_c *MockUserAccounts_Create_Call) Run(run func(ctx context.Context, email string, bio string)) *MockUserAccounts_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserAccounts_Create_Call) Return(_a0 *model.User, _a1 error) *MockUserAccounts_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserAccounts_Create_Call) RunAndReturn(run func(context.Context, string, string) (*model.User, error)) *MockUserAccounts_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, uid
+func (_m *MockUserAccounts) Delete(ctx context.Context, uid []byte) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserAccounts_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserAccounts_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockUserAccounts_Expecter) Delete(ctx interface{}, uid interface{}) *MockUserAccounts_Delete_Call {
+	return &MockUserAccounts_Delete_Call{Call: _e.mock.On("Delete", ctx, uid)}
+}
+
+func (_c *MockUserAccounts_Delete_Call) Run(run func(ctx context.Context, uid []byte)) *MockUserAccounts_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserAccounts_Delete_Call) Return(_a0 error) *MockUserAccounts_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserAccounts_Delete_Call) RunAndReturn(run func(context.Context, []byte) error) *MockUserAccounts_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserAccounts creates a new instance of MockUserAccounts. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserAccounts(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserAccounts {
+	mock := &MockUserAccounts{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
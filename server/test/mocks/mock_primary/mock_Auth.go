@@ -0,0 +1,427 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuth is an autogenerated mock type for the Auth type
+type MockAuth struct {
+	mock.Mock
+}
+
+type MockAuth_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuth) EXPECT() *MockAuth_Expecter {
+	return &MockAuth_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, uid, email, pass
+func (_m *MockAuth) Create(ctx context.Context, uid []byte, email string, pass string) ([]byte, error) {
+	ret := _m.Called(ctx, uid, email, pass)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, string) ([]byte, error)); ok {
+		return rf(ctx, uid, email, pass)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, string) []byte); ok {
+		r0 = rf(ctx, uid, email, pass)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string, string) error); ok {
+		r1 = rf(ctx, uid, email, pass)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuth_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAuth_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - email string
+//   - pass string
+func (_e *MockAuth_Expecter) Create(ctx interface{}, uid interface{}, email interface{}, pass interface{}) *MockAuth_Create_Call {
+	return &MockAuth_Create_Call{Call: _e.mock.On("Create", ctx, uid, email, pass)}
+}
+
+func (_c *MockAuth_Create_Call) Run(run func(ctx context.Context, uid []byte, email string, pass string)) *MockAuth_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuth_Create_Call) Return(_a0 []byte, _a1 error) *MockAuth_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuth_Create_Call) RunAndReturn(run func(context.Context, []byte, string, string) ([]byte, error)) *MockAuth_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, uid
+func (_m *MockAuth) Delete(ctx context.Context, uid []byte) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuth_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockAuth_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockAuth_Expecter) Delete(ctx interface{}, uid interface{}) *MockAuth_Delete_Call {
+	return &MockAuth_Delete_Call{Call: _e.mock.On("Delete", ctx, uid)}
+}
+
+func (_c *MockAuth_Delete_Call) Run(run func(ctx context.Context, uid []byte)) *MockAuth_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockAuth_Delete_Call) Return(_a0 error) *MockAuth_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuth_Delete_Call) RunAndReturn(run func(context.Context, []byte) error) *MockAuth_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, email, pass
+func (_m *MockAuth) Login(ctx context.Context, email string, pass string) (*model.TokenPair, error) {
+	ret := _m.Called(ctx, email, pass)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *model.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.TokenPair, error)); ok {
+		return rf(ctx, email, pass)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.TokenPair); ok {
+		r0 = rf(ctx, email, pass)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, pass)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuth_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockAuth_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - pass string
+func (_e *MockAuth_Expecter) Login(ctx interface{}, email interface{}, pass interface{}) *MockAuth_Login_Call {
+	return &MockAuth_Login_Call{Call: _e.mock.On("Login", ctx, email, pass)}
+}
+
+func (_c *MockAuth_Login_Call) Run(run func(ctx context.Context, email string, pass string)) *MockAuth_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuth_Login_Call) Return(_a0 *model.TokenPair, _a1 error) *MockAuth_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuth_Login_Call) RunAndReturn(run func(context.Context, string, string) (*model.TokenPair, error)) *MockAuth_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function with given fields: ctx, accessToken, refreshToken
+func (_m *MockAuth) Logout(ctx context.Context, accessToken string, refreshToken string) error {
+	ret := _m.Called(ctx, accessToken, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuth_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuth_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accessToken string
+//   - refreshToken string
+func (_e *MockAuth_Expecter) Logout(ctx interface{}, accessToken interface{}, refreshToken interface{}) *MockAuth_Logout_Call {
+	return &MockAuth_Logout_Call{Call: _e.mock.On("Logout", ctx, accessToken, refreshToken)}
+}
+
+func (_c *MockAuth_Logout_Call) Run(run func(ctx context.Context, accessToken string, refreshToken string)) *MockAuth_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuth_Logout_Call) Return(_a0 error) *MockAuth_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuth_Logout_Call) RunAndReturn(run func(context.Context, string, string) error) *MockAuth_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublicKeys provides a mock function with given fields: ctx
+func (_m *MockAuth) PublicKeys(ctx context.Context) ([]model.PublicKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKeys")
+	}
+
+	var r0 []model.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.PublicKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.PublicKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuth_PublicKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeys'
+type MockAuth_PublicKeys_Call struct {
+	*mock.Call
+}
+
+// PublicKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuth_Expecter) PublicKeys(ctx interface{}) *MockAuth_PublicKeys_Call {
+	return &MockAuth_PublicKeys_Call{Call: _e.mock.On("PublicKeys", ctx)}
+}
+
+func (_c *MockAuth_PublicKeys_Call) Run(run func(ctx context.Context)) *MockAuth_PublicKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAuth_PublicKeys_Call) Return(_a0 []model.PublicKey, _a1 error) *MockAuth_PublicKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuth_PublicKeys_Call) RunAndReturn(run func(context.Context) ([]model.PublicKey, error)) *MockAuth_PublicKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *MockAuth) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *model.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuth_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockAuth_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockAuth_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *MockAuth_Refresh_Call {
+	return &MockAuth_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *MockAuth_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *MockAuth_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuth_Refresh_Call) Return(_a0 *model.TokenPair, _a1 error) *MockAuth_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuth_Refresh_Call) RunAndReturn(run func(context.Context, string) (*model.TokenPair, error)) *MockAuth_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoked provides a mock function with given fields: ctx, jti
+func (_m *MockAuth) Revoked(ctx context.Context, jti string) (bool, error) {
+	ret := _m.Called(ctx, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, jti)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, jti)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuth_Revoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoked'
+type MockAuth_Revoked_Call struct {
+	*mock.Call
+}
+
+// Revoked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jti string
+func (_e *MockAuth_Expecter) Revoked(ctx interface{}, jti interface{}) *MockAuth_Revoked_Call {
+	return &MockAuth_Revoked_Call{Call: _e.mock.On("Revoked", ctx, jti)}
+}
+
+func (_c *MockAuth_Revoked_Call) Run(run func(ctx context.Context, jti string)) *MockAuth_Revoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuth_Revoked_Call) Return(_a0 bool, _a1 error) *MockAuth_Revoked_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuth_Revoked_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockAuth_Revoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuth creates a new instance of MockAuth. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuth(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuth {
+	mock := &MockAuth{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
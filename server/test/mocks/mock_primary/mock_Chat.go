@@ -0,0 +1,1073 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockChat is an autogenerated mock type for the Chat type
+type MockChat struct {
+	mock.Mock
+}
+
+type MockChat_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChat) EXPECT() *MockChat_Expecter {
+	return &MockChat_Expecter{mock: &_m.Mock}
+}
+
+// AddMember provides a mock function with given fields: ctx, cid, uid, memberUid
+func (_m *MockChat) AddMember(ctx context.Context, cid []byte, uid []byte, memberUid []byte) (*model.Chat, error) {
+	ret := _m.Called(ctx, cid, uid, memberUid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 *model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) (*model.Chat, error)); ok {
+		return rf(ctx, cid, uid, memberUid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) *model.Chat); ok {
+		r0 = rf(ctx, cid, uid, memberUid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, uid, memberUid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type MockChat_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+//   - memberUid []byte
+func (_e *MockChat_Expecter) AddMember(ctx interface{}, cid interface{}, uid interface{}, memberUid interface{}) *MockChat_AddMember_Call {
+	return &MockChat_AddMember_Call{Call: _e.mock.On("AddMember", ctx, cid, uid, memberUid)}
+}
+
+func (_c *MockChat_AddMember_Call) Run(run func(ctx context.Context, cid []byte, uid []byte, memberUid []byte)) *MockChat_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_AddMember_Call) Return(_a0 *model.Chat, _a1 error) *MockChat_AddMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_AddMember_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte) (*model.Chat, error)) *MockChat_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddReaction provides a mock function with given fields: ctx, cid, mid, uid, emoji
+func (_m *MockChat) AddReaction(ctx context.Context, cid []byte, mid []byte, uid []byte, emoji string) (*model.ReactionUpdate, error) {
+	ret := _m.Called(ctx, cid, mid, uid, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 *model.ReactionUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, string) (*model.ReactionUpdate, error)); ok {
+		return rf(ctx, cid, mid, uid, emoji)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, string) *model.ReactionUpdate); ok {
+		r0 = rf(ctx, cid, mid, uid, emoji)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReactionUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte, string) error); ok {
+		r1 = rf(ctx, cid, mid, uid, emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type MockChat_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+//   - uid []byte
+//   - emoji string
+func (_e *MockChat_Expecter) AddReaction(ctx interface{}, cid interface{}, mid interface{}, uid interface{}, emoji interface{}) *MockChat_AddReaction_Call {
+	return &MockChat_AddReaction_Call{Call: _e.mock.On("AddReaction", ctx, cid, mid, uid, emoji)}
+}
+
+func (_c *MockChat_AddReaction_Call) Run(run func(ctx context.Context, cid []byte, mid []byte, uid []byte, emoji string)) *MockChat_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockChat_AddReaction_Call) Return(_a0 *model.ReactionUpdate, _a1 error) *MockChat_AddReaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_AddReaction_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte, string) (*model.ReactionUpdate, error)) *MockChat_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Chat provides a mock function with given fields: ctx, cid, uid
+func (_m *MockChat) Chat(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error) {
+	ret := _m.Called(ctx, cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Chat")
+	}
+
+	var r0 *model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (*model.Chat, error)); ok {
+		return rf(ctx, cid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) *model.Chat); ok {
+		r0 = rf(ctx, cid, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_Chat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Chat'
+type MockChat_Chat_Call struct {
+	*mock.Call
+}
+
+// Chat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+func (_e *MockChat_Expecter) Chat(ctx interface{}, cid interface{}, uid interface{}) *MockChat_Chat_Call {
+	return &MockChat_Chat_Call{Call: _e.mock.On("Chat", ctx, cid, uid)}
+}
+
+func (_c *MockChat_Chat_Call) Run(run func(ctx context.Context, cid []byte, uid []byte)) *MockChat_Chat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_Chat_Call) Return(_a0 *model.Chat, _a1 error) *MockChat_Chat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_Chat_Call) RunAndReturn(run func(context.Context, []byte, []byte) (*model.Chat, error)) *MockChat_Chat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChatStates provides a mock function with given fields: ctx, uid
+func (_m *MockChat) ChatStates(ctx context.Context, uid []byte) ([]model.ChatState, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChatStates")
+	}
+
+	var r0 []model.ChatState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) ([]model.ChatState, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) []model.ChatState); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ChatState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_ChatStates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChatStates'
+type MockChat_ChatStates_Call struct {
+	*mock.Call
+}
+
+// ChatStates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockChat_Expecter) ChatStates(ctx interface{}, uid interface{}) *MockChat_ChatStates_Call {
+	return &MockChat_ChatStates_Call{Call: _e.mock.On("ChatStates", ctx, uid)}
+}
+
+func (_c *MockChat_ChatStates_Call) Run(run func(ctx context.Context, uid []byte)) *MockChat_ChatStates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_ChatStates_Call) Return(_a0 []model.ChatState, _a1 error) *MockChat_ChatStates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_ChatStates_Call) RunAndReturn(run func(context.Context, []byte) ([]model.ChatState, error)) *MockChat_ChatStates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, fromUid, toUid
+func (_m *MockChat) Create(ctx context.Context, fromUid []byte, toUid []byte) (*model.Chat, error) {
+	ret := _m.Called(ctx, fromUid, toUid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (*model.Chat, error)); ok {
+		return rf(ctx, fromUid, toUid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) *model.Chat); ok {
+		r0 = rf(ctx, fromUid, toUid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, fromUid, toUid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockChat_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromUid []byte
+//   - toUid []byte
+func (_e *MockChat_Expecter) Create(ctx interface{}, fromUid interface{}, toUid interface{}) *MockChat_Create_Call {
+	return &MockChat_Create_Call{Call: _e.mock.On("Create", ctx, fromUid, toUid)}
+}
+
+func (_c *MockChat_Create_Call) Run(run func(ctx context.Context, fromUid []byte, toUid []byte)) *MockChat_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_Create_Call) Return(_a0 *model.Chat, _a1 error) *MockChat_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_Create_Call) RunAndReturn(run func(context.Context, []byte, []byte) (*model.Chat, error)) *MockChat_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGroup provides a mock function with given fields: ctx, ownerUid, title, memberUids
+func (_m *MockChat) CreateGroup(ctx context.Context, ownerUid []byte, title string, memberUids [][]byte) (*model.Chat, error) {
+	ret := _m.Called(ctx, ownerUid, title, memberUids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 *model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, [][]byte) (*model.Chat, error)); ok {
+		return rf(ctx, ownerUid, title, memberUids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string, [][]byte) *model.Chat); ok {
+		r0 = rf(ctx, ownerUid, title, memberUids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string, [][]byte) error); ok {
+		r1 = rf(ctx, ownerUid, title, memberUids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type MockChat_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerUid []byte
+//   - title string
+//   - memberUids [][]byte
+func (_e *MockChat_Expecter) CreateGroup(ctx interface{}, ownerUid interface{}, title interface{}, memberUids interface{}) *MockChat_CreateGroup_Call {
+	return &MockChat_CreateGroup_Call{Call: _e.mock.On("CreateGroup", ctx, ownerUid, title, memberUids)}
+}
+
+func (_c *MockChat_CreateGroup_Call) Run(run func(ctx context.Context, ownerUid []byte, title string, memberUids [][]byte)) *MockChat_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(string), args[3].([][]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_CreateGroup_Call) Return(_a0 *model.Chat, _a1 error) *MockChat_CreateGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_CreateGroup_Call) RunAndReturn(run func(context.Context, []byte, string, [][]byte) (*model.Chat, error)) *MockChat_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMessage provides a mock function with given fields: ctx, cid, mid, uid
+func (_m *MockChat) DeleteMessage(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, mid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 *model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) (*model.ChatMessage, error)); ok {
+		return rf(ctx, cid, mid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) *model.ChatMessage); ok {
+		r0 = rf(ctx, cid, mid, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, mid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_DeleteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessage'
+type MockChat_DeleteMessage_Call struct {
+	*mock.Call
+}
+
+// DeleteMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+//   - uid []byte
+func (_e *MockChat_Expecter) DeleteMessage(ctx interface{}, cid interface{}, mid interface{}, uid interface{}) *MockChat_DeleteMessage_Call {
+	return &MockChat_DeleteMessage_Call{Call: _e.mock.On("DeleteMessage", ctx, cid, mid, uid)}
+}
+
+func (_c *MockChat_DeleteMessage_Call) Run(run func(ctx context.Context, cid []byte, mid []byte, uid []byte)) *MockChat_DeleteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_DeleteMessage_Call) Return(_a0 *model.ChatMessage, _a1 error) *MockChat_DeleteMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_DeleteMessage_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte) (*model.ChatMessage, error)) *MockChat_DeleteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditMessage provides a mock function with given fields: ctx, cid, mid, uid, text
+func (_m *MockChat) EditMessage(ctx context.Context, cid []byte, mid []byte, uid []byte, text string) (*model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, mid, uid, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessage")
+	}
+
+	var r0 *model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, string) (*model.ChatMessage, error)); ok {
+		return rf(ctx, cid, mid, uid, text)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, string) *model.ChatMessage); ok {
+		r0 = rf(ctx, cid, mid, uid, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte, string) error); ok {
+		r1 = rf(ctx, cid, mid, uid, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_EditMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditMessage'
+type MockChat_EditMessage_Call struct {
+	*mock.Call
+}
+
+// EditMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+//   - uid []byte
+//   - text string
+func (_e *MockChat_Expecter) EditMessage(ctx interface{}, cid interface{}, mid interface{}, uid interface{}, text interface{}) *MockChat_EditMessage_Call {
+	return &MockChat_EditMessage_Call{Call: _e.mock.On("EditMessage", ctx, cid, mid, uid, text)}
+}
+
+func (_c *MockChat_EditMessage_Call) Run(run func(ctx context.Context, cid []byte, mid []byte, uid []byte, text string)) *MockChat_EditMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockChat_EditMessage_Call) Return(_a0 *model.ChatMessage, _a1 error) *MockChat_EditMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_EditMessage_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte, string) (*model.ChatMessage, error)) *MockChat_EditMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastMessages provides a mock function with given fields: ctx, uid
+func (_m *MockChat) LastMessages(ctx context.Context, uid []byte) ([]model.ChatMessage, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastMessages")
+	}
+
+	var r0 []model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) ([]model.ChatMessage, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) []model.ChatMessage); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_LastMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastMessages'
+type MockChat_LastMessages_Call struct {
+	*mock.Call
+}
+
+// LastMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockChat_Expecter) LastMessages(ctx interface{}, uid interface{}) *MockChat_LastMessages_Call {
+	return &MockChat_LastMessages_Call{Call: _e.mock.On("LastMessages", ctx, uid)}
+}
+
+func (_c *MockChat_LastMessages_Call) Run(run func(ctx context.Context, uid []byte)) *MockChat_LastMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_LastMessages_Call) Return(_a0 []model.ChatMessage, _a1 error) *MockChat_LastMessages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_LastMessages_Call) RunAndReturn(run func(context.Context, []byte) ([]model.ChatMessage, error)) *MockChat_LastMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Leave provides a mock function with given fields: ctx, cid, uid
+func (_m *MockChat) Leave(ctx context.Context, cid []byte, uid []byte) (*model.Chat, error) {
+	ret := _m.Called(ctx, cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Leave")
+	}
+
+	var r0 *model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) (*model.Chat, error)); ok {
+		return rf(ctx, cid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) *model.Chat); ok {
+		r0 = rf(ctx, cid, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_Leave_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Leave'
+type MockChat_Leave_Call struct {
+	*mock.Call
+}
+
+// Leave is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+func (_e *MockChat_Expecter) Leave(ctx interface{}, cid interface{}, uid interface{}) *MockChat_Leave_Call {
+	return &MockChat_Leave_Call{Call: _e.mock.On("Leave", ctx, cid, uid)}
+}
+
+func (_c *MockChat_Leave_Call) Run(run func(ctx context.Context, cid []byte, uid []byte)) *MockChat_Leave_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_Leave_Call) Return(_a0 *model.Chat, _a1 error) *MockChat_Leave_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_Leave_Call) RunAndReturn(run func(context.Context, []byte, []byte) (*model.Chat, error)) *MockChat_Leave_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function with given fields: ctx, cid, mid, uid
+func (_m *MockChat) MarkRead(ctx context.Context, cid []byte, mid []byte, uid []byte) (*model.ReadReceipt, error) {
+	ret := _m.Called(ctx, cid, mid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 *model.ReadReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) (*model.ReadReceipt, error)); ok {
+		return rf(ctx, cid, mid, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) *model.ReadReceipt); ok {
+		r0 = rf(ctx, cid, mid, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReadReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, mid, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type MockChat_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+//   - uid []byte
+func (_e *MockChat_Expecter) MarkRead(ctx interface{}, cid interface{}, mid interface{}, uid interface{}) *MockChat_MarkRead_Call {
+	return &MockChat_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, cid, mid, uid)}
+}
+
+func (_c *MockChat_MarkRead_Call) Run(run func(ctx context.Context, cid []byte, mid []byte, uid []byte)) *MockChat_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_MarkRead_Call) Return(_a0 *model.ReadReceipt, _a1 error) *MockChat_MarkRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_MarkRead_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte) (*model.ReadReceipt, error)) *MockChat_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Messages provides a mock function with given fields: ctx, cid, uid, before, after, limit
+func (_m *MockChat) Messages(ctx context.Context, cid []byte, uid []byte, before []byte, after []byte, limit int) (*model.MessagesPage, error) {
+	ret := _m.Called(ctx, cid, uid, before, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Messages")
+	}
+
+	var r0 *model.MessagesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, []byte, int) (*model.MessagesPage, error)); ok {
+		return rf(ctx, cid, uid, before, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, []byte, int) *model.MessagesPage); ok {
+		r0 = rf(ctx, cid, uid, before, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MessagesPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte, []byte, int) error); ok {
+		r1 = rf(ctx, cid, uid, before, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_Messages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Messages'
+type MockChat_Messages_Call struct {
+	*mock.Call
+}
+
+// Messages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+//   - before []byte
+//   - after []byte
+//   - limit int
+func (_e *MockChat_Expecter) Messages(ctx interface{}, cid interface{}, uid interface{}, before interface{}, after interface{}, limit interface{}) *MockChat_Messages_Call {
+	return &MockChat_Messages_Call{Call: _e.mock.On("Messages", ctx, cid, uid, before, after, limit)}
+}
+
+func (_c *MockChat_Messages_Call) Run(run func(ctx context.Context, cid []byte, uid []byte, before []byte, after []byte, limit int)) *MockChat_Messages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte), args[4].([]byte), args[5].(int))
+	})
+	return _c
+}
+
+func (_c *MockChat_Messages_Call) Return(_a0 *model.MessagesPage, _a1 error) *MockChat_Messages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_Messages_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte, []byte, int) (*model.MessagesPage, error)) *MockChat_Messages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, cid, uid, memberUid
+func (_m *MockChat) RemoveMember(ctx context.Context, cid []byte, uid []byte, memberUid []byte) (*model.Chat, error) {
+	ret := _m.Called(ctx, cid, uid, memberUid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 *model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) (*model.Chat, error)); ok {
+		return rf(ctx, cid, uid, memberUid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) *model.Chat); ok {
+		r0 = rf(ctx, cid, uid, memberUid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r1 = rf(ctx, cid, uid, memberUid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type MockChat_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+//   - memberUid []byte
+func (_e *MockChat_Expecter) RemoveMember(ctx interface{}, cid interface{}, uid interface{}, memberUid interface{}) *MockChat_RemoveMember_Call {
+	return &MockChat_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, cid, uid, memberUid)}
+}
+
+func (_c *MockChat_RemoveMember_Call) Run(run func(ctx context.Context, cid []byte, uid []byte, memberUid []byte)) *MockChat_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_RemoveMember_Call) Return(_a0 *model.Chat, _a1 error) *MockChat_RemoveMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_RemoveMember_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte) (*model.Chat, error)) *MockChat_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReaction provides a mock function with given fields: ctx, cid, mid, uid, emoji
+func (_m *MockChat) RemoveReaction(ctx context.Context, cid []byte, mid []byte, uid []byte, emoji string) (*model.ReactionUpdate, error) {
+	ret := _m.Called(ctx, cid, mid, uid, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 *model.ReactionUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, string) (*model.ReactionUpdate, error)); ok {
+		return rf(ctx, cid, mid, uid, emoji)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte, string) *model.ReactionUpdate); ok {
+		r0 = rf(ctx, cid, mid, uid, emoji)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ReactionUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte, string) error); ok {
+		r1 = rf(ctx, cid, mid, uid, emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_RemoveReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReaction'
+type MockChat_RemoveReaction_Call struct {
+	*mock.Call
+}
+
+// RemoveReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - mid []byte
+//   - uid []byte
+//   - emoji string
+func (_e *MockChat_Expecter) RemoveReaction(ctx interface{}, cid interface{}, mid interface{}, uid interface{}, emoji interface{}) *MockChat_RemoveReaction_Call {
+	return &MockChat_RemoveReaction_Call{Call: _e.mock.On("RemoveReaction", ctx, cid, mid, uid, emoji)}
+}
+
+func (_c *MockChat_RemoveReaction_Call) Run(run func(ctx context.Context, cid []byte, mid []byte, uid []byte, emoji string)) *MockChat_RemoveReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockChat_RemoveReaction_Call) Return(_a0 *model.ReactionUpdate, _a1 error) *MockChat_RemoveReaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_RemoveReaction_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte, string) (*model.ReactionUpdate, error)) *MockChat_RemoveReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchMessages provides a mock function with given fields: ctx, uid, cid, query, after, limit
+func (_m *MockChat) SearchMessages(ctx context.Context, uid []byte, cid []byte, query string, after []byte, limit int) (*model.MessageHitsPage, error) {
+	ret := _m.Called(ctx, uid, cid, query, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchMessages")
+	}
+
+	var r0 *model.MessageHitsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string, []byte, int) (*model.MessageHitsPage, error)); ok {
+		return rf(ctx, uid, cid, query, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string, []byte, int) *model.MessageHitsPage); ok {
+		r0 = rf(ctx, uid, cid, query, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MessageHitsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, string, []byte, int) error); ok {
+		r1 = rf(ctx, uid, cid, query, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_SearchMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchMessages'
+type MockChat_SearchMessages_Call struct {
+	*mock.Call
+}
+
+// SearchMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - cid []byte
+//   - query string
+//   - after []byte
+//   - limit int
+func (_e *MockChat_Expecter) SearchMessages(ctx interface{}, uid interface{}, cid interface{}, query interface{}, after interface{}, limit interface{}) *MockChat_SearchMessages_Call {
+	return &MockChat_SearchMessages_Call{Call: _e.mock.On("SearchMessages", ctx, uid, cid, query, after, limit)}
+}
+
+func (_c *MockChat_SearchMessages_Call) Run(run func(ctx context.Context, uid []byte, cid []byte, query string, after []byte, limit int)) *MockChat_SearchMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(string), args[4].([]byte), args[5].(int))
+	})
+	return _c
+}
+
+func (_c *MockChat_SearchMessages_Call) Return(_a0 *model.MessageHitsPage, _a1 error) *MockChat_SearchMessages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_SearchMessages_Call) RunAndReturn(run func(context.Context, []byte, []byte, string, []byte, int) (*model.MessageHitsPage, error)) *MockChat_SearchMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMessage provides a mock function with given fields: ctx, cid, uid, text, refs
+func (_m *MockChat) SendMessage(ctx context.Context, cid []byte, uid []byte, text string, refs model.MessageRefs) (*model.ChatMessage, error) {
+	ret := _m.Called(ctx, cid, uid, text, refs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 *model.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string, model.MessageRefs) (*model.ChatMessage, error)); ok {
+		return rf(ctx, cid, uid, text, refs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, string, model.MessageRefs) *model.ChatMessage); ok {
+		r0 = rf(ctx, cid, uid, text, refs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, []byte, string, model.MessageRefs) error); ok {
+		r1 = rf(ctx, cid, uid, text, refs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_SendMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMessage'
+type MockChat_SendMessage_Call struct {
+	*mock.Call
+}
+
+// SendMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+//   - text string
+//   - refs model.MessageRefs
+func (_e *MockChat_Expecter) SendMessage(ctx interface{}, cid interface{}, uid interface{}, text interface{}, refs interface{}) *MockChat_SendMessage_Call {
+	return &MockChat_SendMessage_Call{Call: _e.mock.On("SendMessage", ctx, cid, uid, text, refs)}
+}
+
+func (_c *MockChat_SendMessage_Call) Run(run func(ctx context.Context, cid []byte, uid []byte, text string, refs model.MessageRefs)) *MockChat_SendMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(string), args[4].(model.MessageRefs))
+	})
+	return _c
+}
+
+func (_c *MockChat_SendMessage_Call) Return(_a0 *model.ChatMessage, _a1 error) *MockChat_SendMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_SendMessage_Call) RunAndReturn(run func(context.Context, []byte, []byte, string, model.MessageRefs) (*model.ChatMessage, error)) *MockChat_SendMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserChats provides a mock function with given fields: ctx, uid
+func (_m *MockChat) UserChats(ctx context.Context, uid []byte) ([]model.Chat, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserChats")
+	}
+
+	var r0 []model.Chat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) ([]model.Chat, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) []model.Chat); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockChat_UserChats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserChats'
+type MockChat_UserChats_Call struct {
+	*mock.Call
+}
+
+// UserChats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockChat_Expecter) UserChats(ctx interface{}, uid interface{}) *MockChat_UserChats_Call {
+	return &MockChat_UserChats_Call{Call: _e.mock.On("UserChats", ctx, uid)}
+}
+
+func (_c *MockChat_UserChats_Call) Run(run func(ctx context.Context, uid []byte)) *MockChat_UserChats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockChat_UserChats_Call) Return(_a0 []model.Chat, _a1 error) *MockChat_UserChats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockChat_UserChats_Call) RunAndReturn(run func(context.Context, []byte) ([]model.Chat, error)) *MockChat_UserChats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChat creates a new instance of MockChat. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChat(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChat {
+	mock := &MockChat{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,125 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockClient is an autogenerated mock type for the Client type
+type MockClient struct {
+	mock.Mock
+}
+
+type MockClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClient) EXPECT() *MockClient_Expecter {
+	return &MockClient_Expecter{mock: &_m.Mock}
+}
+
+// GetId provides a mock function with given fields:
+func (_m *MockClient) GetId() []byte {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetId")
+	}
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	return r0
+}
+
+// MockClient_GetId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetId'
+type MockClient_GetId_Call struct {
+	*mock.Call
+}
+
+// GetId is a helper method to define mock.On call
+func (_e *MockClient_Expecter) GetId() *MockClient_GetId_Call {
+	return &MockClient_GetId_Call{Call: _e.mock.On("GetId")}
+}
+
+func (_c *MockClient_GetId_Call) Run(run func()) *MockClient_GetId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClient_GetId_Call) Return(_a0 []byte) *MockClient_GetId_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_GetId_Call) RunAndReturn(run func() []byte) *MockClient_GetId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: msg
+func (_m *MockClient) Send(msg []byte) error {
+	ret := _m.Called(msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockClient_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - msg []byte
+func (_e *MockClient_Expecter) Send(msg interface{}) *MockClient_Send_Call {
+	return &MockClient_Send_Call{Call: _e.mock.On("Send", msg)}
+}
+
+func (_c *MockClient_Send_Call) Run(run func(msg []byte)) *MockClient_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClient_Send_Call) Return(_a0 error) *MockClient_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Send_Call) RunAndReturn(run func([]byte) error) *MockClient_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClient {
+	mock := &MockClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
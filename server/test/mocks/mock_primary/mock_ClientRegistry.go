@@ -0,0 +1,373 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	primary "github.com/dvid-messanger/internal/adapter/primary"
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockClientRegistry is an autogenerated mock type for the ClientRegistry type
+type MockClientRegistry struct {
+	mock.Mock
+}
+
+type MockClientRegistry_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClientRegistry) EXPECT() *MockClientRegistry_Expecter {
+	return &MockClientRegistry_Expecter{mock: &_m.Mock}
+}
+
+// Auth provides a mock function with given fields: clientId
+func (_m *MockClientRegistry) Auth(clientId []byte) (string, error) {
+	ret := _m.Called(clientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Auth")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte) (string, error)); ok {
+		return rf(clientId)
+	}
+	if rf, ok := ret.Get(0).(func([]byte) string); ok {
+		r0 = rf(clientId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(clientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClientRegistry_Auth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Auth'
+type MockClientRegistry_Auth_Call struct {
+	*mock.Call
+}
+
+// Auth is a helper method to define mock.On call
+//   - clientId []byte
+func (_e *MockClientRegistry_Expecter) Auth(clientId interface{}) *MockClientRegistry_Auth_Call {
+	return &MockClientRegistry_Auth_Call{Call: _e.mock.On("Auth", clientId)}
+}
+
+func (_c *MockClientRegistry_Auth_Call) Run(run func(clientId []byte)) *MockClientRegistry_Auth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_Auth_Call) Return(_a0 string, _a1 error) *MockClientRegistry_Auth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClientRegistry_Auth_Call) RunAndReturn(run func([]byte) (string, error)) *MockClientRegistry_Auth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: client
+func (_m *MockClientRegistry) Register(client primary.Client) error {
+	ret := _m.Called(client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primary.Client) error); ok {
+		r0 = rf(client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClientRegistry_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockClientRegistry_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - client primary.Client
+func (_e *MockClientRegistry_Expecter) Register(client interface{}) *MockClientRegistry_Register_Call {
+	return &MockClientRegistry_Register_Call{Call: _e.mock.On("Register", client)}
+}
+
+func (_c *MockClientRegistry_Register_Call) Run(run func(client primary.Client)) *MockClientRegistry_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(primary.Client))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_Register_Call) Return(_a0 error) *MockClientRegistry_Register_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientRegistry_Register_Call) RunAndReturn(run func(primary.Client) error) *MockClientRegistry_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceAuth provides a mock function with given fields: clientId, uid, auth
+func (_m *MockClientRegistry) ReplaceAuth(clientId []byte, uid []byte, auth string) error {
+	ret := _m.Called(clientId, uid, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceAuth")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, []byte, string) error); ok {
+		r0 = rf(clientId, uid, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClientRegistry_ReplaceAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceAuth'
+type MockClientRegistry_ReplaceAuth_Call struct {
+	*mock.Call
+}
+
+// ReplaceAuth is a helper method to define mock.On call
+//   - clientId []byte
+//   - uid []byte
+//   - auth string
+func (_e *MockClientRegistry_Expecter) ReplaceAuth(clientId interface{}, uid interface{}, auth interface{}) *MockClientRegistry_ReplaceAuth_Call {
+	return &MockClientRegistry_ReplaceAuth_Call{Call: _e.mock.On("ReplaceAuth", clientId, uid, auth)}
+}
+
+func (_c *MockClientRegistry_ReplaceAuth_Call) Run(run func(clientId []byte, uid []byte, auth string)) *MockClientRegistry_ReplaceAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].([]byte), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_ReplaceAuth_Call) Return(_a0 error) *MockClientRegistry_ReplaceAuth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientRegistry_ReplaceAuth_Call) RunAndReturn(run func([]byte, []byte, string) error) *MockClientRegistry_ReplaceAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAuth provides a mock function with given fields: clientId, auth
+func (_m *MockClientRegistry) SetAuth(clientId []byte, auth string) error {
+	ret := _m.Called(clientId, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAuth")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, string) error); ok {
+		r0 = rf(clientId, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClientRegistry_SetAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAuth'
+type MockClientRegistry_SetAuth_Call struct {
+	*mock.Call
+}
+
+// SetAuth is a helper method to define mock.On call
+//   - clientId []byte
+//   - auth string
+func (_e *MockClientRegistry_Expecter) SetAuth(clientId interface{}, auth interface{}) *MockClientRegistry_SetAuth_Call {
+	return &MockClientRegistry_SetAuth_Call{Call: _e.mock.On("SetAuth", clientId, auth)}
+}
+
+func (_c *MockClientRegistry_SetAuth_Call) Run(run func(clientId []byte, auth string)) *MockClientRegistry_SetAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_SetAuth_Call) Return(_a0 error) *MockClientRegistry_SetAuth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientRegistry_SetAuth_Call) RunAndReturn(run func([]byte, string) error) *MockClientRegistry_SetAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetInfo provides a mock function with given fields: clientId, user, chats
+func (_m *MockClientRegistry) SetInfo(clientId []byte, user *model.User, chats []model.Chat) error {
+	ret := _m.Called(clientId, user, chats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetInfo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, *model.User, []model.Chat) error); ok {
+		r0 = rf(clientId, user, chats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClientRegistry_SetInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInfo'
+type MockClientRegistry_SetInfo_Call struct {
+	*mock.Call
+}
+
+// SetInfo is a helper method to define mock.On call
+//   - clientId []byte
+//   - user *model.User
+//   - chats []model.Chat
+func (_e *MockClientRegistry_Expecter) SetInfo(clientId interface{}, user interface{}, chats interface{}) *MockClientRegistry_SetInfo_Call {
+	return &MockClientRegistry_SetInfo_Call{Call: _e.mock.On("SetInfo", clientId, user, chats)}
+}
+
+func (_c *MockClientRegistry_SetInfo_Call) Run(run func(clientId []byte, user *model.User, chats []model.Chat)) *MockClientRegistry_SetInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte), args[1].(*model.User), args[2].([]model.Chat))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_SetInfo_Call) Return(_a0 error) *MockClientRegistry_SetInfo_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientRegistry_SetInfo_Call) RunAndReturn(run func([]byte, *model.User, []model.Chat) error) *MockClientRegistry_SetInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unregister provides a mock function with given fields: clientId
+func (_m *MockClientRegistry) Unregister(clientId []byte) error {
+	ret := _m.Called(clientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unregister")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(clientId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClientRegistry_Unregister_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unregister'
+type MockClientRegistry_Unregister_Call struct {
+	*mock.Call
+}
+
+// Unregister is a helper method to define mock.On call
+//   - clientId []byte
+func (_e *MockClientRegistry_Expecter) Unregister(clientId interface{}) *MockClientRegistry_Unregister_Call {
+	return &MockClientRegistry_Unregister_Call{Call: _e.mock.On("Unregister", clientId)}
+}
+
+func (_c *MockClientRegistry_Unregister_Call) Run(run func(clientId []byte)) *MockClientRegistry_Unregister_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_Unregister_Call) Return(_a0 error) *MockClientRegistry_Unregister_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientRegistry_Unregister_Call) RunAndReturn(run func([]byte) error) *MockClientRegistry_Unregister_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnsetAuth provides a mock function with given fields: clientId
+func (_m *MockClientRegistry) UnsetAuth(clientId []byte) error {
+	ret := _m.Called(clientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsetAuth")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(clientId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClientRegistry_UnsetAuth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsetAuth'
+type MockClientRegistry_UnsetAuth_Call struct {
+	*mock.Call
+}
+
+// UnsetAuth is a helper method to define mock.On call
+//   - clientId []byte
+func (_e *MockClientRegistry_Expecter) UnsetAuth(clientId interface{}) *MockClientRegistry_UnsetAuth_Call {
+	return &MockClientRegistry_UnsetAuth_Call{Call: _e.mock.On("UnsetAuth", clientId)}
+}
+
+func (_c *MockClientRegistry_UnsetAuth_Call) Run(run func(clientId []byte)) *MockClientRegistry_UnsetAuth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClientRegistry_UnsetAuth_Call) Return(_a0 error) *MockClientRegistry_UnsetAuth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientRegistry_UnsetAuth_Call) RunAndReturn(run func([]byte) error) *MockClientRegistry_UnsetAuth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClientRegistry creates a new instance of MockClientRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClientRegistry(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClientRegistry {
+	mock := &MockClientRegistry{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
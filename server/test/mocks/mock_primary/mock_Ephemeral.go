@@ -0,0 +1,85 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEphemeral is an autogenerated mock type for the Ephemeral type
+type MockEphemeral struct {
+	mock.Mock
+}
+
+type MockEphemeral_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEphemeral) EXPECT() *MockEphemeral_Expecter {
+	return &MockEphemeral_Expecter{mock: &_m.Mock}
+}
+
+// Typing provides a mock function with given fields: ctx, clientId, uid, cid
+func (_m *MockEphemeral) Typing(ctx context.Context, clientId []byte, uid []byte, cid []byte) error {
+	ret := _m.Called(ctx, clientId, uid, cid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Typing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r0 = rf(ctx, clientId, uid, cid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEphemeral_Typing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Typing'
+type MockEphemeral_Typing_Call struct {
+	*mock.Call
+}
+
+// Typing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientId []byte
+//   - uid []byte
+//   - cid []byte
+func (_e *MockEphemeral_Expecter) Typing(ctx interface{}, clientId interface{}, uid interface{}, cid interface{}) *MockEphemeral_Typing_Call {
+	return &MockEphemeral_Typing_Call{Call: _e.mock.On("Typing", ctx, clientId, uid, cid)}
+}
+
+func (_c *MockEphemeral_Typing_Call) Run(run func(ctx context.Context, clientId []byte, uid []byte, cid []byte)) *MockEphemeral_Typing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockEphemeral_Typing_Call) Return(_a0 error) *MockEphemeral_Typing_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEphemeral_Typing_Call) RunAndReturn(run func(context.Context, []byte, []byte, []byte) error) *MockEphemeral_Typing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEphemeral creates a new instance of MockEphemeral. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEphemeral(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEphemeral {
+	mock := &MockEphemeral{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
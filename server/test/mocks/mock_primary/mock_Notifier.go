@@ -0,0 +1,511 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNotifier is an autogenerated mock type for the Notifier type
+type MockNotifier struct {
+	mock.Mock
+}
+
+type MockNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifier) EXPECT() *MockNotifier_Expecter {
+	return &MockNotifier_Expecter{mock: &_m.Mock}
+}
+
+// ChatUpdated provides a mock function with given fields: ctx, chat
+func (_m *MockNotifier) ChatUpdated(ctx context.Context, chat *model.Chat) error {
+	ret := _m.Called(ctx, chat)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChatUpdated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Chat) error); ok {
+		r0 = rf(ctx, chat)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_ChatUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChatUpdated'
+type MockNotifier_ChatUpdated_Call struct {
+	*mock.Call
+}
+
+// ChatUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chat *model.Chat
+func (_e *MockNotifier_Expecter) ChatUpdated(ctx interface{}, chat interface{}) *MockNotifier_ChatUpdated_Call {
+	return &MockNotifier_ChatUpdated_Call{Call: _e.mock.On("ChatUpdated", ctx, chat)}
+}
+
+func (_c *MockNotifier_ChatUpdated_Call) Run(run func(ctx context.Context, chat *model.Chat)) *MockNotifier_ChatUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Chat))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_ChatUpdated_Call) Return(_a0 error) *MockNotifier_ChatUpdated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_ChatUpdated_Call) RunAndReturn(run func(context.Context, *model.Chat) error) *MockNotifier_ChatUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessageDeleted provides a mock function with given fields: ctx, message
+func (_m *MockNotifier) MessageDeleted(ctx context.Context, message *model.ChatMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessageDeleted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ChatMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_MessageDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessageDeleted'
+type MockNotifier_MessageDeleted_Call struct {
+	*mock.Call
+}
+
+// MessageDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.ChatMessage
+func (_e *MockNotifier_Expecter) MessageDeleted(ctx interface{}, message interface{}) *MockNotifier_MessageDeleted_Call {
+	return &MockNotifier_MessageDeleted_Call{Call: _e.mock.On("MessageDeleted", ctx, message)}
+}
+
+func (_c *MockNotifier_MessageDeleted_Call) Run(run func(ctx context.Context, message *model.ChatMessage)) *MockNotifier_MessageDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_MessageDeleted_Call) Return(_a0 error) *MockNotifier_MessageDeleted_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_MessageDeleted_Call) RunAndReturn(run func(context.Context, *model.ChatMessage) error) *MockNotifier_MessageDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessageEdited provides a mock function with given fields: ctx, message
+func (_m *MockNotifier) MessageEdited(ctx context.Context, message *model.ChatMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessageEdited")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ChatMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_MessageEdited_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessageEdited'
+type MockNotifier_MessageEdited_Call struct {
+	*mock.Call
+}
+
+// MessageEdited is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.ChatMessage
+func (_e *MockNotifier_Expecter) MessageEdited(ctx interface{}, message interface{}) *MockNotifier_MessageEdited_Call {
+	return &MockNotifier_MessageEdited_Call{Call: _e.mock.On("MessageEdited", ctx, message)}
+}
+
+func (_c *MockNotifier_MessageEdited_Call) Run(run func(ctx context.Context, message *model.ChatMessage)) *MockNotifier_MessageEdited_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_MessageEdited_Call) Return(_a0 error) *MockNotifier_MessageEdited_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_MessageEdited_Call) RunAndReturn(run func(context.Context, *model.ChatMessage) error) *MockNotifier_MessageEdited_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessagesRead provides a mock function with given fields: ctx, receipt
+func (_m *MockNotifier) MessagesRead(ctx context.Context, receipt *model.ReadReceipt) error {
+	ret := _m.Called(ctx, receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessagesRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReadReceipt) error); ok {
+		r0 = rf(ctx, receipt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_MessagesRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessagesRead'
+type MockNotifier_MessagesRead_Call struct {
+	*mock.Call
+}
+
+// MessagesRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - receipt *model.ReadReceipt
+func (_e *MockNotifier_Expecter) MessagesRead(ctx interface{}, receipt interface{}) *MockNotifier_MessagesRead_Call {
+	return &MockNotifier_MessagesRead_Call{Call: _e.mock.On("MessagesRead", ctx, receipt)}
+}
+
+func (_c *MockNotifier_MessagesRead_Call) Run(run func(ctx context.Context, receipt *model.ReadReceipt)) *MockNotifier_MessagesRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ReadReceipt))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_MessagesRead_Call) Return(_a0 error) *MockNotifier_MessagesRead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_MessagesRead_Call) RunAndReturn(run func(context.Context, *model.ReadReceipt) error) *MockNotifier_MessagesRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChat provides a mock function with given fields: ctx, message
+func (_m *MockNotifier) NewChat(ctx context.Context, message *model.Chat) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewChat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Chat) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_NewChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewChat'
+type MockNotifier_NewChat_Call struct {
+	*mock.Call
+}
+
+// NewChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.Chat
+func (_e *MockNotifier_Expecter) NewChat(ctx interface{}, message interface{}) *MockNotifier_NewChat_Call {
+	return &MockNotifier_NewChat_Call{Call: _e.mock.On("NewChat", ctx, message)}
+}
+
+func (_c *MockNotifier_NewChat_Call) Run(run func(ctx context.Context, message *model.Chat)) *MockNotifier_NewChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Chat))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_NewChat_Call) Return(_a0 error) *MockNotifier_NewChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_NewChat_Call) RunAndReturn(run func(context.Context, *model.Chat) error) *MockNotifier_NewChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMessage provides a mock function with given fields: ctx, message
+func (_m *MockNotifier) NewMessage(ctx context.Context, message *model.ChatMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ChatMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_NewMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewMessage'
+type MockNotifier_NewMessage_Call struct {
+	*mock.Call
+}
+
+// NewMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *model.ChatMessage
+func (_e *MockNotifier_Expecter) NewMessage(ctx interface{}, message interface{}) *MockNotifier_NewMessage_Call {
+	return &MockNotifier_NewMessage_Call{Call: _e.mock.On("NewMessage", ctx, message)}
+}
+
+func (_c *MockNotifier_NewMessage_Call) Run(run func(ctx context.Context, message *model.ChatMessage)) *MockNotifier_NewMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ChatMessage))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_NewMessage_Call) Return(_a0 error) *MockNotifier_NewMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_NewMessage_Call) RunAndReturn(run func(context.Context, *model.ChatMessage) error) *MockNotifier_NewMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Presence provides a mock function with given fields: ctx, cid, uid, online
+func (_m *MockNotifier) Presence(ctx context.Context, cid []byte, uid []byte, online bool) error {
+	ret := _m.Called(ctx, cid, uid, online)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Presence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte, bool) error); ok {
+		r0 = rf(ctx, cid, uid, online)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_Presence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Presence'
+type MockNotifier_Presence_Call struct {
+	*mock.Call
+}
+
+// Presence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+//   - online bool
+func (_e *MockNotifier_Expecter) Presence(ctx interface{}, cid interface{}, uid interface{}, online interface{}) *MockNotifier_Presence_Call {
+	return &MockNotifier_Presence_Call{Call: _e.mock.On("Presence", ctx, cid, uid, online)}
+}
+
+func (_c *MockNotifier_Presence_Call) Run(run func(ctx context.Context, cid []byte, uid []byte, online bool)) *MockNotifier_Presence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_Presence_Call) Return(_a0 error) *MockNotifier_Presence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_Presence_Call) RunAndReturn(run func(context.Context, []byte, []byte, bool) error) *MockNotifier_Presence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProfileUpdated provides a mock function with given fields: ctx, cids, user
+func (_m *MockNotifier) ProfileUpdated(ctx context.Context, cids [][]byte, user *model.User) error {
+	ret := _m.Called(ctx, cids, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProfileUpdated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte, *model.User) error); ok {
+		r0 = rf(ctx, cids, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_ProfileUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProfileUpdated'
+type MockNotifier_ProfileUpdated_Call struct {
+	*mock.Call
+}
+
+// ProfileUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cids [][]byte
+//   - user *model.User
+func (_e *MockNotifier_Expecter) ProfileUpdated(ctx interface{}, cids interface{}, user interface{}) *MockNotifier_ProfileUpdated_Call {
+	return &MockNotifier_ProfileUpdated_Call{Call: _e.mock.On("ProfileUpdated", ctx, cids, user)}
+}
+
+func (_c *MockNotifier_ProfileUpdated_Call) Run(run func(ctx context.Context, cids [][]byte, user *model.User)) *MockNotifier_ProfileUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([][]byte), args[2].(*model.User))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_ProfileUpdated_Call) Return(_a0 error) *MockNotifier_ProfileUpdated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_ProfileUpdated_Call) RunAndReturn(run func(context.Context, [][]byte, *model.User) error) *MockNotifier_ProfileUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReactionUpdated provides a mock function with given fields: ctx, update
+func (_m *MockNotifier) ReactionUpdated(ctx context.Context, update *model.ReactionUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactionUpdated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReactionUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_ReactionUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReactionUpdated'
+type MockNotifier_ReactionUpdated_Call struct {
+	*mock.Call
+}
+
+// ReactionUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update *model.ReactionUpdate
+func (_e *MockNotifier_Expecter) ReactionUpdated(ctx interface{}, update interface{}) *MockNotifier_ReactionUpdated_Call {
+	return &MockNotifier_ReactionUpdated_Call{Call: _e.mock.On("ReactionUpdated", ctx, update)}
+}
+
+func (_c *MockNotifier_ReactionUpdated_Call) Run(run func(ctx context.Context, update *model.ReactionUpdate)) *MockNotifier_ReactionUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.ReactionUpdate))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_ReactionUpdated_Call) Return(_a0 error) *MockNotifier_ReactionUpdated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_ReactionUpdated_Call) RunAndReturn(run func(context.Context, *model.ReactionUpdate) error) *MockNotifier_ReactionUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Typing provides a mock function with given fields: ctx, cid, uid
+func (_m *MockNotifier) Typing(ctx context.Context, cid []byte, uid []byte) error {
+	ret := _m.Called(ctx, cid, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Typing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) error); ok {
+		r0 = rf(ctx, cid, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_Typing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Typing'
+type MockNotifier_Typing_Call struct {
+	*mock.Call
+}
+
+// Typing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cid []byte
+//   - uid []byte
+func (_e *MockNotifier_Expecter) Typing(ctx interface{}, cid interface{}, uid interface{}) *MockNotifier_Typing_Call {
+	return &MockNotifier_Typing_Call{Call: _e.mock.On("Typing", ctx, cid, uid)}
+}
+
+func (_c *MockNotifier_Typing_Call) Run(run func(ctx context.Context, cid []byte, uid []byte)) *MockNotifier_Typing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockNotifier_Typing_Call) Return(_a0 error) *MockNotifier_Typing_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_Typing_Call) RunAndReturn(run func(context.Context, []byte, []byte) error) *MockNotifier_Typing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotifier creates a new instance of MockNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifier {
+	mock := &MockNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockProfile is an autogenerated mock type for the Profile type
+type MockProfile struct {
+	mock.Mock
+}
+
+type MockProfile_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockProfile) EXPECT() *MockProfile_Expecter {
+	return &MockProfile_Expecter{mock: &_m.Mock}
+}
+
+// Update provides a mock function with given fields: ctx, uid, upd
+func (_m *MockProfile) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error) {
+	ret := _m.Called(ctx, uid, upd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) (*model.User, error)); ok {
+		return rf(ctx, uid, upd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) *model.User); ok {
+		r0 = rf(ctx, uid, upd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, model.ProfileUpdate) error); ok {
+		r1 = rf(ctx, uid, upd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfile_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockProfile_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - upd model.ProfileUpdate
+func (_e *MockProfile_Expecter) Update(ctx interface{}, uid interface{}, upd interface{}) *MockProfile_Update_Call {
+	return &MockProfile_Update_Call{Call: _e.mock.On("Update", ctx, uid, upd)}
+}
+
+func (_c *MockProfile_Update_Call) Run(run func(ctx context.Context, uid []byte, upd model.ProfileUpdate)) *MockProfile_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(model.ProfileUpdate))
+	})
+	return _c
+}
+
+func (_c *MockProfile_Update_Call) Return(_a0 *model.User, _a1 error) *MockProfile_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProfile_Update_Call) RunAndReturn(run func(context.Context, []byte, model.ProfileUpdate) (*model.User, error)) *MockProfile_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockProfile creates a new instance of MockProfile. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockProfile(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockProfile {
+	mock := &MockProfile{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
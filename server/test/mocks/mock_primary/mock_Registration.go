@@ -0,0 +1,98 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRegistration is an autogenerated mock type for the Registration type
+type MockRegistration struct {
+	mock.Mock
+}
+
+type MockRegistration_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRegistration) EXPECT() *MockRegistration_Expecter {
+	return &MockRegistration_Expecter{mock: &_m.Mock}
+}
+
+// Register provides a mock function with given fields: ctx, email, password, bio
+func (_m *MockRegistration) Register(ctx context.Context, email string, password string, bio string) (*model.User, error) {
+	ret := _m.Called(ctx, email, password, bio)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.User, error)); ok {
+		return rf(ctx, email, password, bio)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.User); ok {
+		r0 = rf(ctx, email, password, bio)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, email, password, bio)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRegistration_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockRegistration_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - bio string
+func (_e *MockRegistration_Expecter) Register(ctx interface{}, email interface{}, password interface{}, bio interface{}) *MockRegistration_Register_Call {
+	return &MockRegistration_Register_Call{Call: _e.mock.On("Register", ctx, email, password, bio)}
+}
+
+func (_c *MockRegistration_Register_Call) Run(run func(ctx context.Context, email string, password string, bio string)) *MockRegistration_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockRegistration_Register_Call) Return(_a0 *model.User, _a1 error) *MockRegistration_Register_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRegistration_Register_Call) RunAndReturn(run func(context.Context, string, string, string) (*model.User, error)) *MockRegistration_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRegistration creates a new instance of MockRegistration. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRegistration(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRegistration {
+	mock := &MockRegistration{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
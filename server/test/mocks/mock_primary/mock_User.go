@@ -0,0 +1,442 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mock_primary
+
+import (
+	context "context"
+
+	model "github.com/dvid-messanger/internal/core/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUser is an autogenerated mock type for the User type
+type MockUser struct {
+	mock.Mock
+}
+
+type MockUser_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUser) EXPECT() *MockUser_Expecter {
+	return &MockUser_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, email, bio
+func (_m *MockUser) Create(ctx context.Context, email string, bio string) (*model.User, error) {
+	ret := _m.Called(ctx, email, bio)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.User, error)); ok {
+		return rf(ctx, email, bio)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = rf(ctx, email, bio)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, bio)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUser_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUser_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - bio string
+func (_e *MockUser_Expecter) Create(ctx interface{}, email interface{}, bio interface{}) *MockUser_Create_Call {
+	return &MockUser_Create_Call{Call: _e.mock.On("Create", ctx, email, bio)}
+}
+
+func (_c *MockUser_Create_Call) Run(run func(ctx context.Context, email string, bio string)) *MockUser_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUser_Create_Call) Return(_a0 *model.User, _a1 error) *MockUser_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUser_Create_Call) RunAndReturn(run func(context.Context, string, string) (*model.User, error)) *MockUser_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, uid
+func (_m *MockUser) Delete(ctx context.Context, uid []byte) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUser_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUser_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockUser_Expecter) Delete(ctx interface{}, uid interface{}) *MockUser_Delete_Call {
+	return &MockUser_Delete_Call{Call: _e.mock.On("Delete", ctx, uid)}
+}
+
+func (_c *MockUser_Delete_Call) Run(run func(ctx context.Context, uid []byte)) *MockUser_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUser_Delete_Call) Return(_a0 error) *MockUser_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUser_Delete_Call) RunAndReturn(run func(context.Context, []byte) error) *MockUser_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, prefix, after, limit
+func (_m *MockUser) Search(ctx context.Context, query string, prefix bool, after []byte, limit int) (*model.UsersPage, error) {
+	ret := _m.Called(ctx, query, prefix, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *model.UsersPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, []byte, int) (*model.UsersPage, error)); ok {
+		return rf(ctx, query, prefix, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, []byte, int) *model.UsersPage); ok {
+		r0 = rf(ctx, query, prefix, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UsersPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, []byte, int) error); ok {
+		r1 = rf(ctx, query, prefix, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUser_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockUser_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - prefix bool
+//   - after []byte
+//   - limit int
+func (_e *MockUser_Expecter) Search(ctx interface{}, query interface{}, prefix interface{}, after interface{}, limit interface{}) *MockUser_Search_Call {
+	return &MockUser_Search_Call{Call: _e.mock.On("Search", ctx, query, prefix, after, limit)}
+}
+
+func (_c *MockUser_Search_Call) Run(run func(ctx context.Context, query string, prefix bool, after []byte, limit int)) *MockUser_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].([]byte), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockUser_Search_Call) Return(_a0 *model.UsersPage, _a1 error) *MockUser_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUser_Search_Call) RunAndReturn(run func(context.Context, string, bool, []byte, int) (*model.UsersPage, error)) *MockUser_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, uid, upd
+func (_m *MockUser) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (*model.User, error) {
+	ret := _m.Called(ctx, uid, upd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) (*model.User, error)); ok {
+		return rf(ctx, uid, upd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) *model.User); ok {
+		r0 = rf(ctx, uid, upd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, model.ProfileUpdate) error); ok {
+		r1 = rf(ctx, uid, upd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUser_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockUser_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - upd model.ProfileUpdate
+func (_e *MockUser_Expecter) Update(ctx interface{}, uid interface{}, upd interface{}) *MockUser_Update_Call {
+	return &MockUser_Update_Call{Call: _e.mock.On("Update", ctx, uid, upd)}
+}
+
+func (_c *MockUser_Update_Call) Run(run func(ctx context.Context, uid []byte, upd model.ProfileUpdate)) *MockUser_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(model.ProfileUpdate))
+	})
+	return _c
+}
+
+func (_c *MockUser_Update_Call) Return(_a0 *model.User, _a1 error) *MockUser_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUser_Update_Call) RunAndReturn(run func(context.Context, []byte, model.ProfileUpdate) (*model.User, error)) *MockUser_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// User provides a mock function with given fields: ctx, uid
+func (_m *MockUser) User(ctx context.Context, uid []byte) (*model.User, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for User")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (*model.User, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *model.User); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUser_User_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'User'
+type MockUser_User_Call struct {
+	*mock.Call
+}
+
+// User is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockUser_Expecter) User(ctx interface{}, uid interface{}) *MockUser_User_Call {
+	return &MockUser_User_Call{Call: _e.mock.On("User", ctx, uid)}
+}
+
+func (_c *MockUser_User_Call) Run(run func(ctx context.Context, uid []byte)) *MockUser_User_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUser_User_Call) Return(_a0 *model.User, _a1 error) *MockUser_User_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUser_User_Call) RunAndReturn(run func(context.Context, []byte) (*model.User, error)) *MockUser_User_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Users provides a mock function with given fields: ctx
+func (_m *MockUser) Users(ctx context.Context) ([]model.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Users")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUser_Users_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Users'
+type MockUser_Users_Call struct {
+	*mock.Call
+}
+
+// Users is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUser_Expecter) Users(ctx interface{}) *MockUser_Users_Call {
+	return &MockUser_Users_Call{Call: _e.mock.On("Users", ctx)}
+}
+
+func (_c *MockUser_Users_Call) Run(run func(ctx context.Context)) *MockUser_Users_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUser_Users_Call) Return(_a0 []model.User, _a1 error) *MockUser_Users_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUser_Users_Call) RunAndReturn(run func(context.Context) ([]model.User, error)) *MockUser_Users_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersByIds provides a mock function with given fields: ctx, uids
+func (_m *MockUser) UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error) {
+	ret := _m.Called(ctx, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersByIds")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte) ([]model.User, error)); ok {
+		return rf(ctx, uids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte) []model.User); ok {
+		r0 = rf(ctx, uids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, [][]byte) error); ok {
+		r1 = rf(ctx, uids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUser_UsersByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersByIds'
+type MockUser_UsersByIds_Call struct {
+	*mock.Call
+}
+
+// UsersByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uids [][]byte
+func (_e *MockUser_Expecter) UsersByIds(ctx interface{}, uids interface{}) *MockUser_UsersByIds_Call {
+	return &MockUser_UsersByIds_Call{Call: _e.mock.On("UsersByIds", ctx, uids)}
+}
+
+func (_c *MockUser_UsersByIds_Call) Run(run func(ctx context.Context, uids [][]byte)) *MockUser_UsersByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([][]byte))
+	})
+	return _c
+}
+
+func (_c *MockUser_UsersByIds_Call) Return(_a0 []model.User, _a1 error) *MockUser_UsersByIds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUser_UsersByIds_Call) RunAndReturn(run func(context.Context, [][]byte) ([]model.User, error)) *MockUser_UsersByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUser creates a new instance of MockUser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUser {
+	mock := &MockUser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -22,6 +22,66 @@ func (_m *MockUserProvider) EXPECT() *MockUserProvider_Expecter {
 	return &MockUserProvider_Expecter{mock: &_m.Mock}
 }
 
+// Search provides a mock function with given fields: ctx, query, prefix, after, limit
+func (_m *MockUserProvider) Search(ctx context.Context, query string, prefix bool, after []byte, limit int) (model.UsersPage, error) {
+	ret := _m.Called(ctx, query, prefix, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 model.UsersPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, []byte, int) (model.UsersPage, error)); ok {
+		return rf(ctx, query, prefix, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, []byte, int) model.UsersPage); ok {
+		r0 = rf(ctx, query, prefix, after, limit)
+	} else {
+		r0 = ret.Get(0).(model.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, []byte, int) error); ok {
+		r1 = rf(ctx, query, prefix, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserProvider_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockUserProvider_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - prefix bool
+//   - after []byte
+//   - limit int
+func (_e *MockUserProvider_Expecter) Search(ctx interface{}, query interface{}, prefix interface{}, after interface{}, limit interface{}) *MockUserProvider_Search_Call {
+	return &MockUserProvider_Search_Call{Call: _e.mock.On("Search", ctx, query, prefix, after, limit)}
+}
+
+func (_c *MockUserProvider_Search_Call) Run(run func(ctx context.Context, query string, prefix bool, after []byte, limit int)) *MockUserProvider_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].([]byte), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserProvider_Search_Call) Return(_a0 model.UsersPage, _a1 error) *MockUserProvider_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserProvider_Search_Call) RunAndReturn(run func(context.Context, string, bool, []byte, int) (model.UsersPage, error)) *MockUserProvider_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // User provides a mock function with given fields: ctx, uid
 func (_m *MockUserProvider) User(ctx context.Context, uid []byte) (model.User, error) {
 	ret := _m.Called(ctx, uid)
@@ -137,6 +197,65 @@ func (_c *MockUserProvider_Users_Call) RunAndReturn(run func(context.Context) ([
 	return _c
 }
 
+// UsersByIds provides a mock function with given fields: ctx, uids
+func (_m *MockUserProvider) UsersByIds(ctx context.Context, uids [][]byte) ([]model.User, error) {
+	ret := _m.Called(ctx, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersByIds")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte) ([]model.User, error)); ok {
+		return rf(ctx, uids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte) []model.User); ok {
+		r0 = rf(ctx, uids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, [][]byte) error); ok {
+		r1 = rf(ctx, uids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserProvider_UsersByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersByIds'
+type MockUserProvider_UsersByIds_Call struct {
+	*mock.Call
+}
+
+// UsersByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uids [][]byte
+func (_e *MockUserProvider_Expecter) UsersByIds(ctx interface{}, uids interface{}) *MockUserProvider_UsersByIds_Call {
+	return &MockUserProvider_UsersByIds_Call{Call: _e.mock.On("UsersByIds", ctx, uids)}
+}
+
+func (_c *MockUserProvider_UsersByIds_Call) Run(run func(ctx context.Context, uids [][]byte)) *MockUserProvider_UsersByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([][]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserProvider_UsersByIds_Call) Return(_a0 []model.User, _a1 error) *MockUserProvider_UsersByIds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserProvider_UsersByIds_Call) RunAndReturn(run func(context.Context, [][]byte) ([]model.User, error)) *MockUserProvider_UsersByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserProvider creates a new instance of MockUserProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserProvider(t interface {
@@ -22,6 +22,53 @@ func (_m *MockUserSaver) EXPECT() *MockUserSaver_Expecter {
 	return &MockUserSaver_Expecter{mock: &_m.Mock}
 }
 
+// Delete provides a mock function with given fields: ctx, uid
+func (_m *MockUserSaver) Delete(ctx context.Context, uid []byte) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserSaver_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserSaver_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+func (_e *MockUserSaver_Expecter) Delete(ctx interface{}, uid interface{}) *MockUserSaver_Delete_Call {
+	return &MockUserSaver_Delete_Call{Call: _e.mock.On("Delete", ctx, uid)}
+}
+
+func (_c *MockUserSaver_Delete_Call) Run(run func(ctx context.Context, uid []byte)) *MockUserSaver_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserSaver_Delete_Call) Return(_a0 error) *MockUserSaver_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSaver_Delete_Call) RunAndReturn(run func(context.Context, []byte) error) *MockUserSaver_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, email, bio
 func (_m *MockUserSaver) Save(ctx context.Context, email string, bio string) (model.User, error) {
 	ret := _m.Called(ctx, email, bio)
@@ -80,6 +127,64 @@ func (_c *MockUserSaver_Save_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// Update provides a mock function with given fields: ctx, uid, upd
+func (_m *MockUserSaver) Update(ctx context.Context, uid []byte, upd model.ProfileUpdate) (model.User, error) {
+	ret := _m.Called(ctx, uid, upd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) (model.User, error)); ok {
+		return rf(ctx, uid, upd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, model.ProfileUpdate) model.User); ok {
+		r0 = rf(ctx, uid, upd)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, model.ProfileUpdate) error); ok {
+		r1 = rf(ctx, uid, upd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserSaver_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockUserSaver_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid []byte
+//   - upd model.ProfileUpdate
+func (_e *MockUserSaver_Expecter) Update(ctx interface{}, uid interface{}, upd interface{}) *MockUserSaver_Update_Call {
+	return &MockUserSaver_Update_Call{Call: _e.mock.On("Update", ctx, uid, upd)}
+}
+
+func (_c *MockUserSaver_Update_Call) Run(run func(ctx context.Context, uid []byte, upd model.ProfileUpdate)) *MockUserSaver_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte), args[2].(model.ProfileUpdate))
+	})
+	return _c
+}
+
+func (_c *MockUserSaver_Update_Call) Return(_a0 model.User, _a1 error) *MockUserSaver_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserSaver_Update_Call) RunAndReturn(run func(context.Context, []byte, model.ProfileUpdate) (model.User, error)) *MockUserSaver_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserSaver creates a new instance of MockUserSaver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserSaver(t interface {